@@ -0,0 +1,255 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/pkg/client"
+)
+
+const (
+	outputTable = "table"
+	outputText  = "text"
+	outputJSON  = "json"
+	// maxPerPage is the largest page the API returns.
+	maxPerPage = 100
+)
+
+// listFlag is a repeatable flag whose values may also be comma-separated.
+type listFlag []string
+
+func (f *listFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *listFlag) Set(value string) error {
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			*f = append(*f, v)
+		}
+	}
+	return nil
+}
+
+// timeFlag accepts an RFC 3339 timestamp, a YYYY-MM-DD date (UTC), or a duration before
+// now such as 90m, 24h or 7d.
+type timeFlag struct {
+	t   time.Time
+	now func() time.Time
+}
+
+func (f *timeFlag) String() string {
+	if f.t.IsZero() {
+		return ""
+	}
+	return f.t.Format(time.RFC3339)
+}
+
+func (f *timeFlag) Set(value string) error {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		f.t = t
+		return nil
+	}
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		f.t = t
+		return nil
+	}
+	now := time.Now
+	if f.now != nil {
+		now = f.now
+	}
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			f.t = now().AddDate(0, 0, -n)
+			return nil
+		}
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		f.t = now().Add(-d)
+		return nil
+	}
+	return errors.New("expected RFC 3339 timestamp, YYYY-MM-DD or a duration like 24h or 7d")
+}
+
+// filterFlags are the event filters shared by list and tail.
+type filterFlags struct {
+	types   listFlag
+	repos   listFlag
+	owners  listFlag
+	senders listFlag
+	actions listFlag
+	since   timeFlag
+	until   timeFlag
+	query   string
+}
+
+func (f *filterFlags) register(fs *flag.FlagSet) {
+	fs.Var(&f.types, "type", "event type, e.g. pull_request (repeatable, comma-separated)")
+	fs.Var(&f.repos, "repo", "repository owner/name (repeatable, comma-separated)")
+	fs.Var(&f.owners, "owner", "repository owner or organization (repeatable, comma-separated)")
+	fs.Var(&f.senders, "sender", "sender login (repeatable, comma-separated)")
+	fs.Var(&f.actions, "action", "action, e.g. opened (repeatable, comma-separated)")
+	fs.Var(&f.since, "since", "only events received at or after: RFC 3339, YYYY-MM-DD or a duration like 24h or 7d")
+	fs.Var(&f.until, "until", "only events received before: RFC 3339, YYYY-MM-DD or a duration")
+	fs.StringVar(&f.query, "q", "", `search string, e.g. "is:merged author:alice flaky"`)
+}
+
+func (f *filterFlags) listOptions() client.ListOptions {
+	return client.ListOptions{
+		EventTypes: f.types,
+		Repos:      f.repos,
+		Owners:     f.owners,
+		Senders:    f.senders,
+		Actions:    f.actions,
+		Since:      f.since.t,
+		Until:      f.until.t,
+		Query:      f.query,
+	}
+}
+
+func runList(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	var conn connectionFlags
+	var filters filterFlags
+	conn.register(fs)
+	filters.register(fs)
+	limit := fs.Int("limit", 20, "maximum number of events to show")
+	cursor := fs.String("cursor", "", "continue from a cursor returned by the API")
+	output := fs.String("output", outputTable, "output format: table or json")
+	if err := parseFlags(fs, args, stderr); err != nil {
+		return err
+	}
+	if *output != outputTable && *output != outputJSON {
+		fmt.Fprintf(stderr, "invalid --output %q: must be table or json\n", *output)
+		return errUsage
+	}
+	if *limit < 1 {
+		fmt.Fprintln(stderr, "invalid --limit: must be positive")
+		return errUsage
+	}
+	c, err := conn.client()
+	if err != nil {
+		return err
+	}
+	opts := filters.listOptions()
+	opts.PerPage = min(*limit, maxPerPage)
+	opts.Cursor = *cursor
+	events := make([]client.Event, 0, opts.PerPage)
+	for event, err := range c.AllEvents(ctx, opts) {
+		if err != nil {
+			return err
+		}
+		events = append(events, event)
+		if len(events) == *limit {
+			break
+		}
+	}
+	if *output == outputJSON {
+		return writeJSON(stdout, events)
+	}
+	return writeTable(stdout, events)
+}
+
+func runShow(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) error {
+	fs := flag.NewFlagSet("show", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: dashboard show [flags] <id>")
+		fs.PrintDefaults()
+	}
+	var conn connectionFlags
+	conn.register(fs)
+	output := fs.String("output", outputText, "output format: text or json")
+	if err := parseFlags(fs, args, stderr); err != nil {
+		return err
+	}
+	if fs.NArg() < 1 {
+		fs.Usage()
+		return errUsage
+	}
+	idArg := fs.Arg(0)
+	// Allow flags after the ID as well: dashboard show 42 --output json.
+	if err := parseFlags(fs, fs.Args()[1:], stderr); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return errUsage
+	}
+	id, err := strconv.ParseInt(idArg, 10, 64)
+	if err != nil || id < 1 {
+		fmt.Fprintf(stderr, "invalid event id %q\n", idArg)
+		return errUsage
+	}
+	if *output != outputText && *output != outputJSON {
+		fmt.Fprintf(stderr, "invalid --output %q: must be text or json\n", *output)
+		return errUsage
+	}
+	c, err := conn.client()
+	if err != nil {
+		return err
+	}
+	event, err := c.GetEvent(ctx, id)
+	if client.IsNotFound(err) {
+		return fmt.Errorf("event %d not found", id)
+	}
+	if err != nil {
+		return err
+	}
+	if *output == outputJSON {
+		return writeJSON(stdout, event)
+	}
+	return writeDetail(stdout, event)
+}
+
+func runTail(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) error {
+	fs := flag.NewFlagSet("tail", flag.ContinueOnError)
+	var conn connectionFlags
+	var filters filterFlags
+	conn.register(fs)
+	filters.register(fs)
+	output := fs.String("output", outputText, "output format: text or json (one event per line)")
+	color := fs.String("color", colorAuto, "colorize output: auto, always or never")
+	if err := parseFlags(fs, args, stderr); err != nil {
+		return err
+	}
+	if *output != outputText && *output != outputJSON {
+		fmt.Fprintf(stderr, "invalid --output %q: must be text or json\n", *output)
+		return errUsage
+	}
+	if *color != colorAuto && *color != colorAlways && *color != colorNever {
+		fmt.Fprintf(stderr, "invalid --color %q: must be auto, always or never\n", *color)
+		return errUsage
+	}
+	c, err := conn.client()
+	if err != nil {
+		return err
+	}
+	stream, err := c.StreamEvents(ctx, client.StreamOptions{Filter: filters.listOptions()})
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+	fmt.Fprintf(stderr, "Following events from %s (Ctrl-C to stop)\n", conn.server)
+	p := newPrinter(stdout, useColor(*color, stdout))
+	enc := json.NewEncoder(stdout)
+	for event := range stream.Events() {
+		if *output == outputJSON {
+			if err := enc.Encode(event); err != nil {
+				return err
+			}
+			continue
+		}
+		p.printLine(&event)
+	}
+	if ctx.Err() != nil {
+		return nil
+	}
+	return stream.Err()
+}
@@ -0,0 +1,107 @@
+// Command dashboard queries and tails GitHub events from the dashboard API.
+//
+//	dashboard list --repo acme/api --q "is:merged" --since 7d
+//	dashboard show 42
+//	dashboard tail --type pull_request,issues
+//
+// The server URL and API token are read from DASHBOARD_URL and DASHBOARD_TOKEN, or from
+// the --server and --token flags. Create a token with POST /api/tokens.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/pkg/client"
+)
+
+const defaultServerURL = "http://localhost:8080"
+
+const usage = `Usage: dashboard <command> [flags]
+
+Commands:
+  list       List events matching filters
+  show <id>  Show a single event
+  tail       Follow new events as they arrive
+
+Run "dashboard <command> -h" for the flags of a command.
+`
+
+// errUsage is returned for invalid command lines, after the usage has been printed.
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	err := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	switch {
+	case errors.Is(err, flag.ErrHelp):
+	case errors.Is(err, errUsage):
+		os.Exit(2)
+	case err != nil:
+		fmt.Fprintf(os.Stderr, "dashboard: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, stdout io.Writer, stderr io.Writer) error {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return errUsage
+	}
+	switch args[0] {
+	case "list":
+		return runList(ctx, args[1:], stdout, stderr)
+	case "show":
+		return runShow(ctx, args[1:], stdout, stderr)
+	case "tail":
+		return runTail(ctx, args[1:], stdout, stderr)
+	case "-h", "-help", "--help", "help":
+		fmt.Fprint(stdout, usage)
+		return nil
+	default:
+		fmt.Fprintf(stderr, "unknown command %q\n\n%s", args[0], usage)
+		return errUsage
+	}
+}
+
+// connectionFlags are the flags shared by every command.
+type connectionFlags struct {
+	server string
+	token  string
+}
+
+func (f *connectionFlags) register(fs *flag.FlagSet) {
+	server := os.Getenv("DASHBOARD_URL")
+	if server == "" {
+		server = defaultServerURL
+	}
+	fs.StringVar(&f.server, "server", server, "dashboard API base URL (env DASHBOARD_URL)")
+	fs.StringVar(&f.token, "token", os.Getenv("DASHBOARD_TOKEN"), "API token (env DASHBOARD_TOKEN)")
+}
+
+func (f *connectionFlags) client() (*client.Client, error) {
+	if f.token == "" {
+		return nil, errors.New("an API token is required: set DASHBOARD_TOKEN or pass --token")
+	}
+	return client.New(f.server, client.WithToken(f.token))
+}
+
+// parseFlags parses a command's flags, printing errors and help to stderr. It returns
+// flag.ErrHelp when help was requested.
+func parseFlags(fs *flag.FlagSet, args []string, stderr io.Writer) error {
+	fs.SetOutput(stderr)
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimeFlagSet(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		value       string
+		expected    time.Time
+		expectedErr bool
+	}{
+		{name: "RFC 3339", value: "2024-03-01T09:30:00Z", expected: time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)},
+		{name: "date", value: "2024-03-01", expected: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "days", value: "7d", expected: now.AddDate(0, 0, -7)},
+		{name: "duration", value: "90m", expected: now.Add(-90 * time.Minute)},
+		{name: "negative duration", value: "-1h", expectedErr: true},
+		{name: "negative days", value: "-2d", expectedErr: true},
+		{name: "garbage", value: "yesterday", expectedErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := timeFlag{now: func() time.Time { return now }}
+			err := f.Set(tt.value)
+			if (err != nil) != tt.expectedErr {
+				t.Fatalf("expected error %v for Set(%q), got %v", tt.expectedErr, tt.value, err)
+			}
+			if !tt.expectedErr && !f.t.Equal(tt.expected) {
+				t.Errorf("expected Set(%q) to be %v, got %v", tt.value, tt.expected, f.t)
+			}
+		})
+	}
+}
+
+func TestListFlagSet(t *testing.T) {
+	var f listFlag
+	for _, v := range []string{"issues", "push, pull_request", ",release,"} {
+		if err := f.Set(v); err != nil {
+			t.Fatalf("Set(%q) error = %v", v, err)
+		}
+	}
+	expected := listFlag{"issues", "push", "pull_request", "release"}
+	if !reflect.DeepEqual(f, expected) {
+		t.Errorf("expected listFlag to be %v, got %v", expected, f)
+	}
+}
+
+func TestRunUsageErrors(t *testing.T) {
+	t.Setenv("DASHBOARD_TOKEN", "")
+	tests := []struct {
+		name           string
+		args           []string
+		expectedErr    error
+		expectedStderr string
+	}{
+		{name: "no command", args: nil, expectedErr: errUsage, expectedStderr: "Usage: dashboard"},
+		{name: "unknown command", args: []string{"frobnicate"}, expectedErr: errUsage, expectedStderr: `unknown command "frobnicate"`},
+		{name: "help", args: []string{"list", "-h"}, expectedErr: flag.ErrHelp, expectedStderr: "-limit"},
+		{name: "unknown flag", args: []string{"list", "--nope"}, expectedErr: errUsage, expectedStderr: "flag provided but not defined"},
+		{name: "bad limit", args: []string{"list", "--limit", "0"}, expectedErr: errUsage, expectedStderr: "invalid --limit"},
+		{name: "bad output", args: []string{"list", "--output", "xml"}, expectedErr: errUsage, expectedStderr: "invalid --output"},
+		{name: "bad since", args: []string{"list", "--since", "soon"}, expectedErr: errUsage, expectedStderr: "invalid value"},
+		{name: "show without id", args: []string{"show"}, expectedErr: errUsage, expectedStderr: "Usage: dashboard show"},
+		{name: "show bad id", args: []string{"show", "abc"}, expectedErr: errUsage, expectedStderr: `invalid event id "abc"`},
+		{name: "show extra args", args: []string{"show", "1", "2"}, expectedErr: errUsage, expectedStderr: "Usage: dashboard show"},
+		{name: "show flag after id", args: []string{"show", "1", "--output", "yaml"}, expectedErr: errUsage, expectedStderr: "invalid --output"},
+		{name: "tail bad color", args: []string{"tail", "--color", "rainbow"}, expectedErr: errUsage, expectedStderr: "invalid --color"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			err := run(context.Background(), tt.args, &stdout, &stderr)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("expected run(%q) error to be %v, got %v", tt.args, tt.expectedErr, err)
+			}
+			if !strings.Contains(stderr.String(), tt.expectedStderr) {
+				t.Errorf("expected stderr to contain %q, got %q", tt.expectedStderr, stderr.String())
+			}
+		})
+	}
+}
+
+func TestRunRequiresToken(t *testing.T) {
+	t.Setenv("DASHBOARD_TOKEN", "")
+	var stdout, stderr bytes.Buffer
+	err := run(context.Background(), []string{"list"}, &stdout, &stderr)
+	if err == nil || !strings.Contains(err.Error(), "API token is required") {
+		t.Fatalf("expected a missing token error from run(list), got %v", err)
+	}
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+	"unicode/utf8"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/pkg/client"
+)
+
+const (
+	colorAuto   = "auto"
+	colorAlways = "always"
+	colorNever  = "never"
+
+	ansiReset   = "\x1b[0m"
+	ansiDim     = "\x1b[2m"
+	ansiBold    = "\x1b[1m"
+	ansiRed     = "\x1b[31m"
+	ansiGreen   = "\x1b[32m"
+	ansiYellow  = "\x1b[33m"
+	ansiBlue    = "\x1b[34m"
+	ansiMagenta = "\x1b[35m"
+	ansiCyan    = "\x1b[36m"
+
+	// maxTitleWidth truncates titles in tables and tail lines.
+	maxTitleWidth = 60
+)
+
+// eventTypeColors assigns a color to the common event types; others are cyan.
+var eventTypeColors = map[string]string{
+	"issues":              ansiGreen,
+	"issue_comment":       ansiGreen,
+	"pull_request":        ansiMagenta,
+	"pull_request_review": ansiMagenta,
+	"push":                ansiBlue,
+	"release":             ansiYellow,
+	"deployment_status":   ansiYellow,
+	"workflow_run":        ansiRed,
+}
+
+// useColor resolves the --color mode. In auto mode color is used when w is a terminal and
+// NO_COLOR is not set.
+func useColor(mode string, w io.Writer) bool {
+	switch mode {
+	case colorAlways:
+		return true
+	case colorNever:
+		return false
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// printer writes one-line event summaries for tail.
+type printer struct {
+	w     io.Writer
+	color bool
+}
+
+func newPrinter(w io.Writer, color bool) *printer {
+	return &printer{w: w, color: color}
+}
+
+func (p *printer) paint(color string, s string) string {
+	if !p.color || s == "" {
+		return s
+	}
+	return color + s + ansiReset
+}
+
+// printLine writes "15:04:05 type.action repo sender title url".
+func (p *printer) printLine(e *client.Event) {
+	typeColor, ok := eventTypeColors[e.EventType]
+	if !ok {
+		typeColor = ansiCyan
+	}
+	fmt.Fprintf(p.w, "%s %s %s %s %s %s\n",
+		p.paint(ansiDim, e.ReceivedAt.Local().Format(time.TimeOnly)),
+		p.paint(typeColor, eventKind(e)),
+		p.paint(ansiBold, e.RepoName),
+		e.SenderLogin,
+		truncate(derefString(e.Title), maxTitleWidth),
+		p.paint(ansiDim, e.HTMLURL),
+	)
+}
+
+func writeTable(w io.Writer, events []client.Event) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tRECEIVED\tEVENT\tREPO\tSENDER\tTITLE")
+	for i := range events {
+		e := &events[i]
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\n",
+			e.ID, e.ReceivedAt.Local().Format(time.DateTime), eventKind(e), e.RepoName, e.SenderLogin,
+			truncate(singleLine(derefString(e.Title)), maxTitleWidth))
+	}
+	return tw.Flush()
+}
+
+func writeDetail(w io.Writer, e *client.Event) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "ID:\t%d\n", e.ID)
+	fmt.Fprintf(tw, "Delivery:\t%s\n", e.DeliveryID)
+	fmt.Fprintf(tw, "Event:\t%s\n", eventKind(e))
+	fmt.Fprintf(tw, "Repository:\t%s\n", e.RepoName)
+	fmt.Fprintf(tw, "Sender:\t%s\n", e.SenderLogin)
+	fmt.Fprintf(tw, "Title:\t%s\n", derefString(e.Title))
+	fmt.Fprintf(tw, "URL:\t%s\n", e.HTMLURL)
+	fmt.Fprintf(tw, "Occurred:\t%s\n", e.OccurredAt.Local().Format(time.RFC3339))
+	fmt.Fprintf(tw, "Received:\t%s\n", e.ReceivedAt.Local().Format(time.RFC3339))
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if body := strings.TrimSpace(derefString(e.Body)); body != "" {
+		_, err := fmt.Fprintf(w, "\n%s\n", body)
+		return err
+	}
+	return nil
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// eventKind returns "type.action", or just the type for events without an action.
+func eventKind(e *client.Event) string {
+	if e.Action == "" {
+		return e.EventType
+	}
+	return e.EventType + "." + e.Action
+}
+
+func truncate(s string, width int) string {
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:width-1]) + "…"
+}
+
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -4,24 +4,33 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"strings"
 	"syscall"
 	"time"
+	_ "time/tzdata"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/gorilla/sessions"
 	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/auth"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/broadcast"
 	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/config"
 	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/crypto"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/eventbus"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/graph"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/grpcapi"
 	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/handler"
 	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/middleware"
 	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
 	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/repository"
 	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/service"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/sla"
 	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/sse"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 func main() {
@@ -39,8 +48,21 @@ func main() {
 	r.Use(middleware.Logger)
 	r.Use(middleware.SecurityHeaders)
 	r.Use(middleware.CORS(cfg.FrontendURL))
-	sseHub := sse.NewHub()
+	sseHub := sse.NewHub(sse.HubOptions{
+		QueueSize:    cfg.HubQueueSize,
+		Overflow:     sse.OverflowPolicy(cfg.HubOverflowPolicy),
+		ClientBuffer: cfg.HubClientBuffer,
+	})
 	go sseHub.Run()
+	runCtx, stopRun := context.WithCancel(context.Background())
+	defer stopRun()
+	var broadcaster broadcast.Backend = broadcast.NewMemory()
+	if cfg.BroadcastBackend == "mysql" {
+		broadcaster = broadcast.NewMySQL(repository.NewOutboxRepository(db), broadcast.MySQLOptions{
+			PollInterval: cfg.BroadcastPollInterval,
+			Retention:    cfg.BroadcastRetention,
+		})
+	}
 	tokenEncryptor, err := crypto.NewTokenEncryptor(cfg.TokenEncryptionKey)
 	if err != nil {
 		log.Fatalf("failed to initialize token encryptor: %v", err)
@@ -48,9 +70,42 @@ func main() {
 	eventRepo := repository.NewEventRepository(db)
 	userRepo := repository.NewUserRepository(db, tokenEncryptor)
 	eventService := service.NewEventService(eventRepo)
-	handler.BroadcastFunc = func(event model.Event) {
-		sseHub.Broadcast(event)
+	statsService := service.NewStatsService(repository.NewStatsRepository(db))
+	go broadcaster.Subscribe(runCtx, func(change model.EventChange) {
+		// Clients refetch the statistics on stats_updated, so drop the cached ones first.
+		if change.Type != model.EventUpdated {
+			statsService.Invalidate()
+		}
+		sseHub.PublishChange(change)
+	})
+	contributorService := service.NewContributorService(repository.NewContributorRepository(db), eventRepo)
+	metricsService := service.NewMetricsService(repository.NewMetricsRepository(db), service.DORAConfig{
+		ProductionEnvironments: cfg.ProductionEnvironments,
+		DeployWorkflows:        cfg.DeployWorkflows,
+	})
+	slaCalendar, err := sla.NewCalendar(cfg.SLABusinessHours, cfg.SLABusinessDays, cfg.SLATimezone)
+	if err != nil {
+		log.Fatalf("invalid SLA business hours: %v", err)
 	}
+	slaService := service.NewSLAService(repository.NewSLARepository(db), service.SLAConfig{
+		Maintainers:    cfg.SLAMaintainers,
+		Calendar:       slaCalendar,
+		ResponseTarget: cfg.SLAResponseTarget,
+	})
+	feedService := service.NewFeedService(repository.NewFeedRepository(db))
+	apiTokenService := service.NewAPITokenService(repository.NewAPITokenRepository(db))
+	// Presence is counted per replica, so it is disabled with a backend for several replicas.
+	var presenceService *service.PresenceService
+	if cfg.BroadcastBackend == "memory" {
+		presenceService = service.NewPresenceService(sseHub, userRepo)
+		go presenceService.Run(runCtx)
+	} else {
+		middleware.LogEvent("info", "presence is disabled", map[string]interface{}{
+			"broadcast_backend": cfg.BroadcastBackend,
+		})
+	}
+	bus := eventbus.New()
+	bus.Subscribe("broadcast", broadcaster.Publish)
 	secureCookie := strings.HasPrefix(cfg.FrontendURL, "https://")
 	sessionManager := auth.NewSessionManager(cfg.SessionSecret, secureCookie)
 	oauthHandler := auth.NewOAuthHandler(cfg.GitHubClientID, cfg.GitHubClientSecret, cfg.FrontendURL, sessionManager, userRepo)
@@ -63,20 +118,70 @@ func main() {
 		SameSite: http.SameSiteLaxMode,
 	}
 	healthHandler := handler.NewHealthHandler(db)
-	webhookHandler := handler.NewWebhookHandler(cfg.GitHubWebhookSecret, eventService)
+	hubHandler := handler.NewHubHandler(sseHub)
+	webhookHandler := handler.NewWebhookHandler(cfg.GitHubWebhookSecret, eventService, bus)
 	eventsHandler := handler.NewEventsHandler(eventService)
-	sseHandler := handler.NewSSEHandler(sseHub)
+	exportHandler := handler.NewExportHandler(eventService, cfg.ExportMaxRows)
+	feedsHandler := handler.NewFeedsHandler(feedService, eventService, cfg.PublicURL, cfg.FrontendURL)
+	sseHandler := handler.NewSSEHandler(sseHub, eventService, handler.SSEOptions{
+		HeartbeatInterval: cfg.SSEHeartbeatInterval,
+		Retry:             cfg.SSERetry,
+		MaxLifetime:       cfg.SSEMaxLifetime,
+	})
+	wsHandler := handler.NewWebSocketHandler(sseHub, cfg.FrontendURL)
+	statsHandler := handler.NewStatsHandler(statsService)
+	contributorsHandler := handler.NewContributorsHandler(contributorService)
+	metricsHandler := handler.NewMetricsHandler(metricsService)
+	slaHandler := handler.NewSLAHandler(slaService)
+	presenceHandler := handler.NewPresenceHandler(presenceService)
+	graphServer, err := graph.NewServer(graph.Services{
+		Events:       eventService,
+		Stats:        statsService,
+		Contributors: contributorService,
+		Users:        userRepo,
+		Hub:          sseHub,
+	}, cfg.GraphQLMaxComplexity)
+	if err != nil {
+		log.Fatalf("failed to initialize GraphQL server: %v", err)
+	}
+	graphqlHandler := handler.NewGraphQLHandler(graphServer, cfg.SSEHeartbeatInterval)
+	apiTokensHandler := handler.NewAPITokensHandler(apiTokenService)
 	r.Get("/api/health", healthHandler.ServeHTTP)
 	r.Post("/api/webhook", webhookHandler.ServeHTTP)
 	r.Get("/api/auth/login", oauthHandler.Login)
 	r.Get("/api/auth/callback", oauthHandler.Callback)
 	r.Get("/api/auth/me", oauthHandler.Me)
 	r.Post("/api/auth/logout", oauthHandler.Logout)
+	r.Get("/api/feeds/{token}/{id}.atom", feedsHandler.Atom)
+	r.Get("/api/feeds/{token}/{id}.rss", feedsHandler.RSS)
 	r.Group(func(r chi.Router) {
-		r.Use(middleware.Auth(sessionStore))
+		r.Use(middleware.Auth(sessionStore, apiTokenService))
 		r.Get("/api/events", eventsHandler.List)
+		r.Get("/api/events/export", exportHandler.ServeHTTP)
 		r.Get("/api/events/{id}", eventsHandler.GetByID)
 		r.Get("/api/events/stream", sseHandler.ServeHTTP)
+		r.Put("/api/events/stream/subscriptions/{id}", sseHandler.UpdateSubscription)
+		r.Get("/api/events/ws", wsHandler.ServeHTTP)
+		r.Get("/api/presence", presenceHandler.ServeHTTP)
+		r.Get("/api/hub", hubHandler.ServeHTTP)
+		r.Get("/api/stats", statsHandler.ServeHTTP)
+		r.Get("/api/contributors", contributorsHandler.List)
+		r.Get("/api/contributors/{login}", contributorsHandler.GetByLogin)
+		r.Get("/api/metrics/pull-requests", metricsHandler.PullRequests)
+		r.Get("/api/metrics/dora", metricsHandler.DORA)
+		r.Get("/api/sla/issues", slaHandler.Issues)
+		r.Get("/api/sla/breaches", slaHandler.Breaches)
+		r.Get("/api/feeds", feedsHandler.List)
+		r.Post("/api/feeds", feedsHandler.Create)
+		r.Delete("/api/feeds/{id}", feedsHandler.Delete)
+		r.Post("/api/feeds/token", feedsHandler.RegenerateToken)
+		r.Delete("/api/feeds/token", feedsHandler.RevokeToken)
+		r.Get("/api/graphql", graphqlHandler.ServeHTTP)
+		r.Post("/api/graphql", graphqlHandler.ServeHTTP)
+		r.Get("/api/graphql/schema.graphql", graphqlHandler.Schema)
+		r.Get("/api/tokens", apiTokensHandler.List)
+		r.Post("/api/tokens", apiTokensHandler.Create)
+		r.Delete("/api/tokens/{id}", apiTokensHandler.Delete)
 	})
 	addr := fmt.Sprintf(":%d", cfg.BackendPort)
 	srv := &http.Server{
@@ -86,20 +191,75 @@ func main() {
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
 	}
+	// Shutdown waits for open streams, which never end on their own. It closes the
+	// listener before running this, so clients told to reconnect reach another replica.
+	srv.RegisterOnShutdown(func() {
+		sseHub.Publish(sse.Message{Type: sse.MessageServerShutdown})
+	})
 	go func() {
 		middleware.LogEvent("info", "server starting", map[string]interface{}{"addr": addr})
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("server error: %v", err)
 		}
 	}()
+	var grpcServer *grpc.Server
+	if cfg.GRPCPort != 0 {
+		grpcAddr := fmt.Sprintf(":%d", cfg.GRPCPort)
+		lis, err := net.Listen("tcp", grpcAddr)
+		if err != nil {
+			log.Fatalf("failed to listen for gRPC: %v", err)
+		}
+		var opts []grpc.ServerOption
+		if cfg.GRPCTLSCert != "" {
+			creds, err := credentials.NewServerTLSFromFile(cfg.GRPCTLSCert, cfg.GRPCTLSKey)
+			if err != nil {
+				log.Fatalf("failed to load gRPC TLS certificate: %v", err)
+			}
+			opts = append(opts, grpc.Creds(creds))
+		}
+		grpcServer = grpcapi.NewServer(eventService, sseHub, apiTokenService, opts...)
+		go func() {
+			middleware.LogEvent("info", "gRPC server starting", map[string]interface{}{
+				"addr": grpcAddr,
+				"tls":  cfg.GRPCTLSCert != "",
+			})
+			if err := grpcServer.Serve(lis); err != nil {
+				log.Fatalf("gRPC server error: %v", err)
+			}
+		}()
+	}
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	middleware.LogEvent("info", "server shutting down", nil)
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
+	if grpcServer != nil {
+		stopGRPC(ctx, grpcServer)
+	}
 	if err := srv.Shutdown(ctx); err != nil {
 		log.Fatalf("server forced to shutdown: %v", err)
 	}
+	// Events received before the shutdown still reach the other replicas.
+	if err := bus.Close(ctx); err != nil {
+		middleware.LogEvent("error", "event bus did not drain before shutdown", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
 	middleware.LogEvent("info", "server stopped", nil)
 }
+
+// stopGRPC stops the gRPC server gracefully, cancelling the remaining calls (such as
+// Subscribe streams, which never finish on their own) once ctx is done.
+func stopGRPC(ctx context.Context, s *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		s.Stop()
+	}
+}
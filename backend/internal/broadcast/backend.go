@@ -0,0 +1,57 @@
+// Package broadcast carries changes to the stored events between backend replicas, so that
+// a change made by one replica reaches the stream clients connected to all of them.
+package broadcast
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+)
+
+// Backend publishes changes to every replica and delivers the changes published by any
+// replica to local subscribers.
+type Backend interface {
+	// Publish sends a change to the subscribers of every replica, including this one.
+	Publish(ctx context.Context, change model.EventChange) error
+	// Subscribe calls deliver with each published change, in publication order, until ctx
+	// is done.
+	Subscribe(ctx context.Context, deliver func(model.EventChange)) error
+}
+
+// Memory is a Backend for a single replica, and for tests: changes are delivered
+// synchronously to the subscribers in the same process.
+type Memory struct {
+	mu   sync.RWMutex
+	next int
+	subs map[int]func(model.EventChange)
+}
+
+// NewMemory creates a new Memory backend.
+func NewMemory() *Memory {
+	return &Memory{subs: make(map[int]func(model.EventChange))}
+}
+
+// Publish delivers change to every current subscriber.
+func (m *Memory) Publish(_ context.Context, change model.EventChange) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, deliver := range m.subs {
+		deliver(change)
+	}
+	return nil
+}
+
+// Subscribe registers deliver until ctx is done.
+func (m *Memory) Subscribe(ctx context.Context, deliver func(model.EventChange)) error {
+	m.mu.Lock()
+	id := m.next
+	m.next++
+	m.subs[id] = deliver
+	m.mu.Unlock()
+	<-ctx.Done()
+	m.mu.Lock()
+	delete(m.subs, id)
+	m.mu.Unlock()
+	return nil
+}
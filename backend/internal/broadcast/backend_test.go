@@ -0,0 +1,286 @@
+package broadcast
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+)
+
+// fakeOutbox is an OutboxStore shared by the replicas of a test.
+type fakeOutbox struct {
+	mu       sync.Mutex
+	messages []model.OutboxMessage
+	nextID   int64
+}
+
+func (s *fakeOutbox) Append(_ context.Context, payload []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextID++
+	s.messages = append(s.messages, model.OutboxMessage{ID: s.nextID, Payload: payload, CreatedAt: time.Now()})
+	return nil
+}
+
+// insert adds a message with a given ID, as a transaction committing out of order would.
+func (s *fakeOutbox) insert(t *testing.T, id int64) {
+	t.Helper()
+	payload, err := json.Marshal(model.Event{ID: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = append(s.messages, model.OutboxMessage{ID: id, Payload: payload, CreatedAt: time.Now()})
+}
+
+func (s *fakeOutbox) LatestID(context.Context) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var latest int64
+	for _, m := range s.messages {
+		latest = max(latest, m.ID)
+	}
+	return latest, nil
+}
+
+func (s *fakeOutbox) ListAfter(_ context.Context, afterID int64, limit int) ([]model.OutboxMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var result []model.OutboxMessage
+	for _, m := range s.messages {
+		if m.ID > afterID {
+			result = append(result, m)
+		}
+	}
+	slices.SortFunc(result, func(a, b model.OutboxMessage) int { return cmp.Compare(a.ID, b.ID) })
+	return result[:min(limit, len(result))], nil
+}
+
+func (s *fakeOutbox) DeleteBefore(_ context.Context, before time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var kept []model.OutboxMessage
+	for _, m := range s.messages {
+		if !m.CreatedAt.Before(before) {
+			kept = append(kept, m)
+		}
+	}
+	n := int64(len(s.messages) - len(kept))
+	s.messages = kept
+	return n, nil
+}
+
+// recorder collects delivered event IDs, prefixed with the type of change unless it is
+// a creation.
+type recorder struct {
+	mu  sync.Mutex
+	ids []string
+}
+
+func (r *recorder) deliver(c model.EventChange) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id := fmt.Sprint(c.Event.ID)
+	if c.Type != model.EventCreated {
+		id = fmt.Sprintf("%s:%d", c.Type, c.Event.ID)
+	}
+	r.ids = append(r.ids, id)
+}
+
+func (r *recorder) String() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return fmt.Sprint(r.ids)
+}
+
+// waitFor polls until r has received expected.
+func waitFor(t *testing.T, r *recorder, expected string) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for r.String() != expected {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected delivered %s, got %s", expected, r)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestMemoryDeliversToEverySubscriber(t *testing.T) {
+	m := NewMemory()
+	ctx, cancel := context.WithCancel(context.Background())
+	var a, b recorder
+	done := make(chan struct{})
+	go func() { m.Subscribe(ctx, a.deliver); done <- struct{}{} }()
+	go func() { m.Subscribe(ctx, b.deliver); done <- struct{}{} }()
+	for {
+		m.mu.RLock()
+		n := len(m.subs)
+		m.mu.RUnlock()
+		if n == 2 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	m.Publish(ctx, created(1))
+	m.Publish(ctx, created(2))
+	if a.String() != "[1 2]" || b.String() != "[1 2]" {
+		t.Errorf("expected [1 2] delivered to both, got %s and %s", &a, &b)
+	}
+
+	cancel()
+	<-done
+	<-done
+	m.Publish(context.Background(), created(3))
+	if a.String() != "[1 2]" {
+		t.Errorf("expected [1 2] delivered after unsubscribing, got %s", &a)
+	}
+}
+
+func TestMySQLDeliversToEveryReplica(t *testing.T) {
+	store := &fakeOutbox{}
+	store.insert(t, 1)
+	store.nextID = 1
+	opts := MySQLOptions{PollInterval: 5 * time.Millisecond}
+	replicaA, replicaB := NewMySQL(store, opts), NewMySQL(store, opts)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var a, b recorder
+	go replicaA.Subscribe(ctx, a.deliver)
+	go replicaB.Subscribe(ctx, b.deliver)
+	time.Sleep(20 * time.Millisecond)
+
+	// Messages from before a replica started are not delivered to it.
+	if err := replicaA.Publish(ctx, created(2)); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if err := replicaB.Publish(ctx, created(3)); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	waitFor(t, &a, "[2 3]")
+	waitFor(t, &b, "[2 3]")
+}
+
+func TestMySQLCarriesChangeType(t *testing.T) {
+	store := &fakeOutbox{}
+	b := NewMySQL(store, MySQLOptions{})
+	ctx := context.Background()
+	// Message 1 predates change types: it is the bare event, and a creation.
+	store.insert(t, 1)
+	store.nextID = 1
+	for _, change := range []model.EventChange{
+		{Type: model.EventUpdated, Event: model.Event{ID: 1}},
+		{Type: model.EventDeleted, Event: model.Event{ID: 1}},
+	} {
+		if err := b.Publish(ctx, change); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+
+	var r recorder
+	b.poll(ctx, &outboxCursor{}, r.deliver)
+	if got := r.String(); got != "[1 updated:1 deleted:1]" {
+		t.Errorf("expected [1 updated:1 deleted:1] delivered, got %s", got)
+	}
+}
+
+func TestMySQLWaitsForMissingIDs(t *testing.T) {
+	store := &fakeOutbox{}
+	b := NewMySQL(store, MySQLOptions{})
+	b.gapWait = 30 * time.Millisecond
+	c := &outboxCursor{}
+	var r recorder
+	ctx := context.Background()
+
+	store.insert(t, 1)
+	store.insert(t, 3)
+	b.poll(ctx, c, r.deliver)
+	if r.String() != "[1]" {
+		t.Fatalf("expected [1] delivered until 2 is committed, got %s", &r)
+	}
+	store.insert(t, 2)
+	b.poll(ctx, c, r.deliver)
+	if r.String() != "[1 2 3]" {
+		t.Fatalf("expected [1 2 3] delivered, got %s", &r)
+	}
+
+	// An ID that never appears is skipped after gapWait.
+	store.insert(t, 5)
+	b.poll(ctx, c, r.deliver)
+	if r.String() != "[1 2 3]" {
+		t.Fatalf("expected [1 2 3] delivered while waiting for 4, got %s", &r)
+	}
+	time.Sleep(40 * time.Millisecond)
+	b.poll(ctx, c, r.deliver)
+	if r.String() != "[1 2 3 5]" {
+		t.Fatalf("expected [1 2 3 5] delivered, got %s", &r)
+	}
+}
+
+func TestMySQLWaitsOnceForSeveralMissingIDs(t *testing.T) {
+	store := &fakeOutbox{}
+	b := NewMySQL(store, MySQLOptions{})
+	b.gapWait = 100 * time.Millisecond
+	c := &outboxCursor{}
+	var r recorder
+	ctx := context.Background()
+
+	store.insert(t, 1)
+	store.insert(t, 3)
+	store.insert(t, 5)
+	b.poll(ctx, c, r.deliver)
+	time.Sleep(50 * time.Millisecond)
+	store.insert(t, 7)
+	b.poll(ctx, c, r.deliver)
+	if r.String() != "[1]" {
+		t.Fatalf("expected [1] delivered while waiting for 2, 4 and 6, got %s", &r)
+	}
+
+	// 2 and 4 have been missing since 5 was listed, so both are skipped at once; 6 has
+	// been missing for a shorter time.
+	time.Sleep(60 * time.Millisecond)
+	b.poll(ctx, c, r.deliver)
+	if r.String() != "[1 3 5]" {
+		t.Fatalf("expected [1 3 5] delivered while waiting for 6, got %s", &r)
+	}
+	time.Sleep(60 * time.Millisecond)
+	b.poll(ctx, c, r.deliver)
+	if r.String() != "[1 3 5 7]" {
+		t.Fatalf("expected [1 3 5 7] delivered, got %s", &r)
+	}
+}
+
+func TestMySQLPrune(t *testing.T) {
+	tests := []struct {
+		name      string
+		retention time.Duration
+		expected  int
+	}{
+		{name: "disabled", retention: 0, expected: 2},
+		{name: "expired", retention: time.Minute, expected: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &fakeOutbox{}
+			store.insert(t, 1)
+			store.insert(t, 2)
+			store.messages[0].CreatedAt = time.Now().Add(-time.Hour)
+			NewMySQL(store, MySQLOptions{Retention: tt.retention}).prune(context.Background())
+			if got := len(store.messages); got != tt.expected {
+				t.Errorf("expected %d messages left, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+// created returns the creation of the event with the given ID.
+func created(id int64) model.EventChange {
+	return model.EventChange{Type: model.EventCreated, Event: model.Event{ID: id}}
+}
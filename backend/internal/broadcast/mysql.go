@@ -0,0 +1,193 @@
+package broadcast
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/middleware"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+)
+
+const (
+	// outboxBatchSize is the number of messages read per query.
+	outboxBatchSize = 500
+	// outboxGapWait is how long a poller waits for a missing ID before skipping it, counted
+	// from when it first listed a later ID. IDs are allocated at insert time but become
+	// visible at commit, so a lower ID can appear after a higher one; an ID that never
+	// appears belongs to a failed insert.
+	outboxGapWait = 2 * time.Second
+	// outboxPruneInterval is how often each replica deletes expired messages.
+	outboxPruneInterval = time.Minute
+)
+
+// OutboxStore is the storage of the MySQL backend, implemented by
+// repository.OutboxRepository.
+type OutboxStore interface {
+	Append(ctx context.Context, payload []byte) error
+	LatestID(ctx context.Context) (int64, error)
+	ListAfter(ctx context.Context, afterID int64, limit int) ([]model.OutboxMessage, error)
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// MySQLOptions configures the MySQL backend.
+type MySQLOptions struct {
+	// PollInterval is how often each replica checks the outbox for new messages.
+	PollInterval time.Duration
+	// Retention is how long messages are kept before being pruned; 0 keeps them forever.
+	Retention time.Duration
+}
+
+// outboxPayload is the JSON of an outbox message: the event, with the type of change.
+// Messages without one were written before other changes than creations were published.
+type outboxPayload struct {
+	model.Event
+	Change model.EventChangeType `json:"change,omitempty"`
+}
+
+// MySQL is a Backend that needs nothing but the database: Publish appends to the
+// event_outbox table and every replica polls it for messages newer than the last one it
+// delivered.
+type MySQL struct {
+	store   OutboxStore
+	opts    MySQLOptions
+	gapWait time.Duration
+}
+
+// NewMySQL creates a new MySQL backend.
+func NewMySQL(store OutboxStore, opts MySQLOptions) *MySQL {
+	return &MySQL{store: store, opts: opts, gapWait: outboxGapWait}
+}
+
+// Publish appends change to the outbox.
+func (b *MySQL) Publish(ctx context.Context, change model.EventChange) error {
+	payload, err := json.Marshal(outboxPayload{Event: change.Event, Change: change.Type})
+	if err != nil {
+		return fmt.Errorf("failed to marshal event change: %w", err)
+	}
+	return b.store.Append(ctx, payload)
+}
+
+// Subscribe polls the outbox until ctx is done, delivering the messages appended after it
+// started. Database errors are logged and retried on the next poll; clients that miss
+// events while the database is unreachable catch up with Last-Event-ID replay.
+func (b *MySQL) Subscribe(ctx context.Context, deliver func(model.EventChange)) error {
+	poll := time.NewTicker(b.opts.PollInterval)
+	defer poll.Stop()
+	prune := time.NewTicker(outboxPruneInterval)
+	defer prune.Stop()
+	var c *outboxCursor
+	for {
+		if c == nil {
+			c = b.start(ctx)
+		} else {
+			b.poll(ctx, c, deliver)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-poll.C:
+		case <-prune.C:
+			b.prune(ctx)
+		}
+	}
+}
+
+// outboxCursor tracks a replica's position in the outbox.
+type outboxCursor struct {
+	last int64
+	// listings records when the IDs after last were first listed, in ID order.
+	listings []outboxListing
+}
+
+// outboxListing records that the IDs up to upTo not covered by an earlier listing were
+// first listed at at.
+type outboxListing struct {
+	upTo int64
+	at   time.Time
+}
+
+// start returns a cursor at the end of the outbox, or nil if the database is unreachable.
+func (b *MySQL) start(ctx context.Context) *outboxCursor {
+	last, err := b.store.LatestID(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			middleware.LogEvent("error", "failed to read broadcast outbox", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+		return nil
+	}
+	return &outboxCursor{last: last}
+}
+
+// poll delivers the new messages in ID order, stopping at a missing ID until it appears or
+// outboxGapWait has passed since the next message was first listed. The IDs missing from
+// one listing are thus waited for together rather than one after another.
+func (b *MySQL) poll(ctx context.Context, c *outboxCursor, deliver func(model.EventChange)) {
+	for {
+		messages, err := b.store.ListAfter(ctx, c.last, outboxBatchSize)
+		if err != nil {
+			if ctx.Err() == nil {
+				middleware.LogEvent("error", "failed to read broadcast outbox", map[string]interface{}{
+					"error": err.Error(),
+				})
+			}
+			return
+		}
+		if len(messages) > 0 {
+			c.listed(messages[len(messages)-1].ID, time.Now())
+		}
+		for _, m := range messages {
+			if m.ID != c.last+1 && !c.skipGap(m.ID, b.gapWait) {
+				return
+			}
+			c.last = m.ID
+			var payload outboxPayload
+			if err := json.Unmarshal(m.Payload, &payload); err != nil {
+				middleware.LogEvent("error", "failed to decode broadcast outbox message", map[string]interface{}{
+					"outbox_id": m.ID,
+					"error":     err.Error(),
+				})
+				continue
+			}
+			if payload.Change == "" {
+				payload.Change = model.EventCreated
+			}
+			deliver(model.EventChange{Type: payload.Change, Event: payload.Event})
+		}
+		if len(messages) < outboxBatchSize {
+			return
+		}
+	}
+}
+
+// listed records that the IDs up to upTo have been listed at the given time, and forgets
+// the listings of delivered IDs.
+func (c *outboxCursor) listed(upTo int64, at time.Time) {
+	c.listings = slices.DeleteFunc(c.listings, func(l outboxListing) bool { return l.upTo <= c.last })
+	if n := len(c.listings); n == 0 || upTo > c.listings[n-1].upTo {
+		c.listings = append(c.listings, outboxListing{upTo: upTo, at: at})
+	}
+}
+
+// skipGap reports whether the IDs missing before next have been missing for at least wait,
+// that is whether next was first listed at least wait ago.
+func (c *outboxCursor) skipGap(next int64, wait time.Duration) bool {
+	i := slices.IndexFunc(c.listings, func(l outboxListing) bool { return l.upTo >= next })
+	return i >= 0 && time.Since(c.listings[i].at) >= wait
+}
+
+// prune deletes the messages older than the retention period.
+func (b *MySQL) prune(ctx context.Context) {
+	if b.opts.Retention <= 0 {
+		return
+	}
+	if _, err := b.store.DeleteBefore(ctx, time.Now().Add(-b.opts.Retention)); err != nil && ctx.Err() == nil {
+		middleware.LogEvent("error", "failed to prune broadcast outbox", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+}
@@ -4,12 +4,32 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 )
 
 const (
 	defaultBackendPort = 8080
+	defaultGRPCPort    = 9090
 	defaultMySQLPort   = 3306
 	defaultMySQLHost   = "db"
+	// defaultProductionEnvironment is the deployment environment counted for DORA metrics
+	// when DORA_PRODUCTION_ENVIRONMENTS is not set.
+	defaultProductionEnvironment = "production"
+	defaultSLABusinessHours      = "09:00-18:00"
+	defaultSLABusinessDays       = "Mon-Fri"
+	defaultSLATimezone           = "UTC"
+	defaultExportMaxRows         = 100000
+	defaultGraphQLMaxComplexity  = 1000
+	defaultSSEHeartbeatInterval  = 15 * time.Second
+	defaultSSERetry              = 3 * time.Second
+	defaultSSEMaxLifetime        = time.Hour
+	defaultBroadcastBackend      = "memory"
+	defaultBroadcastPollInterval = 500 * time.Millisecond
+	defaultBroadcastRetention    = time.Hour
+	defaultHubQueueSize          = 256
+	defaultHubOverflowPolicy     = "drop_oldest"
+	defaultHubClientBuffer       = 64
 )
 
 // Config holds all application configuration loaded from environment variables.
@@ -26,6 +46,59 @@ type Config struct {
 	FrontendURL         string
 	SessionSecret       string
 	TokenEncryptionKey  string
+	// PublicURL is the externally reachable base URL of the backend, used in feed links.
+	PublicURL string
+	// GRPCPort is the port of the gRPC server; 0 disables it.
+	GRPCPort int
+	// GRPCTLSCert and GRPCTLSKey are the PEM files of the gRPC server's TLS certificate and
+	// key. The server refuses to start without them unless GRPCInsecure is set.
+	GRPCTLSCert string
+	GRPCTLSKey  string
+	// GRPCInsecure serves gRPC over plaintext, sending API tokens unencrypted. It is meant
+	// for local development.
+	GRPCInsecure bool
+	// ProductionEnvironments maps a repository full name to the deployment environments
+	// counted as production for DORA metrics. The "" key holds the default for other repos.
+	ProductionEnvironments map[string][]string
+	// DeployWorkflows lists GitHub Actions workflow names whose runs count as production
+	// deployments for repositories that do not use the Deployments API.
+	DeployWorkflows []string
+	// SLAMaintainers maps a repository full name to the logins whose replies count as
+	// maintainer responses for the issue SLA. The "" key holds the default for other repos.
+	// When empty, GitHub's author association (OWNER, MEMBER, COLLABORATOR) is used instead.
+	SLAMaintainers   map[string][]string
+	SLABusinessHours string
+	SLABusinessDays  string
+	SLATimezone      string
+	// SLAResponseTarget is the first-response target in business time; 0 means one business day.
+	SLAResponseTarget time.Duration
+	// ExportMaxRows caps the number of rows a single event export returns.
+	ExportMaxRows int
+	// GraphQLMaxComplexity is the highest estimated cost a GraphQL operation may have.
+	GraphQLMaxComplexity int
+	// SSEHeartbeatInterval is the interval of keep-alive comments on event streams; 0
+	// disables them.
+	SSEHeartbeatInterval time.Duration
+	// SSERetry is the reconnection delay suggested to event stream clients.
+	SSERetry time.Duration
+	// SSEMaxLifetime is how long an event stream stays open before the client is told to
+	// reconnect; 0 means no limit.
+	SSEMaxLifetime time.Duration
+	// BroadcastBackend carries live events to the stream clients: "memory" for a single
+	// replica, or "mysql" to share them between replicas through the event_outbox table.
+	BroadcastBackend string
+	// BroadcastPollInterval is how often each replica polls the outbox.
+	BroadcastPollInterval time.Duration
+	// BroadcastRetention is how long outbox messages are kept; 0 keeps them forever.
+	BroadcastRetention time.Duration
+	// HubQueueSize is the number of live events that can wait to be sent to stream clients.
+	HubQueueSize int
+	// HubOverflowPolicy decides which event is discarded when the queue is full:
+	// "drop_oldest", "drop_newest" or "coalesce".
+	HubOverflowPolicy string
+	// HubClientBuffer is the number of live events buffered per stream client; a client
+	// that falls further behind is told to resync and disconnected.
+	HubClientBuffer int
 }
 
 // DSN returns the MySQL Data Source Name for database/sql connection.
@@ -53,11 +126,97 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("invalid BACKEND_PORT: %w", err)
 	}
 	cfg.BackendPort = port
+	grpcPort, err := strconv.Atoi(getEnv("GRPC_PORT", strconv.Itoa(defaultGRPCPort)))
+	if err != nil || grpcPort < 0 {
+		return nil, fmt.Errorf("invalid GRPC_PORT: must be a port number, or 0 to disable")
+	}
+	cfg.GRPCPort = grpcPort
+	cfg.GRPCTLSCert = getEnv("GRPC_TLS_CERT", "")
+	cfg.GRPCTLSKey = getEnv("GRPC_TLS_KEY", "")
+	if (cfg.GRPCTLSCert == "") != (cfg.GRPCTLSKey == "") {
+		return nil, fmt.Errorf("invalid GRPC_TLS_CERT/GRPC_TLS_KEY: both must be set")
+	}
+	if cfg.GRPCInsecure, err = strconv.ParseBool(getEnv("GRPC_INSECURE", "false")); err != nil {
+		return nil, fmt.Errorf("invalid GRPC_INSECURE: must be true or false")
+	}
+	if cfg.GRPCPort != 0 && cfg.GRPCTLSCert == "" && !cfg.GRPCInsecure {
+		return nil, fmt.Errorf("GRPC_TLS_CERT and GRPC_TLS_KEY are required to serve gRPC; set GRPC_INSECURE=true to serve plaintext, or GRPC_PORT=0 to disable it")
+	}
+	cfg.PublicURL = strings.TrimSuffix(getEnv("BACKEND_PUBLIC_URL", fmt.Sprintf("http://localhost:%d", port)), "/")
 	mysqlPort, err := strconv.Atoi(getEnv("MYSQL_PORT", strconv.Itoa(defaultMySQLPort)))
 	if err != nil {
 		return nil, fmt.Errorf("invalid MYSQL_PORT: %w", err)
 	}
 	cfg.MySQLPort = mysqlPort
+	prodEnvs, err := parseRepoLists(getEnv("DORA_PRODUCTION_ENVIRONMENTS", defaultProductionEnvironment))
+	if err != nil {
+		return nil, fmt.Errorf("invalid DORA_PRODUCTION_ENVIRONMENTS: %w", err)
+	}
+	cfg.ProductionEnvironments = prodEnvs
+	cfg.DeployWorkflows = splitList(getEnv("DORA_DEPLOY_WORKFLOWS", ""), ",")
+	maintainers, err := parseRepoLists(getEnv("SLA_MAINTAINERS", ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SLA_MAINTAINERS: %w", err)
+	}
+	cfg.SLAMaintainers = maintainers
+	cfg.SLABusinessHours = getEnv("SLA_BUSINESS_HOURS", defaultSLABusinessHours)
+	cfg.SLABusinessDays = getEnv("SLA_BUSINESS_DAYS", defaultSLABusinessDays)
+	cfg.SLATimezone = getEnv("SLA_TIMEZONE", defaultSLATimezone)
+	if v := getEnv("SLA_RESPONSE_TARGET", ""); v != "" {
+		target, err := time.ParseDuration(v)
+		if err != nil || target <= 0 {
+			return nil, fmt.Errorf("invalid SLA_RESPONSE_TARGET: must be a positive duration such as 4h")
+		}
+		cfg.SLAResponseTarget = target
+	}
+	exportMaxRows, err := strconv.Atoi(getEnv("EXPORT_MAX_ROWS", strconv.Itoa(defaultExportMaxRows)))
+	if err != nil || exportMaxRows < 1 {
+		return nil, fmt.Errorf("invalid EXPORT_MAX_ROWS: must be a positive integer")
+	}
+	cfg.ExportMaxRows = exportMaxRows
+	graphQLMaxComplexity, err := strconv.Atoi(getEnv("GRAPHQL_MAX_COMPLEXITY", strconv.Itoa(defaultGraphQLMaxComplexity)))
+	if err != nil || graphQLMaxComplexity < 1 {
+		return nil, fmt.Errorf("invalid GRAPHQL_MAX_COMPLEXITY: must be a positive integer")
+	}
+	cfg.GraphQLMaxComplexity = graphQLMaxComplexity
+	if cfg.SSEHeartbeatInterval, err = parseDurationEnv("SSE_HEARTBEAT_INTERVAL", defaultSSEHeartbeatInterval); err != nil {
+		return nil, err
+	}
+	if cfg.SSERetry, err = parseDurationEnv("SSE_RETRY", defaultSSERetry); err != nil {
+		return nil, err
+	}
+	if cfg.SSEMaxLifetime, err = parseDurationEnv("SSE_MAX_LIFETIME", defaultSSEMaxLifetime); err != nil {
+		return nil, err
+	}
+	cfg.BroadcastBackend = getEnv("BROADCAST_BACKEND", defaultBroadcastBackend)
+	if cfg.BroadcastBackend != "memory" && cfg.BroadcastBackend != "mysql" {
+		return nil, fmt.Errorf("invalid BROADCAST_BACKEND: must be memory or mysql")
+	}
+	if cfg.BroadcastPollInterval, err = parseDurationEnv("BROADCAST_POLL_INTERVAL", defaultBroadcastPollInterval); err != nil {
+		return nil, err
+	}
+	if cfg.BroadcastPollInterval == 0 {
+		return nil, fmt.Errorf("invalid BROADCAST_POLL_INTERVAL: must be a positive duration")
+	}
+	if cfg.BroadcastRetention, err = parseDurationEnv("BROADCAST_RETENTION", defaultBroadcastRetention); err != nil {
+		return nil, err
+	}
+	hubQueueSize, err := strconv.Atoi(getEnv("HUB_QUEUE_SIZE", strconv.Itoa(defaultHubQueueSize)))
+	if err != nil || hubQueueSize < 1 {
+		return nil, fmt.Errorf("invalid HUB_QUEUE_SIZE: must be a positive integer")
+	}
+	cfg.HubQueueSize = hubQueueSize
+	cfg.HubOverflowPolicy = getEnv("HUB_OVERFLOW_POLICY", defaultHubOverflowPolicy)
+	switch cfg.HubOverflowPolicy {
+	case "drop_oldest", "drop_newest", "coalesce":
+	default:
+		return nil, fmt.Errorf("invalid HUB_OVERFLOW_POLICY: must be drop_oldest, drop_newest or coalesce")
+	}
+	hubClientBuffer, err := strconv.Atoi(getEnv("HUB_CLIENT_BUFFER", strconv.Itoa(defaultHubClientBuffer)))
+	if err != nil || hubClientBuffer < 1 {
+		return nil, fmt.Errorf("invalid HUB_CLIENT_BUFFER: must be a positive integer")
+	}
+	cfg.HubClientBuffer = hubClientBuffer
 	if cfg.MySQLUser == "" || cfg.MySQLPassword == "" || cfg.MySQLDatabase == "" {
 		return nil, fmt.Errorf("MYSQL_USER, MYSQL_PASSWORD, and MYSQL_DATABASE are required")
 	}
@@ -73,3 +232,47 @@ func getEnv(key string, fallback string) string {
 	}
 	return fallback
 }
+
+// parseDurationEnv reads a non-negative duration such as 30s from the environment.
+func parseDurationEnv(key string, fallback time.Duration) (time.Duration, error) {
+	v := getEnv(key, "")
+	if v == "" {
+		return fallback, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid %s: must be a duration such as 30s, or 0 to disable", key)
+	}
+	return d, nil
+}
+
+// parseRepoLists parses a comma-separated list of entries where "value" applies to every
+// repository and "owner/repo=value1|value2" overrides a single repository,
+// e.g. "production,acme/api=prod|prod-eu".
+func parseRepoLists(value string) (map[string][]string, error) {
+	lists := make(map[string][]string)
+	for _, entry := range splitList(value, ",") {
+		repo, list, found := strings.Cut(entry, "=")
+		if !found {
+			lists[""] = append(lists[""], entry)
+			continue
+		}
+		repo = strings.TrimSpace(repo)
+		names := splitList(list, "|")
+		if !strings.Contains(repo, "/") || len(names) == 0 {
+			return nil, fmt.Errorf("entry %q must be in owner/repo=value1|value2 form", entry)
+		}
+		lists[repo] = append(lists[repo], names...)
+	}
+	return lists, nil
+}
+
+func splitList(value string, sep string) []string {
+	var items []string
+	for _, item := range strings.Split(value, sep) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,141 @@
+// Package eventbus delivers changes to the stored events to the in-process consumers that
+// react to them, such as the live broadcast to stream clients.
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/middleware"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+)
+
+// subscriberBuffer is the number of changes queued per subscriber. Changes published while
+// a subscriber's queue is full are dropped for that subscriber.
+const subscriberBuffer = 1024
+
+// Handler processes a published change. A returned error or a panic is logged and affects
+// neither the other subscribers nor the handler's later changes.
+type Handler func(ctx context.Context, change model.EventChange) error
+
+// Publisher is the producer side of the bus.
+type Publisher interface {
+	Publish(change model.EventChange)
+}
+
+// Bus delivers each published change to every subscriber. Each subscriber has its own
+// queue and goroutine, so it receives changes in publication order, and a slow or failing
+// subscriber never delays the publisher or the other subscribers.
+type Bus struct {
+	mu     sync.RWMutex
+	subs   []*subscriber
+	closed bool
+	wg     sync.WaitGroup
+	// ctx is passed to handlers and cancelled when Close gives up waiting for them.
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+type subscriber struct {
+	name    string
+	handler Handler
+	queue   chan model.EventChange
+	dropped atomic.Uint64
+}
+
+// New creates a new Bus.
+func New() *Bus {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Bus{ctx: ctx, cancel: cancel}
+}
+
+// Subscribe registers handler for the changes published from now on. name identifies the
+// subscriber in logs. Subscribing to a closed bus does nothing.
+func (b *Bus) Subscribe(name string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	s := &subscriber{name: name, handler: handler, queue: make(chan model.EventChange, subscriberBuffer)}
+	b.subs = append(b.subs, s)
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		for change := range s.queue {
+			s.handle(b.ctx, change)
+		}
+	}()
+}
+
+// Publish queues change for every subscriber without waiting for them.
+func (b *Bus) Publish(change model.EventChange) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.closed {
+		return
+	}
+	for _, s := range b.subs {
+		select {
+		case s.queue <- change:
+		default:
+			s.dropped.Add(1)
+			middleware.LogEvent("error", "event bus subscriber fell behind; change dropped", map[string]interface{}{
+				"subscriber": s.name,
+				"change":     change.Type,
+				"event_id":   change.Event.ID,
+				"dropped":    s.dropped.Load(),
+			})
+		}
+	}
+}
+
+// Close stops accepting changes and waits until the subscribers have handled the queued
+// ones. If ctx ends first, the handlers' context is cancelled and ctx's error returned.
+func (b *Bus) Close(ctx context.Context) error {
+	b.mu.Lock()
+	if !b.closed {
+		b.closed = true
+		for _, s := range b.subs {
+			close(s.queue)
+		}
+	}
+	b.mu.Unlock()
+	done := make(chan struct{})
+	go func() {
+		b.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		b.cancel()
+		return nil
+	case <-ctx.Done():
+		b.cancel()
+		return ctx.Err()
+	}
+}
+
+// handle runs the handler for one change, logging its error or panic.
+func (s *subscriber) handle(ctx context.Context, change model.EventChange) {
+	defer func() {
+		if p := recover(); p != nil {
+			middleware.LogEvent("error", "event bus subscriber panicked", map[string]interface{}{
+				"subscriber": s.name,
+				"change":     change.Type,
+				"event_id":   change.Event.ID,
+				"panic":      fmt.Sprint(p),
+			})
+		}
+	}()
+	if err := s.handler(ctx, change); err != nil {
+		middleware.LogEvent("error", "event bus subscriber failed", map[string]interface{}{
+			"subscriber": s.name,
+			"change":     change.Type,
+			"event_id":   change.Event.ID,
+			"error":      err.Error(),
+		})
+	}
+}
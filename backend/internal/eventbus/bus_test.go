@@ -0,0 +1,109 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+)
+
+// recorder is a Handler that records the IDs it handled and fails on event 2.
+type recorder struct {
+	mu  sync.Mutex
+	ids []int64
+	// fail, if set, is called with each event ID; it may panic or block.
+	fail func(id int64)
+}
+
+func (r *recorder) handle(_ context.Context, change model.EventChange) error {
+	r.mu.Lock()
+	r.ids = append(r.ids, change.Event.ID)
+	r.mu.Unlock()
+	if change.Event.ID == 2 {
+		return errors.New("boom")
+	}
+	if r.fail != nil {
+		r.fail(change.Event.ID)
+	}
+	return nil
+}
+
+func (r *recorder) String() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return fmt.Sprint(r.ids)
+}
+
+func TestBusDeliversInOrderAndIsolatesFailures(t *testing.T) {
+	bus := New()
+	var a, b recorder
+	b.fail = func(id int64) {
+		if id == 3 {
+			panic("boom")
+		}
+	}
+	// A subscriber that blocks must not delay the publisher or the other subscribers.
+	release := make(chan struct{})
+	var slow recorder
+	slow.fail = func(int64) { <-release }
+	bus.Subscribe("a", a.handle)
+	bus.Subscribe("b", b.handle)
+	bus.Subscribe("slow", slow.handle)
+
+	published := make(chan struct{})
+	go func() {
+		for id := int64(1); id <= 5; id++ {
+			bus.Publish(model.EventChange{Type: model.EventCreated, Event: model.Event{ID: id}})
+		}
+		close(published)
+	}()
+	select {
+	case <-published:
+	case <-time.After(3 * time.Second):
+		t.Fatal("Publish blocked on a slow subscriber")
+	}
+	close(release)
+	if err := bus.Close(context.Background()); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	// Every subscriber saw every event in order, despite the error on 2 and the panic on 3.
+	for name, r := range map[string]*recorder{"a": &a, "b": &b, "slow": &slow} {
+		if got := r.String(); got != "[1 2 3 4 5]" {
+			t.Errorf("expected subscriber %s to handle [1 2 3 4 5], got %s", name, got)
+		}
+	}
+	// A closed bus ignores new events.
+	bus.Publish(model.EventChange{Type: model.EventCreated, Event: model.Event{ID: 6}})
+	if got := a.String(); got != "[1 2 3 4 5]" {
+		t.Errorf("expected subscriber a to handle [1 2 3 4 5] after Close, got %s", got)
+	}
+}
+
+func TestBusCloseGivesUp(t *testing.T) {
+	bus := New()
+	handled := make(chan error, 1)
+	bus.Subscribe("stuck", func(ctx context.Context, _ model.EventChange) error {
+		<-ctx.Done()
+		handled <- ctx.Err()
+		return ctx.Err()
+	})
+	bus.Publish(model.EventChange{Type: model.EventCreated, Event: model.Event{ID: 1}})
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := bus.Close(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected Close() error to be DeadlineExceeded, got %v", err)
+	}
+	select {
+	case err := <-handled:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected handler context error to be Canceled, got %v", err)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("handler context was not cancelled")
+	}
+}
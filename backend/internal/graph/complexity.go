@@ -0,0 +1,57 @@
+package graph
+
+import (
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// pageSizeArgument is the argument whose value multiplies the cost of a field's selections.
+const pageSizeArgument = "first"
+
+// operationComplexity estimates the cost of executing op: every selected field costs 1,
+// and the cost of a field's sub-selections is multiplied by its page size (the value of
+// its first argument, including the schema default). Fields skipped by @skip/@include are
+// still counted. Computation stops early once the cost exceeds limit.
+func operationComplexity(op *ast.OperationDefinition, vars map[string]interface{}, limit int) int {
+	return selectionComplexity(op.SelectionSet, vars, limit)
+}
+
+func selectionComplexity(set ast.SelectionSet, vars map[string]interface{}, limit int) int {
+	total := 0
+	for _, sel := range set {
+		switch sel := sel.(type) {
+		case *ast.Field:
+			total++
+			if len(sel.SelectionSet) > 0 {
+				total += pageSize(sel, vars) * selectionComplexity(sel.SelectionSet, vars, limit)
+			}
+		case *ast.InlineFragment:
+			total += selectionComplexity(sel.SelectionSet, vars, limit)
+		case *ast.FragmentSpread:
+			if sel.Definition != nil {
+				total += selectionComplexity(sel.Definition.SelectionSet, vars, limit)
+			}
+		}
+		if total > limit {
+			return limit + 1
+		}
+	}
+	return total
+}
+
+// pageSize returns the page size requested by a field, or 1 if it takes no page size.
+func pageSize(field *ast.Field, vars map[string]interface{}) int {
+	var n int
+	switch v := field.ArgumentMap(vars)[pageSizeArgument].(type) {
+	case int64:
+		n = int(v)
+	case int32:
+		n = int(v)
+	case int:
+		n = v
+	case float64:
+		n = int(v)
+	default:
+		return 1
+	}
+	return min(max(n, 1), maxPageSize)
+}
@@ -0,0 +1,114 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func TestNewServerBindsResolvers(t *testing.T) {
+	if _, err := NewServer(Services{}, 1000); err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+}
+
+func TestOperationComplexity(t *testing.T) {
+	s, err := NewServer(Services{}, 1000)
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		vars     map[string]interface{}
+		expected int
+	}{
+		{
+			name:     "scalar field",
+			query:    `{ viewer { login } }`,
+			expected: 2,
+		},
+		{
+			name:     "schema default page size",
+			query:    `{ events { nodes { id } } }`,
+			expected: 1 + 20*(1+1),
+		},
+		{
+			name:     "explicit page size",
+			query:    `{ events(first: 5) { totalCount nodes { id title } } }`,
+			expected: 1 + 5*(1+1+2),
+		},
+		{
+			name:     "page size from variable",
+			query:    `query($n: Int) { repos(first: $n) { name } }`,
+			vars:     map[string]interface{}{"n": float64(3)},
+			expected: 1 + 3*1,
+		},
+		{
+			name:     "page size capped",
+			query:    `{ repos(first: 1000) { name } }`,
+			expected: 1 + maxPageSize*1,
+		},
+		{
+			name:     "fragments expanded",
+			query:    `{ contributors(first: 2) { ...c } } fragment c on Contributor { login total }`,
+			expected: 1 + 2*2,
+		},
+		{
+			name:     "stops above limit",
+			query:    `{ events(first: 100) { nodes { id title body htmlUrl action eventType repoName senderLogin deliveryId occurredAt receivedAt } } }`,
+			expected: 1001,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op := parseOperation(t, s, tt.query)
+			if got := operationComplexity(op, tt.vars, 1000); got != tt.expected {
+				t.Errorf("expected operationComplexity() to be %d, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestPrepareRejectsComplexQueries(t *testing.T) {
+	s, err := NewServer(Services{}, 50)
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		query       string
+		expectedOp  ast.Operation
+		expectedErr bool
+	}{
+		{name: "within limit", query: `{ events(first: 10) { nodes { id } } }`, expectedOp: ast.Query},
+		{name: "over limit", query: `{ events(first: 50) { nodes { id } } }`, expectedErr: true},
+		{name: "subscription", query: `subscription { eventAdded { id } }`, expectedOp: ast.Subscription},
+		{name: "invalid field", query: `{ nope }`, expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op, resp := s.Prepare(Request{Query: tt.query})
+			if (resp != nil) != tt.expectedErr {
+				t.Fatalf("expected error %v from Prepare(), got response %v", tt.expectedErr, resp)
+			}
+			if !tt.expectedErr && op != tt.expectedOp {
+				t.Errorf("expected Prepare() operation to be %q, got %q", tt.expectedOp, op)
+			}
+		})
+	}
+}
+
+func parseOperation(t *testing.T, s *Server, query string) *ast.OperationDefinition {
+	t.Helper()
+	doc, errs := gqlparser.LoadQueryWithRules(s.analysis, query, nil)
+	if len(errs) > 0 {
+		t.Fatalf("failed to parse query: %v", errs)
+	}
+	return doc.Operations[0]
+}
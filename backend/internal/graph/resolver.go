@@ -0,0 +1,284 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	graphql "github.com/graph-gophers/graphql-go"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/middleware"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/repository"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/search"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/service"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/sse"
+)
+
+// maxPageSize caps every first argument.
+const maxPageSize = 100
+
+// resolver is the root resolver for Query and Subscription fields.
+type resolver struct {
+	services Services
+}
+
+// eventFilterInput is the EventFilter input type.
+type eventFilterInput struct {
+	EventTypes *[]string
+	Repos      *[]string
+	Owners     *[]string
+	Senders    *[]string
+	Actions    *[]string
+	Since      *graphql.Time
+	Until      *graphql.Time
+	Q          *string
+}
+
+// toModel converts the input into a model.EventFilter with search.BuildFilter, like the
+// REST and gRPC APIs. A nil input is an empty filter.
+func (in *eventFilterInput) toModel() (model.EventFilter, error) {
+	if in == nil {
+		return model.EventFilter{}, nil
+	}
+	list := func(name string, values *[]string) search.FilterList {
+		l := search.FilterList{Name: name}
+		if values != nil {
+			l.Values = *values
+		}
+		return l
+	}
+	filter := search.FilterInput{
+		EventTypes: list("eventTypes", in.EventTypes),
+		Repos:      list("repos", in.Repos),
+		Owners:     list("owners", in.Owners),
+		Senders:    list("senders", in.Senders),
+		Actions:    list("actions", in.Actions),
+	}
+	if in.Since != nil {
+		filter.Since = &in.Since.Time
+	}
+	if in.Until != nil {
+		filter.Until = &in.Until.Time
+	}
+	if in.Q != nil {
+		filter.Q = *in.Q
+	}
+	return search.BuildFilter(filter)
+}
+
+func clampPageSize(first int32) int {
+	return min(max(int(first), 1), maxPageSize)
+}
+
+// internalError logs err and returns a generic error that is safe to show to clients.
+func internalError(message string, err error) error {
+	middleware.LogEvent("error", message, map[string]interface{}{"error": err.Error(), "api": "graphql"})
+	return errors.New(message)
+}
+
+// Events resolves Query.events.
+func (r *resolver) Events(ctx context.Context, args struct {
+	Filter *eventFilterInput
+	First  int32
+	After  *string
+}) (*eventConnectionResolver, error) {
+	filter, err := args.Filter.toModel()
+	if err != nil {
+		return nil, err
+	}
+	var after string
+	if args.After != nil {
+		after = *args.After
+	}
+	includeTotal := graphql.HasSelectedField(ctx, "totalCount")
+	result, err := r.services.Events.ListEventsByCursor(filter, after, clampPageSize(args.First), includeTotal)
+	if errors.Is(err, service.ErrInvalidCursor) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, internalError("failed to list events", err)
+	}
+	return &eventConnectionResolver{result: result, hasPrevious: after != "" && result.Pagination.PrevCursor != nil}, nil
+}
+
+// Event resolves Query.event.
+func (r *resolver) Event(args struct{ ID graphql.ID }) (*eventResolver, error) {
+	id, err := strconv.ParseInt(string(args.ID), 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid event id")
+	}
+	event, err := r.services.Events.GetEventByID(id)
+	if err != nil {
+		return nil, internalError("failed to get event", err)
+	}
+	if event == nil {
+		return nil, nil
+	}
+	return &eventResolver{e: *event}, nil
+}
+
+// Viewer resolves Query.viewer.
+func (r *resolver) Viewer(ctx context.Context) (*userResolver, error) {
+	userID := middleware.UserIDFromContext(ctx)
+	if userID == 0 {
+		return nil, nil
+	}
+	user, err := r.services.Users.FindByID(userID)
+	if err != nil {
+		return nil, internalError("failed to get user", err)
+	}
+	if user == nil {
+		return nil, nil
+	}
+	return &userResolver{u: user.ToResponse()}, nil
+}
+
+// Repos resolves Query.repos from the stats service's per-repository counts.
+func (r *resolver) Repos(args struct {
+	Filter *eventFilterInput
+	First  int32
+}) ([]*repoResolver, error) {
+	filter, err := args.Filter.toModel()
+	if err != nil {
+		return nil, err
+	}
+	stats, err := r.getStats(service.StatsQuery{Filter: filter, Limit: clampPageSize(args.First)})
+	if err != nil {
+		return nil, err
+	}
+	repos := make([]*repoResolver, len(stats.ByRepo))
+	for i, c := range stats.ByRepo {
+		repos[i] = &repoResolver{c: c}
+	}
+	return repos, nil
+}
+
+// Contributors resolves Query.contributors.
+func (r *resolver) Contributors(args struct {
+	Filter      *eventFilterInput
+	Sort        string
+	IncludeBots bool
+	First       int32
+}) ([]*contributorResolver, error) {
+	filter, err := args.Filter.toModel()
+	if err != nil {
+		return nil, err
+	}
+	filter.ExcludeBots = !args.IncludeBots
+	sort, err := repository.ParseContributorSort(args.Sort)
+	if err != nil {
+		return nil, err
+	}
+	result, err := r.services.Contributors.ListContributors(filter, sort, clampPageSize(args.First))
+	if err != nil {
+		return nil, internalError("failed to list contributors", err)
+	}
+	contributors := make([]*contributorResolver, len(result.Contributors))
+	for i := range result.Contributors {
+		contributors[i] = &contributorResolver{c: &result.Contributors[i]}
+	}
+	return contributors, nil
+}
+
+// Contributor resolves Query.contributor.
+func (r *resolver) Contributor(args struct {
+	Login  string
+	Filter *eventFilterInput
+}) (*contributorProfileResolver, error) {
+	filter, err := args.Filter.toModel()
+	if err != nil {
+		return nil, err
+	}
+	profile, err := r.services.Contributors.GetContributor(filter, args.Login)
+	if err != nil {
+		return nil, internalError("failed to get contributor", err)
+	}
+	if profile == nil {
+		return nil, nil
+	}
+	return &contributorProfileResolver{contributorResolver{c: &profile.ContributorStats}, profile}, nil
+}
+
+// Stats resolves Query.stats.
+func (r *resolver) Stats(args struct {
+	Filter   *eventFilterInput
+	Bucket   *string
+	Timezone *string
+	First    int32
+}) (*statsResolver, error) {
+	filter, err := args.Filter.toModel()
+	if err != nil {
+		return nil, err
+	}
+	q := service.StatsQuery{Filter: filter, Limit: clampPageSize(args.First)}
+	if args.Bucket != nil {
+		q.Bucket = strings.ToLower(*args.Bucket)
+	}
+	if args.Timezone != nil && *args.Timezone != "" {
+		loc, err := time.LoadLocation(*args.Timezone)
+		if err != nil {
+			return nil, errors.New("invalid timezone: unknown time zone")
+		}
+		q.Location = loc
+	}
+	stats, err := r.getStats(q)
+	if err != nil {
+		return nil, err
+	}
+	return &statsResolver{s: stats}, nil
+}
+
+func (r *resolver) getStats(q service.StatsQuery) (*model.StatsResponse, error) {
+	stats, err := r.services.Stats.GetStats(q)
+	if errors.Is(err, service.ErrInvalidBucket) || errors.Is(err, service.ErrTooManyBuckets) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, internalError("failed to compute stats", err)
+	}
+	return stats, nil
+}
+
+// EventAdded resolves Subscription.eventAdded by subscribing to the SSE hub with the
+// filter and forwarding the new events it routes until ctx is cancelled or the server shuts
+// down.
+func (r *resolver) EventAdded(ctx context.Context, args struct{ Filter *eventFilterInput }) (<-chan *eventResolver, error) {
+	filter, err := args.Filter.toModel()
+	if err != nil {
+		return nil, err
+	}
+	client := r.services.Hub.NewClient()
+	r.services.Hub.Subscribe(client, filter)
+	out := make(chan *eventResolver)
+	go func() {
+		defer close(out)
+		defer r.services.Hub.Unregister(client)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case env, ok := <-client:
+				if !ok || env.Type == sse.MessageServerShutdown {
+					return
+				}
+				if env.Type != sse.MessageNewEvent {
+					continue
+				}
+				var event model.Event
+				if err := json.Unmarshal(env.Data, &event); err != nil {
+					continue
+				}
+				select {
+				case out <- &eventResolver{e: event}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
@@ -0,0 +1,126 @@
+// Package graph implements the GraphQL API over events, repositories, users and stats.
+// Queries are executed by graph-gophers/graphql-go against resolvers backed by the service
+// layer; before execution each operation is parsed with gqlparser to enforce a complexity
+// limit.
+package graph
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	qerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/repository"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/service"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/sse"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+const (
+	maxQueryDepth  = 10
+	maxQueryLength = 10000
+	maxParallelism = 10
+)
+
+// Services are the dependencies the resolvers read from.
+type Services struct {
+	Events       *service.EventService
+	Stats        *service.StatsService
+	Contributors *service.ContributorService
+	Users        *repository.UserRepository
+	Hub          *sse.Hub
+}
+
+// Request is a GraphQL request as sent in a POST body or GET query string.
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Server executes GraphQL requests.
+type Server struct {
+	schema        *graphql.Schema
+	analysis      *ast.Schema
+	maxComplexity int
+}
+
+// NewServer parses the schema and binds it to resolvers backed by services.
+// Operations whose complexity exceeds maxComplexity are rejected before execution.
+func NewServer(services Services, maxComplexity int) (*Server, error) {
+	schema, err := graphql.ParseSchema(schemaSDL, &resolver{services: services},
+		graphql.MaxDepth(maxQueryDepth),
+		graphql.MaxQueryLength(maxQueryLength),
+		graphql.MaxParallelism(maxParallelism),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse GraphQL schema: %w", err)
+	}
+	analysis, err := gqlparser.LoadSchema(&ast.Source{Name: "schema.graphql", Input: schemaSDL})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load GraphQL schema for analysis: %w", err)
+	}
+	return &Server{schema: schema, analysis: analysis, maxComplexity: maxComplexity}, nil
+}
+
+// SDL returns the schema in GraphQL schema definition language.
+func (s *Server) SDL() string {
+	return schemaSDL
+}
+
+// Prepare validates a request and checks its complexity. It returns the type of the
+// selected operation, or an error response if the request must not be executed.
+func (s *Server) Prepare(req Request) (ast.Operation, *graphql.Response) {
+	if len(req.Query) > maxQueryLength {
+		return "", errorResponse(&qerrors.QueryError{Message: fmt.Sprintf("query length exceeds the maximum of %d characters", maxQueryLength)})
+	}
+	doc, errs := gqlparser.LoadQueryWithRules(s.analysis, req.Query, nil)
+	if len(errs) > 0 {
+		return "", errorResponse(convertErrors(errs)...)
+	}
+	op := doc.Operations.ForName(req.OperationName)
+	if op == nil {
+		return "", errorResponse(&qerrors.QueryError{Message: "operation not found; operationName is required when the document has several operations"})
+	}
+	if cost := operationComplexity(op, req.Variables, s.maxComplexity); cost > s.maxComplexity {
+		return "", errorResponse(&qerrors.QueryError{
+			Message:    fmt.Sprintf("query complexity %d exceeds the maximum of %d", cost, s.maxComplexity),
+			Extensions: map[string]any{"code": "COMPLEXITY_LIMIT_EXCEEDED", "complexity": cost, "maxComplexity": s.maxComplexity},
+		})
+	}
+	return op.Operation, nil
+}
+
+// Exec executes a query operation. Call Prepare first.
+func (s *Server) Exec(ctx context.Context, req Request) *graphql.Response {
+	return s.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+}
+
+// Subscribe starts a subscription operation. Call Prepare first. The returned channel
+// yields *graphql.Response values and is closed when ctx is cancelled.
+func (s *Server) Subscribe(ctx context.Context, req Request) (<-chan interface{}, error) {
+	return s.schema.Subscribe(ctx, req.Query, req.OperationName, req.Variables)
+}
+
+func errorResponse(errs ...*qerrors.QueryError) *graphql.Response {
+	return &graphql.Response{Errors: errs}
+}
+
+func convertErrors(errs gqlerror.List) []*qerrors.QueryError {
+	converted := make([]*qerrors.QueryError, len(errs))
+	for i, e := range errs {
+		qe := &qerrors.QueryError{Message: e.Message}
+		for _, loc := range e.Locations {
+			qe.Locations = append(qe.Locations, qerrors.Location{Line: loc.Line, Column: loc.Column})
+		}
+		converted[i] = qe
+	}
+	return converted
+}
@@ -0,0 +1,209 @@
+package graph
+
+import (
+	"strconv"
+	"strings"
+
+	graphql "github.com/graph-gophers/graphql-go"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/service"
+)
+
+type eventResolver struct {
+	e model.Event
+}
+
+func (r *eventResolver) ID() graphql.ID           { return graphql.ID(strconv.FormatInt(r.e.ID, 10)) }
+func (r *eventResolver) DeliveryID() string       { return r.e.DeliveryID }
+func (r *eventResolver) EventType() string        { return r.e.EventType }
+func (r *eventResolver) Action() string           { return r.e.Action }
+func (r *eventResolver) RepoName() string         { return r.e.RepoName }
+func (r *eventResolver) SenderLogin() string      { return r.e.SenderLogin }
+func (r *eventResolver) SenderAvatarURL() *string { return r.e.SenderAvatarURL }
+func (r *eventResolver) Title() *string           { return r.e.Title }
+func (r *eventResolver) Body() *string            { return r.e.Body }
+func (r *eventResolver) HTMLURL() string          { return r.e.HTMLURL }
+func (r *eventResolver) EventData() *string       { return r.e.EventData }
+func (r *eventResolver) OccurredAt() graphql.Time { return graphql.Time{Time: r.e.OccurredAt} }
+func (r *eventResolver) ReceivedAt() graphql.Time { return graphql.Time{Time: r.e.ReceivedAt} }
+
+func eventResolvers(events []model.Event) []*eventResolver {
+	resolvers := make([]*eventResolver, len(events))
+	for i, e := range events {
+		resolvers[i] = &eventResolver{e: e}
+	}
+	return resolvers
+}
+
+type eventConnectionResolver struct {
+	result      *model.EventListResponse
+	hasPrevious bool
+}
+
+func (r *eventConnectionResolver) Edges() []*eventEdgeResolver {
+	edges := make([]*eventEdgeResolver, len(r.result.Events))
+	for i, e := range r.result.Events {
+		edges[i] = &eventEdgeResolver{e: e}
+	}
+	return edges
+}
+
+func (r *eventConnectionResolver) Nodes() []*eventResolver {
+	return eventResolvers(r.result.Events)
+}
+
+func (r *eventConnectionResolver) PageInfo() *pageInfoResolver {
+	info := &pageInfoResolver{
+		hasNext:     r.result.Pagination.NextCursor != nil,
+		hasPrevious: r.hasPrevious,
+	}
+	if n := len(r.result.Events); n > 0 {
+		start := service.CursorForEvent(r.result.Events[0])
+		end := service.CursorForEvent(r.result.Events[n-1])
+		info.start, info.end = &start, &end
+	}
+	return info
+}
+
+// TotalCount is only computed by the service when the field is selected (see resolver.Events).
+func (r *eventConnectionResolver) TotalCount() int32 {
+	if r.result.Pagination.Total == nil {
+		return 0
+	}
+	return int32(*r.result.Pagination.Total)
+}
+
+type eventEdgeResolver struct {
+	e model.Event
+}
+
+func (r *eventEdgeResolver) Cursor() string       { return service.CursorForEvent(r.e) }
+func (r *eventEdgeResolver) Node() *eventResolver { return &eventResolver{e: r.e} }
+
+type pageInfoResolver struct {
+	hasNext     bool
+	hasPrevious bool
+	start       *string
+	end         *string
+}
+
+func (r *pageInfoResolver) HasNextPage() bool     { return r.hasNext }
+func (r *pageInfoResolver) HasPreviousPage() bool { return r.hasPrevious }
+func (r *pageInfoResolver) StartCursor() *string  { return r.start }
+func (r *pageInfoResolver) EndCursor() *string    { return r.end }
+
+type userResolver struct {
+	u model.UserResponse
+}
+
+func (r *userResolver) ID() graphql.ID      { return graphql.ID(strconv.FormatInt(r.u.ID, 10)) }
+func (r *userResolver) Login() string       { return r.u.Login }
+func (r *userResolver) DisplayName() string { return r.u.DisplayName }
+func (r *userResolver) AvatarURL() *string  { return r.u.AvatarURL }
+
+type repoResolver struct {
+	c model.StatCount
+}
+
+func (r *repoResolver) Name() string { return r.c.Key }
+
+func (r *repoResolver) Owner() string {
+	owner, _, _ := strings.Cut(r.c.Key, "/")
+	return owner
+}
+
+func (r *repoResolver) EventCount() int32 { return int32(r.c.Count) }
+
+type contributorResolver struct {
+	c *model.ContributorStats
+}
+
+func (r *contributorResolver) Login() string       { return r.c.Login }
+func (r *contributorResolver) AvatarURL() *string  { return r.c.AvatarURL }
+func (r *contributorResolver) IssuesOpened() int32 { return int32(r.c.IssuesOpened) }
+func (r *contributorResolver) PrsMerged() int32    { return int32(r.c.PRsMerged) }
+func (r *contributorResolver) Reviews() int32      { return int32(r.c.Reviews) }
+func (r *contributorResolver) Pushes() int32       { return int32(r.c.Pushes) }
+func (r *contributorResolver) Total() int32        { return int32(r.c.Total) }
+
+type contributorProfileResolver struct {
+	contributorResolver
+	p *model.ContributorProfile
+}
+
+func (r *contributorProfileResolver) Since() graphql.Time { return graphql.Time{Time: r.p.Since} }
+func (r *contributorProfileResolver) Until() graphql.Time { return graphql.Time{Time: r.p.Until} }
+
+func (r *contributorProfileResolver) Repos() []*repoActivityResolver {
+	repos := make([]*repoActivityResolver, len(r.p.Repos))
+	for i, a := range r.p.Repos {
+		repos[i] = &repoActivityResolver{a: a}
+	}
+	return repos
+}
+
+func (r *contributorProfileResolver) Activity() []*seriesPointResolver {
+	return seriesPointResolvers(r.p.Activity)
+}
+
+func (r *contributorProfileResolver) RecentEvents() []*eventResolver {
+	return eventResolvers(r.p.RecentEvents)
+}
+
+type repoActivityResolver struct {
+	a model.RepoActivity
+}
+
+func (r *repoActivityResolver) RepoName() string { return r.a.RepoName }
+func (r *repoActivityResolver) Count() int32     { return int32(r.a.Count) }
+func (r *repoActivityResolver) LastActivityAt() graphql.Time {
+	return graphql.Time{Time: r.a.LastActivityAt}
+}
+
+type statsResolver struct {
+	s *model.StatsResponse
+}
+
+func (r *statsResolver) Since() graphql.Time          { return graphql.Time{Time: r.s.Since} }
+func (r *statsResolver) Until() graphql.Time          { return graphql.Time{Time: r.s.Until} }
+func (r *statsResolver) Timezone() string             { return r.s.Timezone }
+func (r *statsResolver) Bucket() string               { return strings.ToUpper(r.s.Bucket) }
+func (r *statsResolver) Total() int32                 { return int32(r.s.Total) }
+func (r *statsResolver) ByRepo() []*statCountResolver { return statCountResolvers(r.s.ByRepo) }
+func (r *statsResolver) ByEventType() []*statCountResolver {
+	return statCountResolvers(r.s.ByEventType)
+}
+func (r *statsResolver) ByAction() []*statCountResolver { return statCountResolvers(r.s.ByAction) }
+func (r *statsResolver) BySender() []*statCountResolver { return statCountResolvers(r.s.BySender) }
+func (r *statsResolver) Series() []*seriesPointResolver { return seriesPointResolvers(r.s.Series) }
+
+type statCountResolver struct {
+	c model.StatCount
+}
+
+func (r *statCountResolver) Key() string  { return r.c.Key }
+func (r *statCountResolver) Count() int32 { return int32(r.c.Count) }
+
+func statCountResolvers(counts []model.StatCount) []*statCountResolver {
+	resolvers := make([]*statCountResolver, len(counts))
+	for i, c := range counts {
+		resolvers[i] = &statCountResolver{c: c}
+	}
+	return resolvers
+}
+
+type seriesPointResolver struct {
+	p model.SeriesPoint
+}
+
+func (r *seriesPointResolver) Start() graphql.Time { return graphql.Time{Time: r.p.Start} }
+func (r *seriesPointResolver) Count() int32        { return int32(r.p.Count) }
+
+func seriesPointResolvers(points []model.SeriesPoint) []*seriesPointResolver {
+	resolvers := make([]*seriesPointResolver, len(points))
+	for i, p := range points {
+		resolvers[i] = &seriesPointResolver{p: p}
+	}
+	return resolvers
+}
@@ -0,0 +1,171 @@
+package grpcapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/middleware"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/search"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/service"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/sse"
+	dashboardv1 "github.com/hirokazuyamada/github-events-dashboard-poc/backend/proto/dashboard/v1"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// eventServer implements dashboardv1.EventServiceServer.
+type eventServer struct {
+	dashboardv1.UnimplementedEventServiceServer
+	events *service.EventService
+	hub    *sse.Hub
+}
+
+// ListEvents returns a page of events matching the filter, newest first. page_token is
+// a cursor as returned in next_page_token (or next_cursor by GET /api/events).
+func (s *eventServer) ListEvents(ctx context.Context, req *dashboardv1.ListEventsRequest) (*dashboardv1.ListEventsResponse, error) {
+	filter, err := toModelFilter(req.GetFilter())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	pageSize := int(req.GetPageSize())
+	if pageSize < 0 {
+		return nil, status.Error(codes.InvalidArgument, "page_size must not be negative")
+	}
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+	pageSize = min(pageSize, maxPageSize)
+	result, err := s.events.ListEventsByCursor(filter, req.GetPageToken(), pageSize, false)
+	if errors.Is(err, service.ErrInvalidCursor) {
+		return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+	}
+	if err != nil {
+		return nil, internalError("failed to list events", err)
+	}
+	resp := &dashboardv1.ListEventsResponse{Events: make([]*dashboardv1.Event, len(result.Events))}
+	for i, e := range result.Events {
+		resp.Events[i] = toProtoEvent(e)
+	}
+	if result.Pagination.NextCursor != nil {
+		resp.NextPageToken = *result.Pagination.NextCursor
+	}
+	return resp, nil
+}
+
+// GetEvent returns a single event by ID.
+func (s *eventServer) GetEvent(ctx context.Context, req *dashboardv1.GetEventRequest) (*dashboardv1.Event, error) {
+	if req.GetId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid event id")
+	}
+	event, err := s.events.GetEventByID(req.GetId())
+	if err != nil {
+		return nil, internalError("failed to get event", err)
+	}
+	if event == nil {
+		return nil, status.Error(codes.NotFound, "event not found")
+	}
+	return toProtoEvent(*event), nil
+}
+
+// Subscribe subscribes to the SSE hub with the request filter and streams events until
+// the client cancels. If the client falls too far behind, the hub drops it and the stream
+// ends with Unavailable, as it does when the server shuts down. Hub messages other than
+// new events are not sent.
+func (s *eventServer) Subscribe(req *dashboardv1.SubscribeRequest, stream dashboardv1.EventService_SubscribeServer) error {
+	filter, err := toModelFilter(req.GetFilter())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	client := s.hub.NewClient()
+	s.hub.Subscribe(client, filter)
+	defer s.hub.Unregister(client)
+	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case env, ok := <-client:
+			if !ok {
+				return status.Error(codes.Unavailable, "subscription dropped because the client fell behind")
+			}
+			if env.Type == sse.MessageServerShutdown {
+				return status.Error(codes.Unavailable, "server shutting down")
+			}
+			if env.Type != sse.MessageNewEvent {
+				continue
+			}
+			var event model.Event
+			if err := json.Unmarshal(env.Data, &event); err != nil {
+				continue
+			}
+			if err := stream.Send(toProtoEvent(event)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// internalError logs err and returns an Internal status that is safe to show to clients.
+func internalError(message string, err error) error {
+	middleware.LogEvent("error", message, map[string]interface{}{"error": err.Error(), "api": "grpc"})
+	return status.Error(codes.Internal, message)
+}
+
+// toModelFilter converts a request filter into a model.EventFilter with
+// search.BuildFilter, like the REST and GraphQL APIs. A nil filter is an empty filter.
+func toModelFilter(f *dashboardv1.EventFilter) (model.EventFilter, error) {
+	if f == nil {
+		return model.EventFilter{}, nil
+	}
+	in := search.FilterInput{
+		EventTypes: search.FilterList{Name: "event_types", Values: f.GetEventTypes()},
+		Repos:      search.FilterList{Name: "repos", Values: f.GetRepos()},
+		Owners:     search.FilterList{Name: "owners", Values: f.GetOwners()},
+		Senders:    search.FilterList{Name: "senders", Values: f.GetSenders()},
+		Actions:    search.FilterList{Name: "actions", Values: f.GetActions()},
+		Q:          f.GetQ(),
+	}
+	if f.GetSince() != nil {
+		if err := f.GetSince().CheckValid(); err != nil {
+			return model.EventFilter{}, fmt.Errorf("invalid since: %w", err)
+		}
+		since := f.GetSince().AsTime()
+		in.Since = &since
+	}
+	if f.GetUntil() != nil {
+		if err := f.GetUntil().CheckValid(); err != nil {
+			return model.EventFilter{}, fmt.Errorf("invalid until: %w", err)
+		}
+		until := f.GetUntil().AsTime()
+		in.Until = &until
+	}
+	return search.BuildFilter(in)
+}
+
+func toProtoEvent(e model.Event) *dashboardv1.Event {
+	return &dashboardv1.Event{
+		Id:              e.ID,
+		DeliveryId:      e.DeliveryID,
+		EventType:       e.EventType,
+		Action:          e.Action,
+		RepoName:        e.RepoName,
+		SenderLogin:     e.SenderLogin,
+		SenderAvatarUrl: e.SenderAvatarURL,
+		Title:           e.Title,
+		Body:            e.Body,
+		HtmlUrl:         e.HTMLURL,
+		EventData:       e.EventData,
+		OccurredAt:      timestamppb.New(e.OccurredAt),
+		ReceivedAt:      timestamppb.New(e.ReceivedAt),
+	}
+}
@@ -0,0 +1,127 @@
+// Package grpcapi implements the dashboard.v1.EventService gRPC API defined in
+// proto/dashboard/v1/events.proto. Calls are authenticated with personal API tokens
+// sent in the "authorization" metadata.
+package grpcapi
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/middleware"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/service"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/sse"
+	dashboardv1 "github.com/hirokazuyamada/github-events-dashboard-poc/backend/proto/dashboard/v1"
+)
+
+// authorizationKey is the metadata key carrying "Bearer <token>".
+const authorizationKey = "authorization"
+
+// Authenticator resolves an API token to the ID of its user (0 if the token is unknown).
+// It is implemented by service.APITokenService.
+type Authenticator interface {
+	Authenticate(token string) (int64, error)
+}
+
+// NewServer creates a gRPC server serving EventService. Every call must carry an API token
+// accepted by auth. opts are added to the server options, e.g. grpc.Creds for TLS.
+func NewServer(events *service.EventService, hub *sse.Hub, auth Authenticator, opts ...grpc.ServerOption) *grpc.Server {
+	srv := grpc.NewServer(append([]grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(logUnary, authUnary(auth)),
+		grpc.ChainStreamInterceptor(logStream, authStream(auth)),
+	}, opts...)...)
+	dashboardv1.RegisterEventServiceServer(srv, &eventServer{events: events, hub: hub})
+	return srv
+}
+
+// authenticate validates the bearer token in the incoming metadata and returns a context
+// carrying the token's user ID.
+func authenticate(ctx context.Context, auth Authenticator) (context.Context, error) {
+	token := bearerToken(ctx)
+	if token == "" {
+		return nil, status.Error(codes.Unauthenticated, "missing API token")
+	}
+	userID, err := auth.Authenticate(token)
+	if err != nil {
+		middleware.LogEvent("error", "failed to authenticate API token", map[string]interface{}{"error": err.Error()})
+		return nil, status.Error(codes.Unavailable, "failed to authenticate")
+	}
+	if userID == 0 {
+		return nil, status.Error(codes.Unauthenticated, "invalid API token")
+	}
+	return middleware.ContextWithUserID(ctx, userID), nil
+}
+
+// bearerToken returns the token from "authorization: Bearer <token>" metadata, or "".
+func bearerToken(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(authorizationKey)
+	if len(values) == 0 {
+		return ""
+	}
+	scheme, token, ok := strings.Cut(values[0], " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+func authUnary(auth Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authenticate(ctx, auth)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func authStream(auth Authenticator) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), auth)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// contextStream overrides the context of a server stream.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+func logUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	logCall(info.FullMethod, start, err)
+	return resp, err
+}
+
+func logStream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	logCall(info.FullMethod, start, err)
+	return err
+}
+
+func logCall(method string, start time.Time, err error) {
+	middleware.LogEvent("info", "gRPC call", map[string]interface{}{
+		"method":   method,
+		"code":     status.Code(err).String(),
+		"duration": time.Since(start).String(),
+	})
+}
@@ -0,0 +1,187 @@
+package grpcapi
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/search"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/sse"
+	dashboardv1 "github.com/hirokazuyamada/github-events-dashboard-poc/backend/proto/dashboard/v1"
+)
+
+type staticAuthenticator map[string]int64
+
+func (a staticAuthenticator) Authenticate(token string) (int64, error) {
+	return a[token], nil
+}
+
+func TestBearerToken(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    []string
+		expected string
+	}{
+		{name: "no metadata", expected: ""},
+		{name: "bearer", value: []string{"Bearer abc"}, expected: "abc"},
+		{name: "lowercase scheme", value: []string{"bearer abc"}, expected: "abc"},
+		{name: "other scheme", value: []string{"Basic abc"}, expected: ""},
+		{name: "no scheme", value: []string{"abc"}, expected: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md := metadata.MD{}
+			if tt.value != nil {
+				md.Set(authorizationKey, tt.value...)
+			}
+			ctx := metadata.NewIncomingContext(context.Background(), md)
+			if got := bearerToken(ctx); got != tt.expected {
+				t.Errorf("expected bearerToken() to be %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestToModelFilter(t *testing.T) {
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	var tooMany []string
+	for i := range search.MaxFilterValues + 1 {
+		tooMany = append(tooMany, fmt.Sprintf("user%d", i))
+	}
+
+	tests := []struct {
+		name        string
+		filter      *dashboardv1.EventFilter
+		check       func(t *testing.T, f model.EventFilter)
+		expectedErr bool
+	}{
+		{
+			name:   "nil filter",
+			filter: nil,
+			check: func(t *testing.T, f model.EventFilter) {
+				if f.Repos != nil || f.Since != nil {
+					t.Errorf("expected filter to be empty, got %+v", f)
+				}
+			},
+		},
+		{
+			name: "fields and time range",
+			filter: &dashboardv1.EventFilter{
+				Repos: []string{"acme/api"},
+				Since: timestamppb.New(since),
+				Until: timestamppb.New(until),
+			},
+			check: func(t *testing.T, f model.EventFilter) {
+				if len(f.Repos) != 1 || f.Repos[0] != "acme/api" {
+					t.Errorf("Repos = %v", f.Repos)
+				}
+				if f.Since == nil || !f.Since.Equal(since) || f.Until == nil || !f.Until.Equal(until) {
+					t.Errorf("Since/Until = %v/%v", f.Since, f.Until)
+				}
+			},
+		},
+		{
+			name:   "search string applied",
+			filter: &dashboardv1.EventFilter{Q: "repo:acme/web"},
+			check: func(t *testing.T, f model.EventFilter) {
+				if len(f.Repos) != 1 || f.Repos[0] != "acme/web" {
+					t.Errorf("expected Repos to be [acme/web], got %v", f.Repos)
+				}
+			},
+		},
+		{name: "too many values", filter: &dashboardv1.EventFilter{Senders: tooMany}, expectedErr: true},
+		{name: "inverted range", filter: &dashboardv1.EventFilter{Since: timestamppb.New(until), Until: timestamppb.New(since)}, expectedErr: true},
+		{name: "invalid timestamp", filter: &dashboardv1.EventFilter{Since: &timestamppb.Timestamp{Nanos: -1}}, expectedErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := toModelFilter(tt.filter)
+			if (err != nil) != tt.expectedErr {
+				t.Fatalf("expected error %v from toModelFilter(), got %v", tt.expectedErr, err)
+			}
+			if tt.check != nil {
+				tt.check(t, f)
+			}
+		})
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	hub := sse.NewHub(sse.HubOptions{})
+	go hub.Run()
+	client := newTestClient(t, hub, staticAuthenticator{"secret": 1})
+
+	t.Run("rejects missing token", func(t *testing.T) {
+		stream, err := client.Subscribe(context.Background(), &dashboardv1.SubscribeRequest{})
+		if err == nil {
+			_, err = stream.Recv()
+		}
+		if status.Code(err) != codes.Unauthenticated {
+			t.Errorf("expected Subscribe() code to be Unauthenticated, got %v", status.Code(err))
+		}
+	})
+
+	t.Run("rejects unknown token", func(t *testing.T) {
+		ctx := metadata.AppendToOutgoingContext(context.Background(), authorizationKey, "Bearer wrong")
+		_, err := client.GetEvent(ctx, &dashboardv1.GetEventRequest{Id: 1})
+		if status.Code(err) != codes.Unauthenticated {
+			t.Errorf("expected GetEvent() code to be Unauthenticated, got %v", status.Code(err))
+		}
+	})
+
+	t.Run("streams matching events", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		ctx = metadata.AppendToOutgoingContext(ctx, authorizationKey, "Bearer secret")
+		stream, err := client.Subscribe(ctx, &dashboardv1.SubscribeRequest{
+			Filter: &dashboardv1.EventFilter{Repos: []string{"acme/api"}},
+		})
+		if err != nil {
+			t.Fatalf("Subscribe() error = %v", err)
+		}
+		// Wait until the subscription has registered with the hub.
+		for hub.ClientCount() == 0 {
+			time.Sleep(5 * time.Millisecond)
+		}
+		hub.Broadcast(model.Event{ID: 1, RepoName: "acme/web"})
+		hub.Broadcast(model.Event{ID: 2, RepoName: "acme/api", OccurredAt: time.Now()})
+		event, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("Recv() error = %v", err)
+		}
+		if event.GetId() != 2 || event.GetRepoName() != "acme/api" {
+			t.Errorf("expected Recv() to return event 2 in acme/api, got %v", event)
+		}
+	})
+}
+
+func newTestClient(t *testing.T, hub *sse.Hub, auth Authenticator) dashboardv1.EventServiceClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := NewServer(nil, hub, auth)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("grpc.NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return dashboardv1.NewEventServiceClient(conn)
+}
@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/middleware"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/service"
+)
+
+// maxAPITokenRequestBytes limits the body of POST /api/tokens.
+const maxAPITokenRequestBytes = 4 << 10
+
+// APITokensHandler handles personal API token management (/api/tokens). Only requests
+// authenticated by the session may manage tokens, so a leaked token cannot mint more.
+type APITokensHandler struct {
+	tokenService *service.APITokenService
+}
+
+// NewAPITokensHandler creates a new APITokensHandler.
+func NewAPITokensHandler(tokenService *service.APITokenService) *APITokensHandler {
+	return &APITokensHandler{tokenService: tokenService}
+}
+
+// List handles GET /api/tokens and returns the current user's tokens (without secrets).
+func (h *APITokensHandler) List(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if !requireSession(w, r) {
+		return
+	}
+	tokens, err := h.tokenService.ListTokens(middleware.UserIDFromContext(r.Context()))
+	if err != nil {
+		middleware.LogEvent("error", "failed to list api tokens", map[string]interface{}{"error": err.Error()})
+		writeError(w, http.StatusInternalServerError, "failed to list api tokens")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string][]model.APIToken{"tokens": tokens})
+}
+
+// Create handles POST /api/tokens. The response includes the token secret, which is not
+// retrievable later.
+func (h *APITokensHandler) Create(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if !requireSession(w, r) {
+		return
+	}
+	var req model.APITokenRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxAPITokenRequestBytes)).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	token, secret, err := h.tokenService.CreateToken(middleware.UserIDFromContext(r.Context()), req.Name)
+	if errors.Is(err, service.ErrInvalidAPIToken) || errors.Is(err, service.ErrTooManyAPITokens) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		middleware.LogEvent("error", "failed to create api token", map[string]interface{}{"error": err.Error()})
+		writeError(w, http.StatusInternalServerError, "failed to create api token")
+		return
+	}
+	writeJSON(w, http.StatusCreated, model.APITokenResponse{APIToken: *token, Token: secret})
+}
+
+// Delete handles DELETE /api/tokens/{id}. Clients using the token are rejected from then on.
+func (h *APITokensHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if !requireSession(w, r) {
+		return
+	}
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid token id")
+		return
+	}
+	found, err := h.tokenService.DeleteToken(middleware.UserIDFromContext(r.Context()), id)
+	if err != nil {
+		middleware.LogEvent("error", "failed to delete api token", map[string]interface{}{"error": err.Error(), "id": id})
+		writeError(w, http.StatusInternalServerError, "failed to delete api token")
+		return
+	}
+	if !found {
+		writeError(w, http.StatusNotFound, "api token not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// requireSession responds 403 and returns false unless the request was authenticated by the
+// session cookie.
+func requireSession(w http.ResponseWriter, r *http.Request) bool {
+	if middleware.IsSessionAuthenticated(r.Context()) {
+		return true
+	}
+	writeError(w, http.StatusForbidden, "API tokens can only be managed from a signed-in browser session")
+	return false
+}
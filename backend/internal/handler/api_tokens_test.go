@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/sessions"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/auth"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/middleware"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/repository"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/service"
+)
+
+const testSessionSecret = "0123456789abcdef0123456789abcdef"
+
+type staticTokens map[string]int64
+
+func (s staticTokens) Authenticate(token string) (int64, error) {
+	return s[token], nil
+}
+
+// sessionCookie returns the session cookie of a browser signed in as userID.
+func sessionCookie(t *testing.T, userID int64) *http.Cookie {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	if err := auth.NewSessionManager(testSessionSecret, false).SetUserID(rec, httptest.NewRequest(http.MethodGet, "/", nil), userID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return rec.Result().Cookies()[0]
+}
+
+func TestAPITokensRequireSession(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		session        bool
+		expectedStatus int
+	}{
+		{name: "Session lists tokens", method: http.MethodGet, path: "/api/tokens", session: true, expectedStatus: http.StatusOK},
+		{name: "API token cannot list tokens", method: http.MethodGet, path: "/api/tokens", expectedStatus: http.StatusForbidden},
+		{name: "API token cannot create tokens", method: http.MethodPost, path: "/api/tokens", body: `{"name": "more"}`, expectedStatus: http.StatusForbidden},
+		{name: "API token cannot delete tokens", method: http.MethodDelete, path: "/api/tokens/1", expectedStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+			if tt.expectedStatus == http.StatusOK {
+				mock.ExpectQuery(`FROM api_tokens WHERE user_id = \?`).WithArgs(42).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "last_used_at", "created_at"}).
+						AddRow(1, 42, "deploy-bot", nil, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)))
+			}
+			h := NewAPITokensHandler(service.NewAPITokenService(repository.NewAPITokenRepository(db)))
+			r := chi.NewRouter()
+			r.Use(middleware.Auth(sessions.NewCookieStore([]byte(testSessionSecret)), staticTokens{"secret": 42}))
+			r.Get("/api/tokens", h.List)
+			r.Post("/api/tokens", h.Create)
+			r.Delete("/api/tokens/{id}", h.Delete)
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if tt.session {
+				req.AddCookie(sessionCookie(t, 42))
+			} else {
+				req.Header.Set("Authorization", "Bearer secret")
+			}
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/middleware"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/repository"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/service"
+)
+
+const (
+	defaultContributorLimit = 20
+	maxContributorLimit     = 100
+)
+
+// ContributorsHandler handles GET /api/contributors and GET /api/contributors/{login} requests.
+type ContributorsHandler struct {
+	contributorService *service.ContributorService
+}
+
+// NewContributorsHandler creates a new ContributorsHandler.
+func NewContributorsHandler(contributorService *service.ContributorService) *ContributorsHandler {
+	return &ContributorsHandler{contributorService: contributorService}
+}
+
+// List handles GET /api/contributors. It accepts the list filters, sort
+// (total|issues_opened|prs_merged|reviews|pushes), limit and include_bots (default false).
+func (h *ContributorsHandler) List(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	filter, err := parseEventFilter(r)
+	if err != nil {
+		writeFilterError(w, err)
+		return
+	}
+	filter.ExcludeBots = !parseBoolQuery(r, "include_bots", false)
+	sort, err := repository.ParseContributorSort(r.URL.Query().Get("sort"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	limit := parseIntQuery(r, "limit", defaultContributorLimit)
+	if limit < 1 || limit > maxContributorLimit {
+		limit = defaultContributorLimit
+	}
+	result, err := h.contributorService.ListContributors(filter, sort, limit)
+	if err != nil {
+		middleware.LogEvent("error", "failed to list contributors", map[string]interface{}{"error": err.Error()})
+		writeError(w, http.StatusInternalServerError, "failed to list contributors")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(result)
+}
+
+// GetByLogin handles GET /api/contributors/{login}.
+func (h *ContributorsHandler) GetByLogin(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	login := chi.URLParam(r, "login")
+	if login == "" {
+		writeError(w, http.StatusBadRequest, "invalid login")
+		return
+	}
+	filter, err := parseEventFilter(r)
+	if err != nil {
+		writeFilterError(w, err)
+		return
+	}
+	profile, err := h.contributorService.GetContributor(filter, login)
+	if err != nil {
+		middleware.LogEvent("error", "failed to get contributor", map[string]interface{}{"error": err.Error(), "login": login})
+		writeError(w, http.StatusInternalServerError, "failed to get contributor")
+		return
+	}
+	if profile == nil {
+		writeError(w, http.StatusNotFound, "contributor not found")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(profile)
+}
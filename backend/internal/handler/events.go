@@ -2,11 +2,13 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/middleware"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
 	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/service"
 )
 
@@ -26,7 +28,10 @@ func NewEventsHandler(eventService *service.EventService) *EventsHandler {
 	return &EventsHandler{eventService: eventService}
 }
 
-// List handles GET /api/events with pagination and optional event_type filter.
+// List handles GET /api/events with pagination and optional filters (see parseEventFilter).
+// Page-number mode (page/per_page) is the default. Cursor mode is selected by passing
+// pagination=cursor for the first page and cursor=<next_cursor|prev_cursor> afterwards.
+// include_total controls the COUNT(*) query (default: true in page mode, false in cursor mode).
 func (h *EventsHandler) List(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	page := parseIntQuery(r, "page", defaultPage)
@@ -37,8 +42,24 @@ func (h *EventsHandler) List(w http.ResponseWriter, r *http.Request) {
 	if perPage < 1 || perPage > maxPerPage {
 		perPage = defaultPerPage
 	}
-	eventType := r.URL.Query().Get("event_type")
-	result, err := h.eventService.ListEvents(page, perPage, eventType)
+	filter, err := parseEventFilter(r)
+	if err != nil {
+		writeFilterError(w, err)
+		return
+	}
+	cursor := r.URL.Query().Get("cursor")
+	cursorMode := cursor != "" || r.URL.Query().Get("pagination") == "cursor"
+	includeTotal := parseBoolQuery(r, "include_total", !cursorMode)
+	var result *model.EventListResponse
+	if cursorMode {
+		result, err = h.eventService.ListEventsByCursor(filter, cursor, perPage, includeTotal)
+	} else {
+		result, err = h.eventService.ListEvents(filter, page, perPage, includeTotal)
+	}
+	if errors.Is(err, service.ErrInvalidCursor) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 	if err != nil {
 		middleware.LogEvent("error", "failed to list events", map[string]interface{}{"error": err.Error()})
 		writeError(w, http.StatusInternalServerError, "failed to list events")
@@ -71,6 +92,18 @@ func (h *EventsHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(event)
 }
 
+func parseBoolQuery(r *http.Request, key string, defaultVal bool) bool {
+	val := r.URL.Query().Get(key)
+	if val == "" {
+		return defaultVal
+	}
+	parsed, err := strconv.ParseBool(val)
+	if err != nil {
+		return defaultVal
+	}
+	return parsed
+}
+
 func parseIntQuery(r *http.Request, key string, defaultVal int) int {
 	val := r.URL.Query().Get(key)
 	if val == "" {
@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/middleware"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/service"
+)
+
+const (
+	exportFormatCSV    = "csv"
+	exportFormatNDJSON = "ndjson"
+	// exportWriteTimeout replaces the server's WriteTimeout for export responses,
+	// which can take far longer to stream than a regular API response.
+	exportWriteTimeout = 10 * time.Minute
+	// exportTruncatedTrailer is sent as an HTTP trailer once the export is complete.
+	exportTruncatedTrailer = "X-Export-Truncated"
+)
+
+// exportColumns is the CSV header row, in the order exportRecord writes the fields.
+var exportColumns = []string{
+	"id", "delivery_id", "event_type", "action", "repo_name", "sender_login",
+	"title", "body", "html_url", "event_data", "occurred_at", "received_at",
+}
+
+// ExportHandler handles GET /api/events/export requests.
+type ExportHandler struct {
+	eventService *service.EventService
+	maxRows      int
+}
+
+// NewExportHandler creates a new ExportHandler that returns at most maxRows rows per export.
+func NewExportHandler(eventService *service.EventService, maxRows int) *ExportHandler {
+	return &ExportHandler{eventService: eventService, maxRows: maxRows}
+}
+
+// ServeHTTP streams the events matching the list filters (see parseEventFilter), newest
+// first, as CSV (format=csv, the default) or newline-delimited JSON (format=ndjson).
+// limit lowers the row cap for a single export. Whether rows were left out because of the
+// cap is reported in the X-Export-Truncated trailer.
+func (h *ExportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = exportFormatCSV
+	}
+	if format != exportFormatCSV && format != exportFormatNDJSON {
+		w.Header().Set("Content-Type", "application/json")
+		writeError(w, http.StatusBadRequest, "format must be csv or ndjson")
+		return
+	}
+	filter, err := parseEventFilter(r)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		writeFilterError(w, err)
+		return
+	}
+	limit := parseIntQuery(r, "limit", h.maxRows)
+	if limit < 1 || limit > h.maxRows {
+		limit = h.maxRows
+	}
+	// Ignore the error: writers that cannot extend the deadline keep the server's.
+	http.NewResponseController(w).SetWriteDeadline(time.Now().Add(exportWriteTimeout))
+
+	filename := fmt.Sprintf("events-%s.%s", time.Now().UTC().Format("20060102T150405Z"), format)
+	if format == exportFormatCSV {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	} else {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+	}
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Trailer", exportTruncatedTrailer)
+	w.WriteHeader(http.StatusOK)
+
+	buf := bufio.NewWriter(w)
+	var writeRow func(*model.Event) error
+	var flush func() error
+	if format == exportFormatCSV {
+		cw := csv.NewWriter(buf)
+		cw.Write(exportColumns)
+		record := make([]string, len(exportColumns))
+		writeRow = func(e *model.Event) error {
+			return cw.Write(exportRecord(e, record))
+		}
+		flush = func() error {
+			cw.Flush()
+			if err := cw.Error(); err != nil {
+				return err
+			}
+			return buf.Flush()
+		}
+	} else {
+		enc := json.NewEncoder(buf)
+		writeRow = func(e *model.Event) error {
+			return enc.Encode(e)
+		}
+		flush = buf.Flush
+	}
+	truncated, err := h.eventService.ExportEvents(r.Context(), filter, limit, writeRow)
+	if err == nil {
+		err = flush()
+	}
+	if err != nil {
+		// The status line has already been sent; the client sees a short body.
+		middleware.LogEvent("error", "failed to export events", map[string]interface{}{
+			"error":  err.Error(),
+			"format": format,
+		})
+		return
+	}
+	w.Header().Set(exportTruncatedTrailer, strconv.FormatBool(truncated))
+}
+
+// exportRecord fills record with the CSV fields of e and returns it. Every text field comes
+// from a webhook payload, so all of them go through csvSafe.
+func exportRecord(e *model.Event, record []string) []string {
+	record[0] = strconv.FormatInt(e.ID, 10)
+	record[1] = csvSafe(e.DeliveryID)
+	record[2] = csvSafe(e.EventType)
+	record[3] = csvSafe(e.Action)
+	record[4] = csvSafe(e.RepoName)
+	record[5] = csvSafe(e.SenderLogin)
+	record[6] = csvSafe(derefString(e.Title))
+	record[7] = csvSafe(derefString(e.Body))
+	record[8] = csvSafe(e.HTMLURL)
+	record[9] = csvSafe(derefString(e.EventData))
+	record[10] = e.OccurredAt.UTC().Format(time.RFC3339)
+	record[11] = e.ReceivedAt.UTC().Format(time.RFC3339)
+	return record
+}
+
+// csvSafe prefixes values that spreadsheet applications would evaluate as formulas
+// (CSV injection) with a single quote.
+func csvSafe(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
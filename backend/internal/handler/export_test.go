@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/repository"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/service"
+)
+
+func TestCSVSafe(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "Plain text", input: "Fix login bug", expected: "Fix login bug"},
+		{name: "Empty", input: "", expected: ""},
+		{name: "Formula", input: "=HYPERLINK(\"http://evil\")", expected: "'=HYPERLINK(\"http://evil\")"},
+		{name: "Plus", input: "+1 from me", expected: "'+1 from me"},
+		{name: "Minus", input: "-2+3", expected: "'-2+3"},
+		{name: "At sign", input: "@mention", expected: "'@mention"},
+		{name: "Leading tab", input: "\t=1", expected: "'\t=1"},
+		{name: "Inner equals", input: "a=b", expected: "a=b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := csvSafe(tt.input); got != tt.expected {
+				t.Errorf("expected csvSafe(%q) to be %q, got %q", tt.input, tt.expected, got)
+			}
+		})
+	}
+}
+
+// exportRows returns the rows for events 3, 2 and 1, newest first. Event 3 carries values a
+// spreadsheet would evaluate as formulas in every text column.
+func exportRows() *sqlmock.Rows {
+	at := eventTime(3)
+	return eventRows().
+		AddRow(3, "=delivery", "+type", "-action", "@acme/api", "=alice", nil, "=HYPERLINK(\"http://evil\")",
+			"+body", "-url", `=1+1`, at, at, at).
+		AddRow(2, "delivery-2", "issues", "opened", "acme/api", "alice", nil, "Issue 2", nil,
+			"https://github.com/acme/api/issues/2", nil, eventTime(2), eventTime(2), eventTime(2)).
+		AddRow(1, "delivery-1", "issues", "opened", "acme/api", "alice", nil, "Issue 1", nil,
+			"https://github.com/acme/api/issues/1", nil, eventTime(1), eventTime(1), eventTime(1))
+}
+
+func TestExportHandler(t *testing.T) {
+	tests := []struct {
+		name              string
+		query             string
+		maxRows           int
+		expectedLimit     int
+		expectedType      string
+		expectedIDs       []string
+		expectedTruncated string
+	}{
+		{
+			name:              "CSV is the default format",
+			query:             "",
+			maxRows:           10,
+			expectedLimit:     11,
+			expectedType:      "text/csv; charset=utf-8",
+			expectedIDs:       []string{"3", "2", "1"},
+			expectedTruncated: "false",
+		},
+		{
+			name:              "CSV is truncated at the row cap",
+			query:             "?format=csv",
+			maxRows:           2,
+			expectedLimit:     3,
+			expectedType:      "text/csv; charset=utf-8",
+			expectedIDs:       []string{"3", "2"},
+			expectedTruncated: "true",
+		},
+		{
+			name:              "NDJSON writes one event per line",
+			query:             "?format=ndjson",
+			maxRows:           10,
+			expectedLimit:     11,
+			expectedType:      "application/x-ndjson",
+			expectedIDs:       []string{"3", "2", "1"},
+			expectedTruncated: "false",
+		},
+		{
+			name:              "Limit lowers the row cap",
+			query:             "?format=ndjson&limit=1",
+			maxRows:           10,
+			expectedLimit:     2,
+			expectedType:      "application/x-ndjson",
+			expectedIDs:       []string{"3"},
+			expectedTruncated: "true",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+			mock.ExpectQuery(`FROM events ORDER BY received_at DESC, id DESC LIMIT \?`).
+				WithArgs(tt.expectedLimit).
+				WillReturnRows(exportRows())
+			h := NewExportHandler(service.NewEventService(repository.NewEventRepository(db)), tt.maxRows)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/events/export"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			res := rec.Result()
+
+			if res.StatusCode != http.StatusOK {
+				t.Fatalf("expected status %d, got %d", http.StatusOK, res.StatusCode)
+			}
+			if ct := res.Header.Get("Content-Type"); ct != tt.expectedType {
+				t.Errorf("expected Content-Type %q, got %q", tt.expectedType, ct)
+			}
+			var ids []string
+			if tt.expectedType == "application/x-ndjson" {
+				ids = ndjsonIDs(t, res.Body)
+			} else {
+				ids = csvIDs(t, res.Body)
+			}
+			if strings.Join(ids, ",") != strings.Join(tt.expectedIDs, ",") {
+				t.Errorf("expected ids %v, got %v", tt.expectedIDs, ids)
+			}
+			if got := res.Trailer.Get(exportTruncatedTrailer); got != tt.expectedTruncated {
+				t.Errorf("expected %s trailer %q, got %q", exportTruncatedTrailer, tt.expectedTruncated, got)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestExportHandlerEscapesFormulas(t *testing.T) {
+	db, mock := newMockDB(t)
+	mock.ExpectQuery(`FROM events`).WillReturnRows(exportRows())
+	h := NewExportHandler(service.NewEventService(repository.NewEventRepository(db)), 10)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/events/export", nil))
+
+	records, err := csv.NewReader(rec.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{"3", "'=delivery", "'+type", "'-action", "'@acme/api", "'=alice",
+		"'=HYPERLINK(\"http://evil\")", "'+body", "'-url", "'=1+1"}
+	for i, value := range expected {
+		if records[1][i] != value {
+			t.Errorf("expected %s to be %q, got %q", exportColumns[i], value, records[1][i])
+		}
+	}
+}
+
+func TestExportHandlerRejectsUnknownFormat(t *testing.T) {
+	db, _ := newMockDB(t)
+	h := NewExportHandler(service.NewEventService(repository.NewEventRepository(db)), 10)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/events/export?format=xlsx", nil))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	}
+}
+
+func csvIDs(t *testing.T, body io.Reader) []string {
+	t.Helper()
+	records, err := csv.NewReader(body).ReadAll()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(records) == 0 || strings.Join(records[0], ",") != strings.Join(exportColumns, ",") {
+		t.Fatalf("expected the header row %v, got %v", exportColumns, records)
+	}
+	var ids []string
+	for _, record := range records[1:] {
+		ids = append(ids, record[0])
+	}
+	return ids
+}
+
+func ndjsonIDs(t *testing.T, body io.Reader) []string {
+	t.Helper()
+	var ids []string
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		var e model.Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids = append(ids, strconv.FormatInt(e.ID, 10))
+	}
+	return ids
+}
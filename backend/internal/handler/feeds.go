@@ -0,0 +1,369 @@
+package handler
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/middleware"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/service"
+)
+
+const (
+	// feedEntryLimit is the number of most recent events rendered in a feed.
+	feedEntryLimit = 50
+	// maxFeedRequestBytes limits the body of POST /api/feeds.
+	maxFeedRequestBytes = 16 << 10
+	feedGenerator       = "GitHub Events Dashboard"
+)
+
+// FeedsHandler handles saved feed management (/api/feeds, session auth) and feed rendering
+// (/api/feeds/{token}/{id}.atom and .rss, authenticated by the owner's feed token in the URL).
+type FeedsHandler struct {
+	feedService  *service.FeedService
+	eventService *service.EventService
+	publicURL    string
+	frontendURL  string
+}
+
+// NewFeedsHandler creates a new FeedsHandler. publicURL is the backend's external base URL
+// used to build feed URLs; frontendURL is linked as the feeds' alternate page.
+func NewFeedsHandler(feedService *service.FeedService, eventService *service.EventService, publicURL string, frontendURL string) *FeedsHandler {
+	return &FeedsHandler{
+		feedService:  feedService,
+		eventService: eventService,
+		publicURL:    publicURL,
+		frontendURL:  frontendURL,
+	}
+}
+
+// List handles GET /api/feeds and returns the current user's feeds (without tokens).
+func (h *FeedsHandler) List(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	feeds, err := h.feedService.ListFeeds(middleware.UserIDFromContext(r.Context()))
+	if err != nil {
+		middleware.LogEvent("error", "failed to list feeds", map[string]interface{}{"error": err.Error()})
+		writeError(w, http.StatusInternalServerError, "failed to list feeds")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string][]model.Feed{"feeds": feeds})
+}
+
+// Create handles POST /api/feeds. The body is a FeedRequest whose query holds the list
+// filters as URL query parameters. The feed is readable with the user's feed token.
+func (h *FeedsHandler) Create(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	var req model.FeedRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxFeedRequestBytes)).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	values, err := url.ParseQuery(req.Query)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid query: must be URL query parameters")
+		return
+	}
+	if _, err := parseEventFilterValues(values); err != nil {
+		writeFilterError(w, err)
+		return
+	}
+	feed, err := h.feedService.CreateFeed(middleware.UserIDFromContext(r.Context()), req.Name, values.Encode())
+	if errors.Is(err, service.ErrInvalidFeed) || errors.Is(err, service.ErrTooManyFeeds) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		middleware.LogEvent("error", "failed to create feed", map[string]interface{}{"error": err.Error()})
+		writeError(w, http.StatusInternalServerError, "failed to create feed")
+		return
+	}
+	writeJSON(w, http.StatusCreated, feed)
+}
+
+// RegenerateToken handles POST /api/feeds/token. It issues a new feed token for the user
+// and returns it with the URLs of all of the user's feeds; URLs built from the previous
+// token stop working.
+func (h *FeedsHandler) RegenerateToken(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	userID := middleware.UserIDFromContext(r.Context())
+	token, err := h.feedService.RegenerateToken(userID)
+	if err != nil {
+		middleware.LogEvent("error", "failed to regenerate feed token", map[string]interface{}{"error": err.Error()})
+		writeError(w, http.StatusInternalServerError, "failed to regenerate feed token")
+		return
+	}
+	feeds, err := h.feedService.ListFeeds(userID)
+	if err != nil {
+		middleware.LogEvent("error", "failed to list feeds", map[string]interface{}{"error": err.Error()})
+		writeError(w, http.StatusInternalServerError, "failed to list feeds")
+		return
+	}
+	resp := model.FeedTokenResponse{Token: token, Feeds: []model.FeedURLs{}}
+	for _, feed := range feeds {
+		resp.Feeds = append(resp.Feeds, model.FeedURLs{
+			Feed:    feed,
+			AtomURL: h.feedURL(token, feed.ID, "atom"),
+			RSSURL:  h.feedURL(token, feed.ID, "rss"),
+		})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// RevokeToken handles DELETE /api/feeds/token. All of the user's feed URLs stop working
+// until a new token is issued.
+func (h *FeedsHandler) RevokeToken(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	found, err := h.feedService.RevokeToken(middleware.UserIDFromContext(r.Context()))
+	if err != nil {
+		middleware.LogEvent("error", "failed to revoke feed token", map[string]interface{}{"error": err.Error()})
+		writeError(w, http.StatusInternalServerError, "failed to revoke feed token")
+		return
+	}
+	if !found {
+		writeError(w, http.StatusNotFound, "feed token not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Delete handles DELETE /api/feeds/{id}.
+func (h *FeedsHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid feed id")
+		return
+	}
+	found, err := h.feedService.DeleteFeed(middleware.UserIDFromContext(r.Context()), id)
+	if err != nil {
+		middleware.LogEvent("error", "failed to delete feed", map[string]interface{}{"error": err.Error(), "id": id})
+		writeError(w, http.StatusInternalServerError, "failed to delete feed")
+		return
+	}
+	if !found {
+		writeError(w, http.StatusNotFound, "feed not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Atom handles GET /api/feeds/{token}/{id}.atom.
+func (h *FeedsHandler) Atom(w http.ResponseWriter, r *http.Request) {
+	feed, events, ok := h.loadFeed(w, r)
+	if !ok {
+		return
+	}
+	doc := atomFeed{
+		ID:        fmt.Sprintf("urn:github-events-dashboard:feed:%d", feed.ID),
+		Title:     feed.Name,
+		Updated:   feedUpdated(feed, events).Format(time.RFC3339),
+		Generator: feedGenerator,
+		Links: []atomLink{
+			{Href: h.feedURL(chi.URLParam(r, "token"), feed.ID, "atom"), Rel: "self", Type: "application/atom+xml"},
+			{Href: h.frontendURL, Rel: "alternate", Type: "text/html"},
+		},
+	}
+	for _, e := range events {
+		entry := atomEntry{
+			ID:         "urn:uuid:" + e.DeliveryID,
+			Title:      eventEntryTitle(e),
+			Updated:    e.OccurredAt.UTC().Format(time.RFC3339),
+			Author:     atomPerson{Name: e.SenderLogin, URI: "https://github.com/" + url.PathEscape(e.SenderLogin)},
+			Links:      []atomLink{{Href: e.HTMLURL, Rel: "alternate", Type: "text/html"}},
+			Categories: []atomCategory{{Term: e.EventType}, {Term: e.RepoName}},
+		}
+		if e.Body != nil && *e.Body != "" {
+			entry.Summary = &atomText{Type: "text", Body: *e.Body}
+		}
+		doc.Entries = append(doc.Entries, entry)
+	}
+	writeFeedXML(w, "application/atom+xml; charset=utf-8", doc)
+}
+
+// RSS handles GET /api/feeds/{token}/{id}.rss.
+func (h *FeedsHandler) RSS(w http.ResponseWriter, r *http.Request) {
+	feed, events, ok := h.loadFeed(w, r)
+	if !ok {
+		return
+	}
+	channel := rssChannel{
+		Title:         feed.Name,
+		Link:          h.frontendURL,
+		Description:   "GitHub events matching " + feed.Name,
+		LastBuildDate: feedUpdated(feed, events).Format(time.RFC1123Z),
+		Generator:     feedGenerator,
+	}
+	for _, e := range events {
+		item := rssItem{
+			Title:      eventEntryTitle(e),
+			Link:       e.HTMLURL,
+			GUID:       rssGUID{IsPermaLink: "false", Value: "urn:uuid:" + e.DeliveryID},
+			PubDate:    e.OccurredAt.UTC().Format(time.RFC1123Z),
+			Categories: []string{e.EventType, e.RepoName},
+		}
+		if e.Body != nil {
+			item.Description = *e.Body
+		}
+		channel.Items = append(channel.Items, item)
+	}
+	writeFeedXML(w, "application/rss+xml; charset=utf-8", rssDocument{Version: "2.0", Channel: channel})
+}
+
+// loadFeed resolves the feed from the token and ID in the URL and loads the feed's latest
+// events, writing an error response and returning ok=false on failure. Errors are JSON like
+// the rest of the API; feed readers only look at the status.
+func (h *FeedsHandler) loadFeed(w http.ResponseWriter, r *http.Request) (*model.Feed, []model.Event, bool) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		writeFeedError(w, http.StatusNotFound, "feed not found")
+		return nil, nil, false
+	}
+	feed, err := h.feedService.GetFeedByToken(chi.URLParam(r, "token"), id)
+	if err != nil {
+		middleware.LogEvent("error", "failed to find feed", map[string]interface{}{"error": err.Error()})
+		writeFeedError(w, http.StatusInternalServerError, "failed to load feed")
+		return nil, nil, false
+	}
+	if feed == nil {
+		writeFeedError(w, http.StatusNotFound, "feed not found")
+		return nil, nil, false
+	}
+	values, err := url.ParseQuery(feed.Query)
+	if err != nil {
+		writeFeedError(w, http.StatusUnprocessableEntity, "feed filter is invalid")
+		return nil, nil, false
+	}
+	filter, err := parseEventFilterValues(values)
+	if err != nil {
+		writeFeedError(w, http.StatusUnprocessableEntity, "feed filter is invalid: "+err.Error())
+		return nil, nil, false
+	}
+	result, err := h.eventService.ListEvents(filter, 1, feedEntryLimit, false)
+	if err != nil {
+		middleware.LogEvent("error", "failed to list feed events", map[string]interface{}{"error": err.Error(), "feed_id": feed.ID})
+		writeFeedError(w, http.StatusInternalServerError, "failed to load feed")
+		return nil, nil, false
+	}
+	return feed, result.Events, true
+}
+
+func writeFeedError(w http.ResponseWriter, statusCode int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	writeError(w, statusCode, message)
+}
+
+func (h *FeedsHandler) feedURL(token string, id int64, format string) string {
+	return fmt.Sprintf("%s/api/feeds/%s/%d.%s", h.publicURL, token, id, format)
+}
+
+// feedUpdated returns the most recent occurred_at among events, or the feed's last
+// modification when it has no events.
+func feedUpdated(feed *model.Feed, events []model.Event) time.Time {
+	updated := feed.UpdatedAt
+	for _, e := range events {
+		if e.OccurredAt.After(updated) {
+			updated = e.OccurredAt
+		}
+	}
+	return updated.UTC()
+}
+
+// eventEntryTitle formats an event as "owner/repo: event_type action: title".
+func eventEntryTitle(e model.Event) string {
+	title := e.RepoName + ": " + e.EventType
+	if e.Action != "" {
+		title += " " + e.Action
+	}
+	if e.Title != nil && *e.Title != "" {
+		title += ": " + *e.Title
+	}
+	return title
+}
+
+func writeFeedXML(w http.ResponseWriter, contentType string, doc interface{}) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", "private, max-age=60")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(xml.Header))
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		middleware.LogEvent("error", "failed to encode feed", map[string]interface{}{"error": err.Error()})
+	}
+}
+
+type atomFeed struct {
+	XMLName   xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID        string      `xml:"id"`
+	Title     string      `xml:"title"`
+	Updated   string      `xml:"updated"`
+	Generator string      `xml:"generator"`
+	Links     []atomLink  `xml:"link"`
+	Entries   []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Updated    string         `xml:"updated"`
+	Author     atomPerson     `xml:"author"`
+	Links      []atomLink     `xml:"link"`
+	Categories []atomCategory `xml:"category"`
+	Summary    *atomText      `xml:"summary,omitempty"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+	URI  string `xml:"uri,omitempty"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomText struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+type rssDocument struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Generator     string    `xml:"generator"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	GUID        rssGUID  `xml:"guid"`
+	PubDate     string   `xml:"pubDate"`
+	Categories  []string `xml:"category"`
+	Description string   `xml:"description,omitempty"`
+}
+
+type rssGUID struct {
+	IsPermaLink string `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-chi/chi/v5"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/repository"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/service"
+)
+
+const testFeedToken = "0123abcd"
+
+var feedColumns = []string{"id", "user_id", "name", "filter_query", "created_at", "updated_at"}
+
+// newFeedTestRouter serves the feed rendering routes backed by a mocked database.
+func newFeedTestRouter(t *testing.T) (http.Handler, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock := newMockDB(t)
+	h := NewFeedsHandler(service.NewFeedService(repository.NewFeedRepository(db)),
+		service.NewEventService(repository.NewEventRepository(db)), "https://api.example.com", "https://dashboard.example.com")
+	r := chi.NewRouter()
+	r.Get("/api/feeds/{token}/{id}.atom", h.Atom)
+	r.Get("/api/feeds/{token}/{id}.rss", h.RSS)
+	return r, mock
+}
+
+// expectFeed expects the lookup of feed 12 with testFeedToken, followed by its events:
+// event 2 and event 1, whose occurred_at is an hour before it was received.
+func expectFeed(mock sqlmock.Sqlmock) {
+	sum := sha256.Sum256([]byte(testFeedToken))
+	updated := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`FROM feeds WHERE id = \? AND user_id = \(SELECT user_id FROM feed_tokens WHERE token_hash = \?\)`).
+		WithArgs(12, hex.EncodeToString(sum[:])).
+		WillReturnRows(sqlmock.NewRows(feedColumns).AddRow(12, 1, "API issues", "repo=acme%2Fapi", updated, updated))
+	occurred := eventTime(1).Add(-time.Hour)
+	mock.ExpectQuery(`FROM events WHERE repo_name = \?`).
+		WithArgs("acme/api", feedEntryLimit, 0).
+		WillReturnRows(eventRows(2).
+			AddRow(1, "delivery-1", "issues", "opened", "acme/api", "alice", nil, "Issue 1", "Steps to reproduce",
+				"https://github.com/acme/api/issues/1", nil, occurred, eventTime(1), eventTime(1)))
+}
+
+func TestFeedsAtom(t *testing.T) {
+	r, mock := newFeedTestRouter(t)
+	expectFeed(mock)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/feeds/"+testFeedToken+"/12.atom", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/atom+xml; charset=utf-8" {
+		t.Errorf("expected Content-Type %q, got %q", "application/atom+xml; charset=utf-8", ct)
+	}
+	var doc atomFeed
+	if err := xml.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if doc.ID != "urn:github-events-dashboard:feed:12" || doc.Title != "API issues" {
+		t.Errorf("expected feed 12 titled %q, got %q titled %q", "API issues", doc.ID, doc.Title)
+	}
+	if expected := eventTime(2).Format(time.RFC3339); doc.Updated != expected {
+		t.Errorf("expected updated %s, got %s", expected, doc.Updated)
+	}
+	if len(doc.Links) == 0 || doc.Links[0].Href != "https://api.example.com/api/feeds/"+testFeedToken+"/12.atom" {
+		t.Errorf("expected a self link to the feed, got %+v", doc.Links)
+	}
+	if len(doc.Entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(doc.Entries))
+	}
+	entry := doc.Entries[1]
+	if entry.ID != "urn:uuid:delivery-1" {
+		t.Errorf("expected entry id %q, got %q", "urn:uuid:delivery-1", entry.ID)
+	}
+	if expected := eventTime(1).Add(-time.Hour).Format(time.RFC3339); entry.Updated != expected {
+		t.Errorf("expected entry updated %s, got %s", expected, entry.Updated)
+	}
+	if entry.Title != "acme/api: issues opened: Issue 1" {
+		t.Errorf("expected entry title %q, got %q", "acme/api: issues opened: Issue 1", entry.Title)
+	}
+	if entry.Summary == nil || entry.Summary.Body != "Steps to reproduce" {
+		t.Errorf("expected the body as the entry summary, got %+v", entry.Summary)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestFeedsRSS(t *testing.T) {
+	r, mock := newFeedTestRouter(t)
+	expectFeed(mock)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/feeds/"+testFeedToken+"/12.rss", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/rss+xml; charset=utf-8" {
+		t.Errorf("expected Content-Type %q, got %q", "application/rss+xml; charset=utf-8", ct)
+	}
+	var doc rssDocument
+	if err := xml.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if doc.Version != "2.0" || doc.Channel.Title != "API issues" || doc.Channel.Link != "https://dashboard.example.com" {
+		t.Errorf("unexpected channel: version %q, %+v", doc.Version, doc.Channel)
+	}
+	if len(doc.Channel.Items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(doc.Channel.Items))
+	}
+	item := doc.Channel.Items[1]
+	if item.GUID.Value != "urn:uuid:delivery-1" || item.GUID.IsPermaLink != "false" {
+		t.Errorf("expected a non-permalink guid %q, got %+v", "urn:uuid:delivery-1", item.GUID)
+	}
+	if expected := eventTime(1).Add(-time.Hour).Format(time.RFC1123Z); item.PubDate != expected {
+		t.Errorf("expected pubDate %s, got %s", expected, item.PubDate)
+	}
+	if item.Link != "https://github.com/acme/api/issues/1" || item.Description != "Steps to reproduce" {
+		t.Errorf("unexpected item: %+v", item)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestFeedsNotFound(t *testing.T) {
+	tests := []struct {
+		name        string
+		path        string
+		expectQuery bool
+	}{
+		{name: "Unknown token or another user's feed", path: "/api/feeds/" + testFeedToken + "/13.atom", expectQuery: true},
+		{name: "Invalid feed id", path: "/api/feeds/" + testFeedToken + "/abc.rss", expectQuery: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, mock := newFeedTestRouter(t)
+			if tt.expectQuery {
+				mock.ExpectQuery(`FROM feeds`).WillReturnRows(sqlmock.NewRows(feedColumns))
+			}
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if rec.Code != http.StatusNotFound {
+				t.Errorf("expected status %d, got %d", http.StatusNotFound, rec.Code)
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("expected Content-Type %q, got %q", "application/json", ct)
+			}
+			var body ErrorResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil || body.Error != "feed not found" {
+				t.Errorf("expected error %q, got %q (%v)", "feed not found", rec.Body.String(), err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/search"
+)
+
+// parseEventFilter builds an EventFilter from the request's query string
+// (see parseEventFilterValues).
+func parseEventFilter(r *http.Request) (model.EventFilter, error) {
+	return parseEventFilterValues(r.URL.Query())
+}
+
+// parseEventFilterValues builds an EventFilter from query parameters with
+// search.BuildFilter, which the GraphQL and gRPC APIs share.
+// Multi-valued parameters (event_type, repo, owner/org, sender, action) accept
+// both repeated keys (?repo=a&repo=b) and comma-separated values (?repo=a,b).
+// since/until accept RFC3339 timestamps or YYYY-MM-DD dates (interpreted as UTC); until is
+// exclusive, so a date-only until includes the whole day it names.
+// q is a search string (see package search) whose qualifiers are merged into the filter.
+func parseEventFilterValues(query url.Values) (model.EventFilter, error) {
+	in := search.FilterInput{
+		EventTypes: parseListQuery(query, "event_type"),
+		Repos:      parseListQuery(query, "repo"),
+		Owners:     parseListQuery(query, "owner", "org"),
+		Senders:    parseListQuery(query, "sender"),
+		Actions:    parseListQuery(query, "action"),
+		Q:          query.Get("q"),
+	}
+	var err error
+	if in.Since, err = parseTimeQuery(query, "since", false); err != nil {
+		return model.EventFilter{}, err
+	}
+	if in.Until, err = parseTimeQuery(query, "until", true); err != nil {
+		return model.EventFilter{}, err
+	}
+	return search.BuildFilter(in)
+}
+
+// SearchErrorResponse is returned with 400 when the q search string cannot be parsed.
+type SearchErrorResponse struct {
+	Error   string             `json:"error"`
+	Details search.ParseErrors `json:"details"`
+}
+
+// writeFilterError responds 400 for an error returned by parseEventFilter,
+// including per-position details when the search string failed to parse.
+func writeFilterError(w http.ResponseWriter, err error) {
+	var parseErrs search.ParseErrors
+	if errors.As(err, &parseErrs) {
+		writeJSON(w, http.StatusBadRequest, SearchErrorResponse{Error: "invalid search query", Details: parseErrs})
+		return
+	}
+	writeError(w, http.StatusBadRequest, err.Error())
+}
+
+// parseListQuery collects the values of one or more query keys, splitting on commas.
+// The list is named after the first key.
+func parseListQuery(query url.Values, keys ...string) search.FilterList {
+	list := search.FilterList{Name: keys[0]}
+	for _, key := range keys {
+		for _, raw := range query[key] {
+			list.Values = append(list.Values, strings.Split(raw, ",")...)
+		}
+	}
+	return list
+}
+
+// parseTimeQuery parses an RFC3339 timestamp or a YYYY-MM-DD date. With endOfDay, a date
+// is moved to the start of the next day, for exclusive upper bounds.
+func parseTimeQuery(query url.Values, key string, endOfDay bool) (*time.Time, error) {
+	val := query.Get(key)
+	if val == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, val); err == nil {
+		t = t.UTC()
+		return &t, nil
+	}
+	if t, err := time.Parse(time.DateOnly, val); err == nil {
+		if endOfDay {
+			t = t.AddDate(0, 0, 1)
+		}
+		return &t, nil
+	}
+	return nil, fmt.Errorf("invalid %s: expected RFC3339 timestamp or YYYY-MM-DD", key)
+}
+
+// parseLocationQuery returns the IANA time zone named by the tz parameter (default UTC).
+func parseLocationQuery(r *http.Request) (*time.Location, error) {
+	tz := r.URL.Query().Get("tz")
+	if tz == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tz: unknown time zone")
+	}
+	return loc, nil
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestParseEventFilterTimeRange(t *testing.T) {
+	date := func(s string) time.Time {
+		t, _ := time.Parse(time.RFC3339, s)
+		return t
+	}
+	tests := []struct {
+		name          string
+		query         string
+		expectedSince *time.Time
+		expectedUntil *time.Time
+		expectedErr   bool
+	}{
+		{name: "no range", query: ""},
+		{
+			name:          "timestamps are kept as given",
+			query:         "since=2026-03-01T09:00:00%2B09:00&until=2026-03-02T00:00:00Z",
+			expectedSince: ptr(date("2026-03-01T00:00:00Z")),
+			expectedUntil: ptr(date("2026-03-02T00:00:00Z")),
+		},
+		{
+			name:          "date-only until includes the whole day",
+			query:         "since=2026-03-01&until=2026-03-01",
+			expectedSince: ptr(date("2026-03-01T00:00:00Z")),
+			expectedUntil: ptr(date("2026-03-02T00:00:00Z")),
+		},
+		{
+			name:          "date-only until at the end of a month",
+			query:         "until=2026-02-28",
+			expectedUntil: ptr(date("2026-03-01T00:00:00Z")),
+		},
+		{name: "since after until", query: "since=2026-03-02&until=2026-03-01", expectedErr: true},
+		{name: "invalid until", query: "until=tomorrow", expectedErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("invalid test query: %v", err)
+			}
+			filter, err := parseEventFilterValues(query)
+			if tt.expectedErr {
+				if err == nil {
+					t.Errorf("expected an error, got filter %+v", filter)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if !sameTime(filter.Since, tt.expectedSince) {
+				t.Errorf("expected since %v, got %v", tt.expectedSince, filter.Since)
+			}
+			if !sameTime(filter.Until, tt.expectedUntil) {
+				t.Errorf("expected until %v, got %v", tt.expectedUntil, filter.Until)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/graph"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/middleware"
+)
+
+// maxGraphQLRequestBytes caps the size of a POST /api/graphql body.
+const maxGraphQLRequestBytes = 64 << 10
+
+// GraphQLHandler handles /api/graphql requests.
+type GraphQLHandler struct {
+	server *graph.Server
+	// heartbeatInterval is the interval of keep-alive comments on idle subscription
+	// streams; 0 disables them.
+	heartbeatInterval time.Duration
+}
+
+// NewGraphQLHandler creates a new GraphQLHandler.
+func NewGraphQLHandler(server *graph.Server, heartbeatInterval time.Duration) *GraphQLHandler {
+	return &GraphQLHandler{server: server, heartbeatInterval: heartbeatInterval}
+}
+
+// ServeHTTP executes a GraphQL request sent as a JSON POST body or as query, operationName
+// and variables query parameters. Subscriptions require Accept: text/event-stream and are
+// streamed as SSE: one "next" event per result, then "complete" when the stream ends.
+func (h *GraphQLHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	req, err := parseGraphQLRequest(w, r)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	op, errResp := h.server.Prepare(req)
+	if errResp != nil {
+		w.Header().Set("Content-Type", "application/json")
+		writeJSON(w, http.StatusBadRequest, errResp)
+		return
+	}
+	if op == ast.Subscription {
+		h.subscribe(w, r, req)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	writeJSON(w, http.StatusOK, h.server.Exec(r.Context(), req))
+}
+
+// Schema serves the schema in GraphQL SDL.
+func (h *GraphQLHandler) Schema(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, h.server.SDL())
+}
+
+func (h *GraphQLHandler) subscribe(w http.ResponseWriter, r *http.Request, req graph.Request) {
+	if !strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+		w.Header().Set("Content-Type", "application/json")
+		writeError(w, http.StatusNotAcceptable, "subscriptions require Accept: text/event-stream")
+		return
+	}
+	if _, ok := w.(http.Flusher); !ok {
+		w.Header().Set("Content-Type", "application/json")
+		writeError(w, http.StatusInternalServerError, "streaming not supported")
+		return
+	}
+	ctx := r.Context()
+	results, err := h.server.Subscribe(ctx, req)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	// Subscriptions stay open far longer than the server's WriteTimeout allows.
+	stream := newSSEWriter(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	if err := stream.open(); err != nil {
+		return
+	}
+	middleware.LogEvent("info", "GraphQL subscription started", map[string]interface{}{
+		"remote_addr": r.RemoteAddr,
+	})
+	if h.streamResults(stream, results) && ctx.Err() == nil {
+		stream.event("", "complete", nil)
+	}
+	middleware.LogEvent("info", "GraphQL subscription closed", map[string]interface{}{
+		"remote_addr": r.RemoteAddr,
+	})
+}
+
+// streamResults writes each result as a next event, with heartbeats while idle, until the
+// results end. It returns false if the client could not be written to.
+func (h *GraphQLHandler) streamResults(stream *sseWriter, results <-chan interface{}) bool {
+	var heartbeat <-chan time.Time
+	if h.heartbeatInterval > 0 {
+		ticker := time.NewTicker(h.heartbeatInterval)
+		defer ticker.Stop()
+		heartbeat = ticker.C
+	}
+	for {
+		select {
+		case <-heartbeat:
+			if err := stream.heartbeat(); err != nil {
+				return false
+			}
+		case result, ok := <-results:
+			if !ok {
+				return true
+			}
+			data, err := json.Marshal(result)
+			if err != nil {
+				middleware.LogEvent("error", "failed to encode GraphQL subscription result", map[string]interface{}{"error": err.Error()})
+				continue
+			}
+			if err := stream.event("", "next", data); err != nil {
+				return false
+			}
+		}
+	}
+}
+
+func parseGraphQLRequest(w http.ResponseWriter, r *http.Request) (graph.Request, error) {
+	var req graph.Request
+	if r.Method == http.MethodPost {
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxGraphQLRequestBytes)).Decode(&req); err != nil {
+			return req, fmt.Errorf("invalid request body")
+		}
+	} else {
+		query := r.URL.Query()
+		req.Query = query.Get("query")
+		req.OperationName = query.Get("operationName")
+		if v := query.Get("variables"); v != "" {
+			if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+				return req, fmt.Errorf("invalid variables: must be a JSON object")
+			}
+		}
+	}
+	if strings.TrimSpace(req.Query) == "" {
+		return req, fmt.Errorf("query is required")
+	}
+	return req, nil
+}
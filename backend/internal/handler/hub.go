@@ -0,0 +1,25 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/sse"
+)
+
+// HubHandler handles GET /api/hub requests.
+type HubHandler struct {
+	hub *sse.Hub
+}
+
+// NewHubHandler creates a new HubHandler.
+func NewHubHandler(hub *sse.Hub) *HubHandler {
+	return &HubHandler{hub: hub}
+}
+
+// ServeHTTP reports the live event hub of this replica: its clients, its queue and the
+// clients lagging the most. It lists per-client details, so unlike /api/health it
+// requires authentication.
+func (h *HubHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	writeJSON(w, http.StatusOK, h.hub.Stats())
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/middleware"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/service"
+)
+
+// MetricsHandler handles GET /api/metrics/* requests.
+type MetricsHandler struct {
+	metricsService *service.MetricsService
+}
+
+// NewMetricsHandler creates a new MetricsHandler.
+func NewMetricsHandler(metricsService *service.MetricsService) *MetricsHandler {
+	return &MetricsHandler{metricsService: metricsService}
+}
+
+// PullRequests handles GET /api/metrics/pull-requests. It accepts the list filters
+// (typically repo/owner and since/until) and tz for week boundaries.
+func (h *MetricsHandler) PullRequests(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	filter, err := parseEventFilter(r)
+	if err != nil {
+		writeFilterError(w, err)
+		return
+	}
+	loc, err := parseLocationQuery(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	result, err := h.metricsService.GetPullRequestMetrics(filter, loc)
+	if errors.Is(err, service.ErrRangeTooLong) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		middleware.LogEvent("error", "failed to compute pull request metrics", map[string]interface{}{"error": err.Error()})
+		writeError(w, http.StatusInternalServerError, "failed to compute pull request metrics")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(result)
+}
+
+// DORA handles GET /api/metrics/dora. It accepts the list filters (typically repo/owner
+// and since/until).
+func (h *MetricsHandler) DORA(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	filter, err := parseEventFilter(r)
+	if err != nil {
+		writeFilterError(w, err)
+		return
+	}
+	result, err := h.metricsService.GetDORAMetrics(filter)
+	if errors.Is(err, service.ErrRangeTooLong) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		middleware.LogEvent("error", "failed to compute DORA metrics", map[string]interface{}{"error": err.Error()})
+		writeError(w, http.StatusInternalServerError, "failed to compute DORA metrics")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(result)
+}
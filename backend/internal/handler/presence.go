@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/middleware"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/service"
+)
+
+// PresenceHandler handles GET /api/presence requests.
+type PresenceHandler struct {
+	presenceService *service.PresenceService
+}
+
+// NewPresenceHandler creates a new PresenceHandler. A nil presenceService means presence
+// is unavailable, because it is counted per replica and the server may run several.
+func NewPresenceHandler(presenceService *service.PresenceService) *PresenceHandler {
+	return &PresenceHandler{presenceService: presenceService}
+}
+
+// ServeHTTP lists the signed-in users connected to the live streams, with their avatars
+// and number of open connections.
+func (h *PresenceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if h.presenceService == nil {
+		writeError(w, http.StatusNotImplemented, "presence is only available with BROADCAST_BACKEND=memory")
+		return
+	}
+	presence, err := h.presenceService.Online()
+	if err != nil {
+		middleware.LogEvent("error", "failed to list online users", map[string]interface{}{"error": err.Error()})
+		writeError(w, http.StatusInternalServerError, "failed to list online users")
+		return
+	}
+	writeJSON(w, http.StatusOK, presence)
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/middleware"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/service"
+)
+
+// SLAHandler handles GET /api/sla/* requests.
+type SLAHandler struct {
+	slaService *service.SLAService
+}
+
+// NewSLAHandler creates a new SLAHandler.
+func NewSLAHandler(slaService *service.SLAService) *SLAHandler {
+	return &SLAHandler{slaService: slaService}
+}
+
+// Issues handles GET /api/sla/issues. It accepts the repo/owner filters and since/until,
+// which select issues by when they were opened.
+func (h *SLAHandler) Issues(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	filter, err := parseEventFilter(r)
+	if err != nil {
+		writeFilterError(w, err)
+		return
+	}
+	result, err := h.slaService.GetIssueSLA(filter)
+	if errors.Is(err, service.ErrRangeTooLong) || errors.Is(err, service.ErrTooMuchActivity) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		middleware.LogEvent("error", "failed to compute issue SLA", map[string]interface{}{"error": err.Error()})
+		writeError(w, http.StatusInternalServerError, "failed to compute issue SLA")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(result)
+}
+
+// Breaches handles GET /api/sla/breaches. It accepts the same parameters as Issues.
+func (h *SLAHandler) Breaches(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	filter, err := parseEventFilter(r)
+	if err != nil {
+		writeFilterError(w, err)
+		return
+	}
+	result, err := h.slaService.ListBreaches(filter)
+	if errors.Is(err, service.ErrRangeTooLong) || errors.Is(err, service.ErrTooMuchActivity) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		middleware.LogEvent("error", "failed to list SLA breaches", map[string]interface{}{"error": err.Error()})
+		writeError(w, http.StatusInternalServerError, "failed to list SLA breaches")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(result)
+}
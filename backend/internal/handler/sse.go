@@ -1,41 +1,180 @@
 package handler
 
 import (
+	"context"
+	crand "crypto/rand"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"math/rand/v2"
 	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
 
 	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/middleware"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/service"
 	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/sse"
 )
 
-// SSEHandler handles GET /api/events/stream for Server-Sent Events.
+const (
+	// sseReplayBatchSize is the number of missed events loaded per query when a client
+	// resumes with Last-Event-ID.
+	sseReplayBatchSize = 200
+	// sseLifetimeJitter is the largest fraction by which a stream's lifetime is shortened
+	// at random, so clients connected at the same time do not all reconnect together.
+	sseLifetimeJitter = 0.1
+)
+
+// SSEOptions configures how long-lived event streams are kept open.
+type SSEOptions struct {
+	// HeartbeatInterval is the interval of keep-alive comments on idle streams; 0 disables
+	// them.
+	HeartbeatInterval time.Duration
+	// Retry is the reconnection delay suggested to clients with the retry: field.
+	Retry time.Duration
+	// MaxLifetime closes streams after about this long, telling clients to reconnect;
+	// 0 keeps them open indefinitely.
+	MaxLifetime time.Duration
+}
+
+// SSESubscription is the data of the subscribed frame that opens every stream. The ID
+// identifies the stream in PUT /api/events/stream/subscriptions/{id}.
+type SSESubscription struct {
+	SubscriptionID string `json:"subscription_id"`
+}
+
+// SSEResync is the data of the resync frame that ends a stream whose client fell too far
+// behind. LastEventID is the last event the stream delivered (0 if none); reconnecting
+// with it as Last-Event-ID replays the events that were skipped.
+type SSEResync struct {
+	LastEventID int64 `json:"last_event_id"`
+}
+
+// sseSubscription is an open stream whose filter its owner may change.
+type sseSubscription struct {
+	userID int64
+	client chan sse.Envelope
+}
+
+// SSEHandler handles GET /api/events/stream for Server-Sent Events and updates to the
+// filters of open streams.
 type SSEHandler struct {
-	hub *sse.Hub
+	hub    *sse.Hub
+	events *service.EventService
+	opts   SSEOptions
+
+	mu            sync.RWMutex
+	subscriptions map[string]*sseSubscription
 }
 
 // NewSSEHandler creates a new SSEHandler.
-func NewSSEHandler(hub *sse.Hub) *SSEHandler {
-	return &SSEHandler{hub: hub}
+func NewSSEHandler(hub *sse.Hub, events *service.EventService, opts SSEOptions) *SSEHandler {
+	return &SSEHandler{hub: hub, events: events, opts: opts, subscriptions: make(map[string]*sseSubscription)}
 }
 
-// ServeHTTP streams events to the client via SSE.
+// ServeHTTP streams the events matching the request's filters (the same query parameters
+// as GET /api/events) to the client via SSE. The stream opens with a subscribed frame
+// carrying the subscription ID. Every event frame carries the event ID, and a client
+// reconnecting with Last-Event-ID (or ?last_event_id=) first receives the matching events
+// it missed. Idle streams carry heartbeat comments, and after MaxLifetime the stream ends
+// with a reconnect frame. A client that falls behind the hub is sent a resync frame
+// before the stream closes. Other hub messages are sent as frames named after their type;
+// a server_shutdown frame ends the stream.
 func (h *SSEHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	flusher, ok := w.(http.Flusher)
-	if !ok {
+	if _, ok := w.(http.Flusher); !ok {
 		writeError(w, http.StatusInternalServerError, "streaming not supported")
 		return
 	}
+	filter, err := parseEventFilter(r)
+	if err != nil {
+		writeFilterError(w, err)
+		return
+	}
+	subscriptionID, err := newSubscriptionID()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to start stream")
+		return
+	}
+	// EventSource sends Last-Event-ID itself when it reconnects; pages that open a new
+	// EventSource pass the ID as a query parameter instead.
+	lastEventIDValue := r.Header.Get("Last-Event-ID")
+	if lastEventIDValue == "" {
+		lastEventIDValue = r.URL.Query().Get("last_event_id")
+	}
+	lastEventID := parseLastEventID(lastEventIDValue)
 	w.Header().Set("Content-Type", "text/event-stream")
 	w.Header().Set("Cache-Control", "no-cache")
 	w.Header().Set("Connection", "keep-alive")
 	w.Header().Set("Access-Control-Allow-Origin", "*")
-	client := make(chan []byte, 64)
-	h.hub.Register(client)
+	// Register before replaying so events received during the replay are buffered rather
+	// than lost; the ones the replay already sent are skipped below.
+	client := h.hub.NewClient()
+	h.hub.Subscribe(client, filter)
 	defer h.hub.Unregister(client)
 	ctx := r.Context()
+	userID := middleware.UserIDFromContext(ctx)
+	// Only browser sessions watch the dashboard; API token clients are scripts and
+	// integrations, so they do not count towards presence.
+	if middleware.IsSessionAuthenticated(ctx) {
+		h.hub.Join(userID)
+		defer h.hub.Leave(userID)
+	}
+	h.mu.Lock()
+	h.subscriptions[subscriptionID] = &sseSubscription{userID: userID, client: client}
+	h.mu.Unlock()
+	defer func() {
+		h.mu.Lock()
+		delete(h.subscriptions, subscriptionID)
+		h.mu.Unlock()
+	}()
+	// Send the subscription right away so clients see the stream open before the first event.
+	stream := newSSEWriter(w)
+	w.WriteHeader(http.StatusOK)
+	if h.opts.Retry > 0 {
+		if err := stream.retry(h.opts.Retry); err != nil {
+			return
+		}
+	}
+	subscribed, _ := json.Marshal(SSESubscription{SubscriptionID: subscriptionID})
+	if err := stream.event("", "subscribed", subscribed); err != nil {
+		return
+	}
 	middleware.LogEvent("info", "SSE stream started", map[string]interface{}{
-		"remote_addr": r.RemoteAddr,
+		"remote_addr":     r.RemoteAddr,
+		"subscription_id": subscriptionID,
+		"last_event_id":   lastEventID,
 	})
+	// lastSent is the ID of the last event written to the stream.
+	lastSent := lastEventID
+	var replayed map[int64]bool
+	if lastEventID > 0 {
+		replayed, lastSent, err = h.replay(ctx, stream, filter, lastEventID)
+		if err != nil {
+			// Closing the stream makes the client reconnect from the last event it received.
+			middleware.LogEvent("error", "failed to replay SSE events", map[string]interface{}{
+				"remote_addr":   r.RemoteAddr,
+				"last_event_id": lastEventID,
+				"error":         err.Error(),
+			})
+			return
+		}
+	}
+	var heartbeat <-chan time.Time
+	if h.opts.HeartbeatInterval > 0 {
+		ticker := time.NewTicker(h.opts.HeartbeatInterval)
+		defer ticker.Stop()
+		heartbeat = ticker.C
+	}
+	var expired <-chan time.Time
+	if h.opts.MaxLifetime > 0 {
+		timer := time.NewTimer(jitterLifetime(h.opts.MaxLifetime))
+		defer timer.Stop()
+		expired = timer.C
+	}
 	for {
 		select {
 		case <-ctx.Done():
@@ -43,12 +182,125 @@ func (h *SSEHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 				"remote_addr": r.RemoteAddr,
 			})
 			return
-		case data, ok := <-client:
+		case <-expired:
+			// Clients reconnect with Last-Event-ID, so nothing is lost across the switch.
+			stream.event("", "reconnect", []byte("{}"))
+			middleware.LogEvent("info", "SSE stream reached max lifetime", map[string]interface{}{
+				"remote_addr": r.RemoteAddr,
+			})
+			return
+		case <-heartbeat:
+			if err := stream.heartbeat(); err != nil {
+				return
+			}
+		case env, ok := <-client:
 			if !ok {
+				// The hub dropped the client after it fell behind; everything buffered
+				// before that has been written, so the client can resume from lastSent.
+				resync, _ := json.Marshal(SSEResync{LastEventID: lastSent})
+				stream.event("", "resync", resync)
+				middleware.LogEvent("warn", "SSE stream dropped for falling behind", map[string]interface{}{
+					"remote_addr":     r.RemoteAddr,
+					"subscription_id": subscriptionID,
+					"last_event_id":   lastSent,
+				})
 				return
 			}
-			fmt.Fprintf(w, "event: new_event\ndata: %s\n\n", data)
-			flusher.Flush()
+			switch env.Type {
+			case sse.MessageNewEvent:
+				if replayed[env.EventID] {
+					delete(replayed, env.EventID)
+					continue
+				}
+				if err := stream.event(strconv.FormatInt(env.EventID, 10), string(env.Type), env.Data); err != nil {
+					return
+				}
+				lastSent = env.EventID
+			case sse.MessageServerShutdown:
+				// Clients reconnect after their retry delay, reaching another replica.
+				stream.event("", string(env.Type), env.Data)
+				return
+			default:
+				// Only new events carry an id, so that Last-Event-ID keeps pointing at the
+				// last one received.
+				if err := stream.event("", string(env.Type), env.Data); err != nil {
+					return
+				}
+			}
 		}
 	}
 }
+
+// UpdateSubscription handles PUT /api/events/stream/subscriptions/{id}, replacing the
+// filters of one of the caller's open streams with those in the query string. Events
+// received from then on are routed with the new filters; nothing is replayed.
+func (h *SSEHandler) UpdateSubscription(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseEventFilter(r)
+	if err != nil {
+		writeFilterError(w, err)
+		return
+	}
+	h.mu.RLock()
+	sub := h.subscriptions[chi.URLParam(r, "id")]
+	h.mu.RUnlock()
+	if sub == nil || sub.userID != middleware.UserIDFromContext(r.Context()) {
+		writeError(w, http.StatusNotFound, "subscription not found")
+		return
+	}
+	h.hub.SetFilter(sub.client, filter)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// replay writes every matching event stored after lastEventID and returns the IDs it
+// sent, so the live loop can drop the copies the hub buffered in the meantime, and the
+// last of them.
+// Deduplicating by ID rather than by the highest ID replayed keeps events that committed
+// out of ID order.
+func (h *SSEHandler) replay(ctx context.Context, stream *sseWriter, filter model.EventFilter, lastEventID int64) (map[int64]bool, int64, error) {
+	replayed := make(map[int64]bool)
+	afterID := lastEventID
+	for {
+		events, err := h.events.ListEventsAfterID(ctx, filter, afterID, sseReplayBatchSize)
+		if err != nil {
+			return nil, 0, err
+		}
+		for i := range events {
+			data, err := json.Marshal(events[i])
+			if err != nil {
+				return nil, 0, fmt.Errorf("failed to marshal event: %w", err)
+			}
+			if err := stream.event(strconv.FormatInt(events[i].ID, 10), "new_event", data); err != nil {
+				return nil, 0, err
+			}
+			replayed[events[i].ID] = true
+			afterID = events[i].ID
+		}
+		if len(events) < sseReplayBatchSize {
+			return replayed, afterID, nil
+		}
+	}
+}
+
+// jitterLifetime shortens lifetime by up to sseLifetimeJitter at random.
+func jitterLifetime(lifetime time.Duration) time.Duration {
+	return lifetime - time.Duration(rand.Float64()*sseLifetimeJitter*float64(lifetime))
+}
+
+// newSubscriptionID returns a random, unguessable stream subscription ID.
+func newSubscriptionID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := crand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// parseLastEventID returns the event ID from a Last-Event-ID header, or 0 if it is absent
+// or not an event ID.
+func parseLastEventID(value string) int64 {
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || id < 0 {
+		return 0
+	}
+	return id
+}
@@ -0,0 +1,534 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/sessions"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/middleware"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/repository"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/service"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/sse"
+)
+
+var eventColumns = []string{
+	"id", "delivery_id", "event_type", "action", "repo_name", "sender_login", "sender_avatar_url",
+	"title", "body", "html_url", "event_data", "occurred_at", "received_at", "created_at",
+}
+
+// eventTime is the received_at of the event with the given ID in eventRows: one minute
+// per ID after 2026-03-01 12:00 UTC.
+func eventTime(id int64) time.Time {
+	return time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC).Add(time.Duration(id) * time.Minute)
+}
+
+// eventRows returns one "issues opened" row in acme/api per ID, titled "Issue <id>".
+func eventRows(ids ...int64) *sqlmock.Rows {
+	rows := sqlmock.NewRows(eventColumns)
+	for _, id := range ids {
+		at := eventTime(id)
+		rows.AddRow(id, fmt.Sprintf("delivery-%d", id), "issues", "opened", "acme/api", "alice", nil,
+			fmt.Sprintf("Issue %d", id), nil, "https://github.com/acme/api/issues/1", nil, at, at, at)
+	}
+	return rows
+}
+
+// newMockDB returns a mocked database that matches queries by regular expression.
+func newMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db, mock
+}
+
+func TestParseLastEventID(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected int64
+	}{
+		{value: "", expected: 0},
+		{value: "42", expected: 42},
+		{value: "-1", expected: 0},
+		{value: "abc", expected: 0},
+		{value: "9223372036854775808", expected: 0},
+	}
+	for _, tt := range tests {
+		if got := parseLastEventID(tt.value); got != tt.expected {
+			t.Errorf("expected parseLastEventID(%q) to be %d, got %d", tt.value, tt.expected, got)
+		}
+	}
+}
+
+// sseFrame is a dispatched SSE frame.
+type sseFrame struct {
+	event string
+	id    string
+	data  string
+}
+
+// newSSETestServer serves the SSE handler backed by a mocked database. Requests are
+// authenticated as the user in the X-Test-User header. The server has a short
+// WriteTimeout, which streams must outlive.
+func newSSETestServer(t *testing.T, opts SSEOptions) (*httptest.Server, sqlmock.Sqlmock, *sse.Hub) {
+	t.Helper()
+	db, mock := newMockDB(t)
+	hub := sse.NewHub(sse.HubOptions{})
+	go hub.Run()
+	h := NewSSEHandler(hub, service.NewEventService(repository.NewEventRepository(db)), opts)
+	r := chi.NewRouter()
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userID, _ := strconv.ParseInt(r.Header.Get("X-Test-User"), 10, 64)
+			next.ServeHTTP(w, r.WithContext(middleware.ContextWithUserID(r.Context(), userID)))
+		})
+	})
+	r.Get("/api/events/stream", h.ServeHTTP)
+	r.Put("/api/events/stream/subscriptions/{id}", h.UpdateSubscription)
+	srv := httptest.NewUnstartedServer(r)
+	srv.Config.WriteTimeout = 50 * time.Millisecond
+	srv.Start()
+	t.Cleanup(srv.Close)
+	return srv, mock, hub
+}
+
+// openStream connects to the stream at path as userID and returns the subscription ID
+// and a channel of the ids of the event frames that follow.
+func openStream(t *testing.T, ctx context.Context, srv *httptest.Server, path string, userID int64, lastEventID string) (string, <-chan string) {
+	t.Helper()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+path, nil)
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+	req.Header.Set("X-Test-User", strconv.FormatInt(userID, 10))
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET stream error = %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		t.Fatalf("expected GET stream status to be 200, got %d", resp.StatusCode)
+	}
+	frames := make(chan sseFrame)
+	go func() {
+		defer resp.Body.Close()
+		defer close(frames)
+		scanner := bufio.NewScanner(resp.Body)
+		var frame sseFrame
+		for scanner.Scan() {
+			field, value, _ := strings.Cut(scanner.Text(), ": ")
+			switch field {
+			case "":
+				frames <- frame
+				frame = sseFrame{}
+			case "event":
+				frame.event = value
+			case "id":
+				frame.id = value
+			case "data":
+				frame.data = value
+			}
+		}
+	}()
+	var subscribed sseFrame
+	select {
+	case subscribed = <-frames:
+	case <-time.After(3 * time.Second):
+		t.Fatal("timed out waiting for the subscribed frame")
+	}
+	var sub SSESubscription
+	if err := json.Unmarshal([]byte(subscribed.data), &sub); subscribed.event != "subscribed" || err != nil || sub.SubscriptionID == "" {
+		t.Fatalf("expected first frame to be a subscribed frame, got %+v", subscribed)
+	}
+	ids := make(chan string)
+	go func() {
+		defer close(ids)
+		for frame := range frames {
+			if frame.event == "new_event" {
+				ids <- frame.id
+			}
+		}
+	}()
+	return sub.SubscriptionID, ids
+}
+
+func receiveIDs(t *testing.T, ids <-chan string, n int) []string {
+	t.Helper()
+	var got []string
+	for len(got) < n {
+		select {
+		case id, ok := <-ids:
+			if !ok {
+				t.Fatalf("stream ended after ids %v", got)
+			}
+			got = append(got, id)
+		case <-time.After(3 * time.Second):
+			t.Fatalf("timed out after ids %v", got)
+		}
+	}
+	return got
+}
+
+func updateSubscription(t *testing.T, srv *httptest.Server, id string, userID int64, query string) int {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPut, srv.URL+"/api/events/stream/subscriptions/"+id+"?"+query, nil)
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+	req.Header.Set("X-Test-User", strconv.FormatInt(userID, 10))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("PUT subscription error = %v", err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func waitForClients(t *testing.T, hub *sse.Hub, n int) {
+	t.Helper()
+	deadline := time.Now().Add(3 * time.Second)
+	for hub.ClientCount() != n {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %d SSE clients", n)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestSSEHandlerLiveEventsCarryIDs(t *testing.T) {
+	srv, mock, hub := newSSETestServer(t, SSEOptions{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, ids := openStream(t, ctx, srv, "/api/events/stream", 1, "")
+	waitForClients(t, hub, 1)
+	hub.Broadcast(model.Event{ID: 1})
+	hub.Broadcast(model.Event{ID: 2})
+	if got := receiveIDs(t, ids, 2); fmt.Sprint(got) != "[1 2]" {
+		t.Errorf("expected ids to be [1 2], got %v", got)
+	}
+	// Without Last-Event-ID nothing is replayed.
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSSEHandlerReplaysMissedEvents(t *testing.T) {
+	srv, mock, hub := newSSETestServer(t, SSEOptions{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rows := eventRows(6, 7)
+	// The replay query is slow enough for live events to arrive while it runs.
+	mock.ExpectQuery(`FROM events WHERE id > \? ORDER BY id ASC LIMIT \?`).
+		WithArgs(5, sseReplayBatchSize).
+		WillDelayFor(200 * time.Millisecond).
+		WillReturnRows(rows)
+
+	_, ids := openStream(t, ctx, srv, "/api/events/stream", 1, "5")
+	waitForClients(t, hub, 1)
+	// Event 7 is both replayed and buffered from the hub; it must be sent once.
+	hub.Broadcast(model.Event{ID: 7})
+	hub.Broadcast(model.Event{ID: 8})
+	if got := receiveIDs(t, ids, 3); fmt.Sprint(got) != "[6 7 8]" {
+		t.Errorf("expected ids to be [6 7 8], got %v", got)
+	}
+	hub.Broadcast(model.Event{ID: 9})
+	if got := receiveIDs(t, ids, 1); fmt.Sprint(got) != "[9]" {
+		t.Errorf("expected ids to be [9], got %v", got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSSEHandlerFiltersAndUpdatesSubscription(t *testing.T) {
+	srv, mock, hub := newSSETestServer(t, SSEOptions{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The replay honors the stream's filters too.
+	mock.ExpectQuery(`FROM events WHERE repo_name = \? AND id > \? ORDER BY id ASC`).
+		WithArgs("acme/api", 5, sseReplayBatchSize).
+		WillReturnRows(eventRows())
+	subscriptionID, ids := openStream(t, ctx, srv, "/api/events/stream?repo=acme/api", 1, "5")
+	waitForClients(t, hub, 1)
+	hub.Broadcast(model.Event{ID: 6, RepoName: "acme/web"})
+	hub.Broadcast(model.Event{ID: 7, RepoName: "acme/api"})
+	if got := receiveIDs(t, ids, 1); fmt.Sprint(got) != "[7]" {
+		t.Errorf("expected ids to be [7], got %v", got)
+	}
+
+	if status := updateSubscription(t, srv, subscriptionID, 2, "repo=acme/web"); status != http.StatusNotFound {
+		t.Errorf("expected PUT by another user status to be 404, got %d", status)
+	}
+	if status := updateSubscription(t, srv, "unknown", 1, "repo=acme/web"); status != http.StatusNotFound {
+		t.Errorf("expected PUT unknown subscription status to be 404, got %d", status)
+	}
+	if status := updateSubscription(t, srv, subscriptionID, 1, "since=tomorrow"); status != http.StatusBadRequest {
+		t.Errorf("expected PUT invalid filter status to be 400, got %d", status)
+	}
+	if status := updateSubscription(t, srv, subscriptionID, 1, "repo=acme/web&event_type=push"); status != http.StatusNoContent {
+		t.Fatalf("expected PUT status to be 204, got %d", status)
+	}
+	hub.Broadcast(model.Event{ID: 8, RepoName: "acme/api", EventType: "push"})
+	hub.Broadcast(model.Event{ID: 9, RepoName: "acme/web", EventType: "issues"})
+	hub.Broadcast(model.Event{ID: 10, RepoName: "acme/web", EventType: "push"})
+	if got := receiveIDs(t, ids, 1); fmt.Sprint(got) != "[10]" {
+		t.Errorf("expected ids after update to be [10], got %v", got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSSEHandlerRejectsInvalidFilter(t *testing.T) {
+	srv, _, _ := newSSETestServer(t, SSEOptions{})
+	resp, err := http.Get(srv.URL + "/api/events/stream?since=tomorrow")
+	if err != nil {
+		t.Fatalf("GET stream error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected status to be 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestSSEHandlerKeepsLongLivedStreamsOpen(t *testing.T) {
+	srv, _, hub := newSSETestServer(t, SSEOptions{
+		HeartbeatInterval: 20 * time.Millisecond,
+		Retry:             1500 * time.Millisecond,
+		MaxLifetime:       300 * time.Millisecond,
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/api/events/stream", nil)
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+	start := time.Now()
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET stream error = %v", err)
+	}
+	defer resp.Body.Close()
+	// An event sent after the server's 50ms WriteTimeout must still be delivered.
+	waitForClients(t, hub, 1)
+	time.Sleep(100 * time.Millisecond)
+	hub.Broadcast(model.Event{ID: 1})
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("reading stream error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 270*time.Millisecond {
+		t.Errorf("expected the stream to end after about the 300ms max lifetime, got %v", elapsed)
+	}
+	got := string(body)
+	if !strings.HasPrefix(got, "retry: 1500\n\n") {
+		t.Errorf("stream does not start with the retry hint: %q", got)
+	}
+	for _, expected := range []string{": heartbeat\n\n", "id: 1\nevent: new_event\n", "event: reconnect\ndata: {}\n\n"} {
+		if !strings.Contains(got, expected) {
+			t.Errorf("stream %q does not contain %q", got, expected)
+		}
+	}
+	if !strings.HasSuffix(got, "event: reconnect\ndata: {}\n\n") {
+		t.Errorf("stream does not end with a reconnect frame: %q", got)
+	}
+}
+
+// stallingWriter is a ResponseWriter whose event frames block until release is closed,
+// like a client that stopped reading.
+type stallingWriter struct {
+	header  http.Header
+	release chan struct{}
+	mu      sync.Mutex
+	body    strings.Builder
+}
+
+func (w *stallingWriter) Header() http.Header { return w.header }
+func (w *stallingWriter) WriteHeader(int)     {}
+func (w *stallingWriter) Flush()              {}
+
+func (w *stallingWriter) Write(p []byte) (int, error) {
+	if strings.Contains(string(p), "event: new_event") {
+		<-w.release
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.body.Write(p)
+}
+
+func TestSSEHandlerSendsResyncToSlowClients(t *testing.T) {
+	hub := sse.NewHub(sse.HubOptions{ClientBuffer: 2})
+	go hub.Run()
+	h := NewSSEHandler(hub, nil, SSEOptions{})
+	w := &stallingWriter{header: http.Header{}, release: make(chan struct{})}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/events/stream", nil))
+	}()
+	waitForClients(t, hub, 1)
+	for id := int64(1); id <= 5; id++ {
+		hub.Broadcast(model.Event{ID: id})
+	}
+	deadline := time.Now().Add(3 * time.Second)
+	for hub.Stats().SlowDisconnects != 1 {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the client to be dropped")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	close(w.release)
+	select {
+	case <-done:
+	case <-time.After(3 * time.Second):
+		t.Fatal("stream did not end after the client was dropped")
+	}
+
+	// The stream delivers the events buffered before the drop, then tells the client
+	// where to resume.
+	var ids []string
+	var resync string
+	for _, frame := range strings.Split(w.body.String(), "\n\n") {
+		switch {
+		case strings.HasPrefix(frame, "id: "):
+			id, _, _ := strings.Cut(strings.TrimPrefix(frame, "id: "), "\n")
+			ids = append(ids, id)
+		case strings.HasPrefix(frame, "event: resync\n"):
+			resync = strings.TrimPrefix(frame, "event: resync\ndata: ")
+		}
+	}
+	if len(ids) == 0 || len(ids) >= 5 {
+		t.Fatalf("expected some but not all events delivered, got %v", ids)
+	}
+	for i, id := range ids {
+		if id != strconv.Itoa(i+1) {
+			t.Fatalf("expected consecutive ids from 1, got %v", ids)
+		}
+	}
+	if expected := fmt.Sprintf(`{"last_event_id":%s}`, ids[len(ids)-1]); resync != expected {
+		t.Errorf("expected resync data to be %q, got %q", expected, resync)
+	}
+}
+
+func TestSSEHandlerSendsTypedMessages(t *testing.T) {
+	hub := sse.NewHub(sse.HubOptions{})
+	go hub.Run()
+	h := NewSSEHandler(hub, nil, SSEOptions{})
+	w := &stallingWriter{header: http.Header{}, release: make(chan struct{})}
+	close(w.release)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/events/stream", nil))
+	}()
+	waitForClients(t, hub, 1)
+	hub.Publish(sse.Message{Type: sse.MessageEventUpdated, Event: &model.Event{ID: 7}})
+	hub.Publish(sse.Message{Type: sse.MessageStatsUpdated})
+	hub.Broadcast(model.Event{ID: 8})
+	hub.Publish(sse.Message{Type: sse.MessageServerShutdown})
+	select {
+	case <-done:
+	case <-time.After(3 * time.Second):
+		t.Fatal("stream did not end on server_shutdown")
+	}
+
+	var got []string
+	for _, frame := range strings.Split(w.body.String(), "\n\n") {
+		lines := strings.Split(frame, "\n")
+		if len(lines) < 2 || strings.HasPrefix(frame, "event: subscribed") {
+			continue
+		}
+		got = append(got, lines[0])
+	}
+	// Only new events carry an id, so Last-Event-ID is not moved by other messages.
+	expected := []string{"event: event_updated", "event: stats_updated", "id: 8", "event: server_shutdown"}
+	if fmt.Sprint(got) != fmt.Sprint(expected) {
+		t.Errorf("expected frames to start with %q, got %q", expected, got)
+	}
+}
+
+func TestSSEHandlerCountsOnlySessionsTowardsPresence(t *testing.T) {
+	db, _ := newMockDB(t)
+	hub := sse.NewHub(sse.HubOptions{})
+	go hub.Run()
+	h := NewSSEHandler(hub, service.NewEventService(repository.NewEventRepository(db)), SSEOptions{})
+	r := chi.NewRouter()
+	r.Use(middleware.Auth(sessions.NewCookieStore([]byte(testSessionSecret)), staticTokens{"secret": 42}))
+	r.Get("/api/events/stream", h.ServeHTTP)
+	srv := httptest.NewServer(r)
+	t.Cleanup(srv.Close)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tests := []struct {
+		name    string
+		session bool
+	}{
+		{name: "Browser session of user 7", session: true},
+		{name: "API token of user 42"},
+	}
+	for _, tt := range tests {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/api/events/stream", nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if tt.session {
+			req.AddCookie(sessionCookie(t, 7))
+		} else {
+			req.Header.Set("Authorization", "Bearer secret")
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		defer resp.Body.Close()
+		// The subscribed frame is written after the stream joined presence.
+		if line, err := bufio.NewReader(resp.Body).ReadString('\n'); err != nil || line != "event: subscribed\n" {
+			t.Fatalf("%s: expected a subscribed frame, got %q (%v)", tt.name, line, err)
+		}
+	}
+
+	if online := hub.Online(); len(online) != 1 || online[7] != 1 {
+		t.Errorf("expected only user 7 to be online, got %v", online)
+	}
+}
+
+func TestPresenceHandlerWithoutPresence(t *testing.T) {
+	rec := httptest.NewRecorder()
+	NewPresenceHandler(nil).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/presence", nil))
+	if rec.Code != http.StatusNotImplemented {
+		t.Errorf("expected status %d, got %d", http.StatusNotImplemented, rec.Code)
+	}
+}
+
+func TestJitterLifetime(t *testing.T) {
+	for range 100 {
+		if got := jitterLifetime(time.Hour); got > time.Hour || got < 54*time.Minute {
+			t.Fatalf("expected jitterLifetime(1h) to be within 10%% below 1h, got %v", got)
+		}
+	}
+}
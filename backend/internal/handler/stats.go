@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/middleware"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/service"
+)
+
+const (
+	defaultStatsLimit = 10
+	maxStatsLimit     = 100
+	// statsCacheControl lets clients keep a response but revalidate it on every use, since
+	// stats_updated tells them the statistics have changed.
+	statsCacheControl = "private, no-cache"
+)
+
+// StatsHandler handles GET /api/stats requests.
+type StatsHandler struct {
+	statsService *service.StatsService
+}
+
+// NewStatsHandler creates a new StatsHandler.
+func NewStatsHandler(statsService *service.StatsService) *StatsHandler {
+	return &StatsHandler{statsService: statsService}
+}
+
+// ServeHTTP returns event counts grouped by repo, event type, action and sender plus a
+// time series. It accepts the list filters, bucket (hour|day|week), tz (IANA name) and
+// limit (top-N per group). Responses carry an ETag and may be revalidated with If-None-Match.
+func (h *StatsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	filter, err := parseEventFilter(r)
+	if err != nil {
+		writeFilterError(w, err)
+		return
+	}
+	loc, err := parseLocationQuery(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	limit := parseIntQuery(r, "limit", defaultStatsLimit)
+	if limit < 1 || limit > maxStatsLimit {
+		limit = defaultStatsLimit
+	}
+	stats, err := h.statsService.GetStats(service.StatsQuery{
+		Filter:   filter,
+		Bucket:   r.URL.Query().Get("bucket"),
+		Location: loc,
+		Limit:    limit,
+	})
+	if errors.Is(err, service.ErrInvalidBucket) || errors.Is(err, service.ErrTooManyBuckets) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		middleware.LogEvent("error", "failed to compute stats", map[string]interface{}{"error": err.Error()})
+		writeError(w, http.StatusInternalServerError, "failed to compute stats")
+		return
+	}
+	writeCacheableJSON(w, r, stats, statsCacheControl)
+}
+
+// writeCacheableJSON writes data with Cache-Control and a content-derived ETag,
+// answering 304 Not Modified when the client already has the same representation.
+func writeCacheableJSON(w http.ResponseWriter, r *http.Request, data interface{}, cacheControl string) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to encode response")
+		return
+	}
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	w.Header().Set("Cache-Control", cacheControl)
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
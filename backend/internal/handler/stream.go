@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// sseWriteTimeout bounds a single SSE write. Streams are exempt from the server's
+// WriteTimeout, which would end them after a few seconds, but a client that stops reading
+// is still dropped once its socket buffer fills.
+const sseWriteTimeout = 10 * time.Second
+
+// sseWriter writes Server-Sent Events frames and flushes each one to the client.
+type sseWriter struct {
+	w  http.ResponseWriter
+	rc *http.ResponseController
+}
+
+// newSSEWriter prepares w for streaming, replacing the server's write deadline with one
+// per frame.
+func newSSEWriter(w http.ResponseWriter) *sseWriter {
+	return &sseWriter{w: w, rc: http.NewResponseController(w)}
+}
+
+// frame writes a raw frame and flushes it.
+func (s *sseWriter) frame(format string, args ...interface{}) error {
+	if err := s.rc.SetWriteDeadline(time.Now().Add(sseWriteTimeout)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	if _, err := fmt.Fprintf(s.w, format, args...); err != nil {
+		return err
+	}
+	return s.rc.Flush()
+}
+
+// open sends the response headers, for streams that start without a frame.
+func (s *sseWriter) open() error {
+	return s.frame("")
+}
+
+// event writes a frame with an event type and data, and an id unless id is empty.
+func (s *sseWriter) event(id string, name string, data []byte) error {
+	if id != "" {
+		return s.frame("id: %s\nevent: %s\ndata: %s\n\n", id, name, data)
+	}
+	return s.frame("event: %s\ndata: %s\n\n", name, data)
+}
+
+// retry tells the client how long to wait before reconnecting.
+func (s *sseWriter) retry(d time.Duration) error {
+	return s.frame("retry: %d\n\n", d.Milliseconds())
+}
+
+// heartbeat writes a comment that keeps idle connections open through proxies and detects
+// clients that went away.
+func (s *sseWriter) heartbeat() error {
+	return s.frame(": heartbeat\n\n")
+}
@@ -9,8 +9,8 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/eventbus"
 	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/middleware"
-	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
 	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/service"
 )
 
@@ -20,13 +20,15 @@ const signaturePrefix = "sha256="
 type WebhookHandler struct {
 	webhookSecret string
 	eventService  *service.EventService
+	bus           eventbus.Publisher
 }
 
-// NewWebhookHandler creates a new WebhookHandler.
-func NewWebhookHandler(webhookSecret string, eventService *service.EventService) *WebhookHandler {
+// NewWebhookHandler creates a new WebhookHandler. Changes to the stored events are published to bus.
+func NewWebhookHandler(webhookSecret string, eventService *service.EventService, bus eventbus.Publisher) *WebhookHandler {
 	return &WebhookHandler{
 		webhookSecret: webhookSecret,
 		eventService:  eventService,
+		bus:           bus,
 	}
 }
 
@@ -58,7 +60,7 @@ func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		"delivery_id": deliveryID,
 		"event_type":  eventType,
 	})
-	result, savedEvent, err := h.eventService.ProcessWebhook(deliveryID, eventType, body)
+	result, changes, err := h.eventService.ProcessWebhook(deliveryID, eventType, body)
 	if err != nil {
 		middleware.LogEvent("error", "failed to process webhook", map[string]interface{}{
 			"delivery_id": deliveryID,
@@ -67,8 +69,8 @@ func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, err.Error())
 		return
 	}
-	if savedEvent != nil {
-		broadcastEvent(*savedEvent)
+	for _, change := range changes {
+		h.bus.Publish(change)
 	}
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(result)
@@ -102,12 +104,3 @@ func writeJSON(w http.ResponseWriter, statusCode int, data interface{}) {
 	w.WriteHeader(statusCode)
 	json.NewEncoder(w).Encode(data)
 }
-
-// Broadcast callback type for SSE integration (set later in Phase 5).
-var BroadcastFunc func(event model.Event)
-
-func broadcastEvent(event model.Event) {
-	if BroadcastFunc != nil {
-		BroadcastFunc(event)
-	}
-}
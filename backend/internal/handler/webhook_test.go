@@ -0,0 +1,198 @@
+package handler
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/eventbus"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/repository"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/service"
+)
+
+const testWebhookSecret = "webhook-secret"
+
+// issueOpenedPayload is an issues/opened webhook payload.
+const issueOpenedPayload = `{
+	"action": "opened",
+	"issue": {"number": 1, "title": "Issue 1", "html_url": "https://github.com/acme/api/issues/1", "created_at": "2026-03-01T12:00:00Z", "user": {"login": "alice"}},
+	"repository": {"full_name": "acme/api"},
+	"sender": {"login": "alice"}
+}`
+
+// signedWebhookRequest returns a webhook request for payload signed with testWebhookSecret.
+func signedWebhookRequest(eventType, payload string) *http.Request {
+	mac := hmac.New(sha256.New, []byte(testWebhookSecret))
+	mac.Write([]byte(payload))
+	req := httptest.NewRequest(http.MethodPost, "/api/webhook", strings.NewReader(payload))
+	req.Header.Set("X-Hub-Signature-256", signaturePrefix+hex.EncodeToString(mac.Sum(nil)))
+	req.Header.Set("X-GitHub-Delivery", "delivery-1")
+	req.Header.Set("X-GitHub-Event", eventType)
+	return req
+}
+
+func TestWebhookAppliesEditsAndDeletions(t *testing.T) {
+	at := eventTime(1)
+	issueRow := []driver.Value{int64(1), "delivery-1", "issues", "opened", "acme/api", "alice", nil,
+		"Issue 1", "Old body", "https://github.com/acme/api/issues/1", `{"issue_number":1}`, at, at, at}
+	commentURL := "https://github.com/acme/api/issues/1#issuecomment-10"
+	commentRow := []driver.Value{int64(2), "delivery-2", "issue_comment", "created", "acme/api", "bob", nil,
+		"Issue 1", "Looks good", commentURL, `{"issue_number":1}`, at, at, at}
+
+	tests := []struct {
+		name            string
+		eventType       string
+		payload         string
+		setupMock       func(mock sqlmock.Sqlmock)
+		expectedStatus  string
+		expectedChanges string
+	}{
+		{
+			name:      "Edited issue updates its events but not its comments' bodies",
+			eventType: "issues",
+			payload: `{"action": "edited", "issue": {"number": 1, "title": "Issue 1", "body": "New body"},
+				"repository": {"full_name": "acme/api"}, "sender": {"login": "alice"}}`,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`FROM events WHERE repo_name = \? AND issue_number = \? .* FOR UPDATE`).
+					WithArgs("acme/api", 1).
+					WillReturnRows(sqlmock.NewRows(eventColumns).AddRow(issueRow...).AddRow(commentRow...))
+				mock.ExpectExec(`UPDATE events SET title = \?, body = \? WHERE id = \?`).
+					WithArgs("Issue 1", "New body", 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			expectedStatus:  "updated",
+			expectedChanges: "[updated 1]",
+		},
+		{
+			name:      "Deleted comment deletes its event",
+			eventType: "issue_comment",
+			payload: `{"action": "deleted", "issue": {"number": 1, "title": "Issue 1"}, "comment": {"html_url": "` + commentURL + `"},
+				"repository": {"full_name": "acme/api"}, "sender": {"login": "bob"}}`,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`AND event_type = 'issue_comment' AND html_url = \? ORDER BY id ASC FOR UPDATE`).
+					WithArgs("acme/api", 1, commentURL).
+					WillReturnRows(sqlmock.NewRows(eventColumns).AddRow(commentRow...))
+				mock.ExpectExec(`DELETE FROM events WHERE id IN \(\?\)`).WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			expectedStatus:  "deleted",
+			expectedChanges: "[deleted 2]",
+		},
+		{
+			name:      "Deleted issue without stored events is ignored",
+			eventType: "issues",
+			payload: `{"action": "deleted", "issue": {"number": 3, "title": "Issue 3"},
+				"repository": {"full_name": "acme/api"}, "sender": {"login": "alice"}}`,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`FOR UPDATE`).WithArgs("acme/api", 3).WillReturnRows(sqlmock.NewRows(eventColumns))
+				mock.ExpectRollback()
+			},
+			expectedStatus:  "ignored",
+			expectedChanges: "[]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+			tt.setupMock(mock)
+			bus := eventbus.New()
+			var mu sync.Mutex
+			changes := []string{}
+			bus.Subscribe("recorder", func(_ context.Context, change model.EventChange) error {
+				mu.Lock()
+				defer mu.Unlock()
+				changes = append(changes, fmt.Sprintf("%s %d", change.Type, change.Event.ID))
+				return nil
+			})
+			h := NewWebhookHandler(testWebhookSecret, service.NewEventService(repository.NewEventRepository(db)), bus)
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, signedWebhookRequest(tt.eventType, tt.payload))
+			if err := bus.Close(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+			}
+			var resp model.WebhookResponse
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Status != tt.expectedStatus {
+				t.Errorf("expected webhook status %q, got %q", tt.expectedStatus, resp.Status)
+			}
+			if got := fmt.Sprint(changes); got != tt.expectedChanges {
+				t.Errorf("expected changes %s, got %s", tt.expectedChanges, got)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestWebhookDoesNotWaitForSubscribers(t *testing.T) {
+	db, mock := newMockDB(t)
+	mock.ExpectExec(`INSERT INTO events`).WillReturnResult(sqlmock.NewResult(1, 1))
+
+	bus := eventbus.New()
+	release := make(chan struct{})
+	handled := make(chan int64, 1)
+	bus.Subscribe("blocked", func(_ context.Context, change model.EventChange) error {
+		<-release
+		handled <- change.Event.ID
+		return nil
+	})
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		bus.Close(ctx)
+	}()
+	h := NewWebhookHandler(testWebhookSecret, service.NewEventService(repository.NewEventRepository(db)), bus)
+
+	done := make(chan int)
+	go func() {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, signedWebhookRequest("issues", issueOpenedPayload))
+		done <- rec.Code
+	}()
+	select {
+	case code := <-done:
+		if code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, code)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the webhook to respond while its subscriber is blocked")
+	}
+
+	close(release)
+	select {
+	case id := <-handled:
+		if id != 1 {
+			t.Errorf("expected event 1 to be published, got %d", id)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the subscriber to receive the event")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
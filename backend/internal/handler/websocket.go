@@ -0,0 +1,356 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/middleware"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/sse"
+)
+
+const (
+	// wsPingInterval is how often the server pings a connection. A client that sends
+	// neither a message nor a pong for two intervals is considered gone.
+	wsPingInterval = 30 * time.Second
+	// wsWriteTimeout bounds a single message write.
+	wsWriteTimeout = 10 * time.Second
+	// wsMaxMessageBytes caps the size of a client message.
+	wsMaxMessageBytes = 8 << 10
+	// wsMaxSubscriptions caps the subscriptions of one connection.
+	wsMaxSubscriptions = 20
+	// wsSendBuffer is the number of outgoing messages queued per connection, in addition to
+	// the hub's buffer of each subscription.
+	wsSendBuffer = 64
+)
+
+// WebSocket message types. Clients send subscribe, unsubscribe and ping; the server sends
+// subscribed, unsubscribed, event, resync, pong and error, and the other hub message types
+// (event_updated, stats_updated, server_shutdown...) under their own names.
+const (
+	wsTypeSubscribe    = "subscribe"
+	wsTypeUnsubscribe  = "unsubscribe"
+	wsTypePing         = "ping"
+	wsTypeSubscribed   = "subscribed"
+	wsTypeUnsubscribed = "unsubscribed"
+	wsTypeEvent        = "event"
+	wsTypeResync       = "resync"
+	wsTypePong         = "pong"
+	wsTypeError        = "error"
+)
+
+// WSMessage is a message exchanged over /api/events/ws. ID is the client-chosen
+// subscription ID; Filter uses the keys of the GET /api/events query parameters, each with
+// a string or an array of strings. Event carries the event of event, event_updated and
+// event_deleted messages, which are sent per subscription, and Data the payload of other
+// hub messages, which are sent once per connection without an ID. A resync message
+// ends a subscription whose client fell behind, with the ID of the last event it
+// delivered.
+type WSMessage struct {
+	Type        string                     `json:"type"`
+	ID          string                     `json:"id,omitempty"`
+	Filter      map[string]json.RawMessage `json:"filter,omitempty"`
+	Event       json.RawMessage            `json:"event,omitempty"`
+	Data        json.RawMessage            `json:"data,omitempty"`
+	LastEventID int64                      `json:"last_event_id,omitempty"`
+	Error       string                     `json:"error,omitempty"`
+}
+
+// WebSocketHandler handles GET /api/events/ws, a WebSocket alternative to the SSE stream
+// backed by the same hub.
+type WebSocketHandler struct {
+	hub          *sse.Hub
+	upgrader     websocket.Upgrader
+	pingInterval time.Duration
+}
+
+// NewWebSocketHandler creates a new WebSocketHandler. Browser connections are accepted only
+// from allowedOrigin (the frontend URL) or the backend's own origin, since they are
+// authenticated by the session cookie.
+func NewWebSocketHandler(hub *sse.Hub, allowedOrigin string) *WebSocketHandler {
+	return &WebSocketHandler{
+		hub: hub,
+		upgrader: websocket.Upgrader{
+			CheckOrigin: func(r *http.Request) bool {
+				origin := r.Header.Get("Origin")
+				if origin == "" || origin == allowedOrigin {
+					return true
+				}
+				u, err := url.Parse(origin)
+				return err == nil && u.Host == r.Host
+			},
+		},
+		pingInterval: wsPingInterval,
+	}
+}
+
+// wsConn is one WebSocket connection and its hub subscriptions.
+type wsConn struct {
+	hub  *sse.Hub
+	conn *websocket.Conn
+	send chan WSMessage
+	// done is closed when either side of the connection stops.
+	done     chan struct{}
+	doneOnce sync.Once
+
+	mu   sync.Mutex
+	subs map[string]chan sse.Envelope
+	wg   sync.WaitGroup
+	// general receives the hub messages about no event, for the whole connection.
+	general chan sse.Envelope
+}
+
+// ServeHTTP upgrades the request and serves subscriptions until the client disconnects.
+func (h *WebSocketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade has already responded with an HTTP error.
+		return
+	}
+	c := &wsConn{
+		hub:  h.hub,
+		conn: conn,
+		send: make(chan WSMessage, wsSendBuffer),
+		done: make(chan struct{}),
+		subs: make(map[string]chan sse.Envelope),
+
+		general: h.hub.NewClient(),
+	}
+	userID := middleware.UserIDFromContext(r.Context())
+	middleware.LogEvent("info", "WebSocket connection opened", map[string]interface{}{
+		"remote_addr": r.RemoteAddr,
+		"user_id":     userID,
+	})
+	// A browser session's connection counts towards presence even before it subscribes.
+	if middleware.IsSessionAuthenticated(r.Context()) {
+		h.hub.Join(userID)
+		defer h.hub.Leave(userID)
+	}
+	h.hub.RegisterGeneral(c.general)
+	c.wg.Add(1)
+	go c.forwardGeneral()
+	writerDone := make(chan struct{})
+	go func() {
+		defer close(writerDone)
+		c.writeLoop(h.pingInterval)
+	}()
+	c.readLoop(2 * h.pingInterval)
+	c.stop()
+	c.unsubscribeAll()
+	c.wg.Wait()
+	<-writerDone
+	conn.Close()
+	middleware.LogEvent("info", "WebSocket connection closed", map[string]interface{}{
+		"remote_addr": r.RemoteAddr,
+	})
+}
+
+// readLoop handles client messages until the connection fails or is closed. Any message
+// or pong from the client extends the read deadline.
+func (c *wsConn) readLoop(pongWait time.Duration) {
+	c.conn.SetReadLimit(wsMaxMessageBytes)
+	c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+	for {
+		_, data, err := c.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		c.conn.SetReadDeadline(time.Now().Add(pongWait))
+		var msg WSMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			c.reply(WSMessage{Type: wsTypeError, Error: "invalid message"})
+			continue
+		}
+		switch msg.Type {
+		case wsTypeSubscribe:
+			c.subscribe(msg)
+		case wsTypeUnsubscribe:
+			c.unsubscribe(msg.ID)
+		case wsTypePing:
+			c.reply(WSMessage{Type: wsTypePong})
+		default:
+			c.reply(WSMessage{Type: wsTypeError, ID: msg.ID, Error: fmt.Sprintf("unknown message type %q", msg.Type)})
+		}
+	}
+}
+
+// writeLoop writes queued messages and pings until the connection is done. A failed write
+// closes the connection, which ends readLoop.
+func (c *wsConn) writeLoop(pingInterval time.Duration) {
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.done:
+			c.conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(wsWriteTimeout))
+			return
+		case msg := <-c.send:
+			c.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			if err := c.conn.WriteJSON(msg); err != nil {
+				c.stop()
+				c.conn.Close()
+				return
+			}
+		case <-ticker.C:
+			if err := c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteTimeout)); err != nil {
+				c.stop()
+				c.conn.Close()
+				return
+			}
+		}
+	}
+}
+
+// stop marks the connection as done.
+func (c *wsConn) stop() {
+	c.doneOnce.Do(func() { close(c.done) })
+}
+
+// reply queues a message for the client unless the connection is done.
+func (c *wsConn) reply(msg WSMessage) {
+	select {
+	case c.send <- msg:
+	case <-c.done:
+	}
+}
+
+// subscribe registers a filtered hub subscription under the message ID, or replaces the
+// filter of an existing one.
+func (c *wsConn) subscribe(msg WSMessage) {
+	if msg.ID == "" {
+		c.reply(WSMessage{Type: wsTypeError, Error: "subscription id is required"})
+		return
+	}
+	values, err := wsFilterValues(msg.Filter)
+	if err != nil {
+		c.reply(WSMessage{Type: wsTypeError, ID: msg.ID, Error: err.Error()})
+		return
+	}
+	filter, err := parseEventFilterValues(values)
+	if err != nil {
+		c.reply(WSMessage{Type: wsTypeError, ID: msg.ID, Error: err.Error()})
+		return
+	}
+	c.mu.Lock()
+	client, exists := c.subs[msg.ID]
+	if !exists && len(c.subs) >= wsMaxSubscriptions {
+		c.mu.Unlock()
+		c.reply(WSMessage{Type: wsTypeError, ID: msg.ID, Error: fmt.Sprintf("at most %d subscriptions per connection", wsMaxSubscriptions)})
+		return
+	}
+	if !exists {
+		client = c.hub.NewClient()
+		c.subs[msg.ID] = client
+	}
+	c.mu.Unlock()
+	if exists {
+		c.hub.SetFilter(client, filter)
+	} else {
+		c.hub.SubscribeEvents(client, filter)
+		c.wg.Add(1)
+		go c.forward(msg.ID, client)
+	}
+	c.reply(WSMessage{Type: wsTypeSubscribed, ID: msg.ID})
+}
+
+// unsubscribe removes the subscription with the given ID.
+func (c *wsConn) unsubscribe(id string) {
+	c.mu.Lock()
+	client, ok := c.subs[id]
+	delete(c.subs, id)
+	c.mu.Unlock()
+	if !ok {
+		c.reply(WSMessage{Type: wsTypeError, ID: id, Error: "unknown subscription"})
+		return
+	}
+	c.hub.Unregister(client)
+	c.reply(WSMessage{Type: wsTypeUnsubscribed, ID: id})
+}
+
+func (c *wsConn) unsubscribeAll() {
+	c.mu.Lock()
+	subs := c.subs
+	c.subs = make(map[string]chan sse.Envelope)
+	c.mu.Unlock()
+	for _, client := range subs {
+		c.hub.Unregister(client)
+	}
+	c.hub.Unregister(c.general)
+}
+
+// forwardGeneral relays the hub messages about no event to the client until the hub
+// closes the connection's general channel. A server_shutdown message closes the
+// connection, and so does the hub dropping the channel because the client fell behind,
+// since nothing tells which of these messages were missed.
+func (c *wsConn) forwardGeneral() {
+	defer c.wg.Done()
+	defer c.stop()
+	for env := range c.general {
+		select {
+		case c.send <- WSMessage{Type: string(env.Type), Data: env.Data}:
+		case <-c.done:
+			return
+		}
+		if env.Type == sse.MessageServerShutdown {
+			return
+		}
+	}
+}
+
+// forward relays a subscription's event messages to the client until the hub closes its
+// channel, either after unsubscribe or because the client fell behind.
+func (c *wsConn) forward(id string, client chan sse.Envelope) {
+	defer c.wg.Done()
+	var lastSent int64
+	for env := range client {
+		msg := WSMessage{Type: string(env.Type), ID: id, Event: env.Data}
+		if env.Type == sse.MessageNewEvent {
+			msg.Type = wsTypeEvent
+			lastSent = env.EventID
+		}
+		select {
+		case c.send <- msg:
+		case <-c.done:
+			return
+		}
+	}
+	c.mu.Lock()
+	dropped := c.subs[id] == client
+	if dropped {
+		delete(c.subs, id)
+	}
+	c.mu.Unlock()
+	if dropped {
+		c.reply(WSMessage{Type: wsTypeResync, ID: id, LastEventID: lastSent})
+	}
+}
+
+// wsFilterValues converts a subscribe filter into query parameters. Each value may be a
+// string or an array of strings.
+func wsFilterValues(filter map[string]json.RawMessage) (url.Values, error) {
+	values := url.Values{}
+	for key, raw := range filter {
+		var single string
+		if err := json.Unmarshal(raw, &single); err == nil {
+			values.Add(key, single)
+			continue
+		}
+		var list []string
+		if err := json.Unmarshal(raw, &list); err != nil {
+			return nil, fmt.Errorf("filter %s must be a string or an array of strings", key)
+		}
+		for _, v := range list {
+			values.Add(key, v)
+		}
+	}
+	return values, nil
+}
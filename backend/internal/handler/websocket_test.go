@@ -0,0 +1,235 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/middleware"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/sse"
+)
+
+const testFrontendOrigin = "http://localhost:3000"
+
+// newWSTestServer serves the WebSocket handler behind the request logger, as in main.
+func newWSTestServer(t *testing.T, pingInterval time.Duration) (*httptest.Server, *sse.Hub) {
+	t.Helper()
+	hub := sse.NewHub(sse.HubOptions{})
+	go hub.Run()
+	h := NewWebSocketHandler(hub, testFrontendOrigin)
+	h.pingInterval = pingInterval
+	srv := httptest.NewServer(middleware.Logger(h))
+	t.Cleanup(srv.Close)
+	return srv, hub
+}
+
+func dialWS(t *testing.T, srv *httptest.Server, origin string) (*websocket.Conn, *http.Response, error) {
+	t.Helper()
+	header := http.Header{}
+	if origin != "" {
+		header.Set("Origin", origin)
+	}
+	return websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), header)
+}
+
+func readWS(t *testing.T, conn *websocket.Conn) WSMessage {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(3 * time.Second))
+	var msg WSMessage
+	if err := conn.ReadJSON(&msg); err != nil {
+		t.Fatalf("ReadJSON() error = %v", err)
+	}
+	return msg
+}
+
+func writeWS(t *testing.T, conn *websocket.Conn, msg string) {
+	t.Helper()
+	if err := conn.WriteMessage(websocket.TextMessage, []byte(msg)); err != nil {
+		t.Fatalf("WriteMessage() error = %v", err)
+	}
+}
+
+func eventID(t *testing.T, msg WSMessage) int64 {
+	t.Helper()
+	var e model.Event
+	if err := json.Unmarshal(msg.Event, &e); err != nil {
+		t.Fatalf("event %s: %v", msg.Event, err)
+	}
+	return e.ID
+}
+
+func TestWebSocketSubscriptions(t *testing.T) {
+	srv, hub := newWSTestServer(t, wsPingInterval)
+	conn, _, err := dialWS(t, srv, testFrontendOrigin)
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	defer conn.Close()
+
+	writeWS(t, conn, `{"type":"subscribe","id":"api","filter":{"repo":"acme/api","event_type":["issues","push"]}}`)
+	if msg := readWS(t, conn); msg.Type != wsTypeSubscribed || msg.ID != "api" {
+		t.Fatalf("expected reply to be subscribed api, got %+v", msg)
+	}
+	hub.Broadcast(model.Event{ID: 1, RepoName: "acme/web", EventType: "push"})
+	hub.Broadcast(model.Event{ID: 2, RepoName: "acme/api", EventType: "release"})
+	hub.Broadcast(model.Event{ID: 3, RepoName: "acme/api", EventType: "push"})
+	if msg := readWS(t, conn); msg.Type != wsTypeEvent || msg.ID != "api" || eventID(t, msg) != 3 {
+		t.Fatalf("expected message to be event 3 for api, got %+v", msg)
+	}
+
+	// Subscribing again with the same ID replaces the filter.
+	writeWS(t, conn, `{"type":"subscribe","id":"api","filter":{"repo":"acme/web"}}`)
+	if msg := readWS(t, conn); msg.Type != wsTypeSubscribed {
+		t.Fatalf("expected reply to be subscribed, got %+v", msg)
+	}
+	hub.Broadcast(model.Event{ID: 4, RepoName: "acme/api"})
+	hub.Broadcast(model.Event{ID: 5, RepoName: "acme/web"})
+	if msg := readWS(t, conn); eventID(t, msg) != 5 {
+		t.Fatalf("expected message to be event 5, got %+v", msg)
+	}
+
+	writeWS(t, conn, `{"type":"unsubscribe","id":"api"}`)
+	if msg := readWS(t, conn); msg.Type != wsTypeUnsubscribed || msg.ID != "api" {
+		t.Fatalf("expected reply to be unsubscribed api, got %+v", msg)
+	}
+	// The connection keeps its client for the messages about no event.
+	if got := hub.ClientCount(); got != 1 {
+		t.Errorf("expected ClientCount() after unsubscribe to be 1, got %d", got)
+	}
+	writeWS(t, conn, `{"type":"ping"}`)
+	if msg := readWS(t, conn); msg.Type != wsTypePong {
+		t.Fatalf("expected reply to be pong, got %+v", msg)
+	}
+
+	conn.Close()
+	waitForClients(t, hub, 0)
+}
+
+func TestWebSocketSendsGeneralMessagesOncePerConnection(t *testing.T) {
+	srv, hub := newWSTestServer(t, wsPingInterval)
+	conn, _, err := dialWS(t, srv, testFrontendOrigin)
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	defer conn.Close()
+
+	for _, id := range []string{"api", "web"} {
+		writeWS(t, conn, `{"type":"subscribe","id":"`+id+`"}`)
+		if msg := readWS(t, conn); msg.Type != wsTypeSubscribed || msg.ID != id {
+			t.Fatalf("expected reply to be subscribed %s, got %+v", id, msg)
+		}
+	}
+	hub.PublishChange(model.EventChange{Type: model.EventUpdated, Event: model.Event{ID: 1}})
+	hub.Publish(sse.Message{Type: sse.MessageStatsUpdated})
+
+	// Each subscription receives the event_updated, and the connection one stats_updated.
+	var got []string
+	for range 3 {
+		msg := readWS(t, conn)
+		got = append(got, msg.Type+" "+msg.ID)
+	}
+	writeWS(t, conn, `{"type":"ping"}`)
+	if msg := readWS(t, conn); msg.Type != wsTypePong {
+		t.Fatalf("expected reply to be pong, got %+v", msg)
+	}
+	slices.Sort(got)
+	expected := []string{"event_updated api", "event_updated web", "stats_updated "}
+	if !slices.Equal(got, expected) {
+		t.Errorf("expected messages %q, got %q", expected, got)
+	}
+}
+
+func TestWebSocketErrors(t *testing.T) {
+	srv, _ := newWSTestServer(t, wsPingInterval)
+	conn, _, err := dialWS(t, srv, "")
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	defer conn.Close()
+
+	tests := []struct {
+		name        string
+		message     string
+		expectedID  string
+		expectedErr string
+	}{
+		{name: "not JSON", message: `hello`, expectedErr: "invalid message"},
+		{name: "unknown type", message: `{"type":"publish","id":"a"}`, expectedID: "a", expectedErr: `unknown message type "publish"`},
+		{name: "missing id", message: `{"type":"subscribe"}`, expectedErr: "subscription id is required"},
+		{name: "bad filter value", message: `{"type":"subscribe","id":"a","filter":{"repo":1}}`, expectedID: "a", expectedErr: "filter repo must be a string or an array of strings"},
+		{name: "bad filter", message: `{"type":"subscribe","id":"a","filter":{"since":"tomorrow"}}`, expectedID: "a", expectedErr: "since"},
+		{name: "unknown subscription", message: `{"type":"unsubscribe","id":"b"}`, expectedID: "b", expectedErr: "unknown subscription"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writeWS(t, conn, tt.message)
+			msg := readWS(t, conn)
+			if msg.Type != wsTypeError || msg.ID != tt.expectedID || !strings.Contains(msg.Error, tt.expectedErr) {
+				t.Errorf("expected reply to be error %q for %q, got %+v", tt.expectedErr, tt.expectedID, msg)
+			}
+		})
+	}
+}
+
+func TestWebSocketRejectsForeignOrigin(t *testing.T) {
+	srv, _ := newWSTestServer(t, wsPingInterval)
+	_, resp, err := dialWS(t, srv, "http://evil.example")
+	if err == nil {
+		t.Fatal("Dial() from a foreign origin succeeded")
+	}
+	if resp == nil || resp.StatusCode != http.StatusForbidden {
+		t.Errorf("expected response to be 403, got %v", resp)
+	}
+}
+
+func TestWebSocketPings(t *testing.T) {
+	srv, hub := newWSTestServer(t, 20*time.Millisecond)
+	conn, _, err := dialWS(t, srv, "")
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	defer conn.Close()
+	var pings atomic.Int32
+	conn.SetPingHandler(func(data string) error {
+		pings.Add(1)
+		return conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(time.Second))
+	})
+	writeWS(t, conn, `{"type":"subscribe","id":"all"}`)
+	// Control frames are handled while reading, so keep a reader running; answering the
+	// pings keeps the connection open well past the 40ms the server waits for a pong.
+	messages := make(chan WSMessage, 4)
+	go func() {
+		defer close(messages)
+		for {
+			var msg WSMessage
+			if err := conn.ReadJSON(&msg); err != nil {
+				return
+			}
+			messages <- msg
+		}
+	}()
+	if msg := <-messages; msg.Type != wsTypeSubscribed {
+		t.Fatalf("expected reply to be subscribed, got %+v", msg)
+	}
+	time.Sleep(150 * time.Millisecond)
+	hub.Broadcast(model.Event{ID: 1})
+	select {
+	case msg, ok := <-messages:
+		if !ok || eventID(t, msg) != 1 {
+			t.Fatalf("expected event 1, got %+v (open %v)", msg, ok)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("timed out waiting for event 1")
+	}
+	if got := pings.Load(); got < 2 {
+		t.Errorf("expected at least 2 pings, got %d", got)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"strings"
 
 	"github.com/gorilla/sessions"
 )
@@ -11,16 +12,42 @@ import (
 type contextKey string
 
 const (
-	userIDKey   contextKey = "user_id"
-	sessionName            = "github-dashboard-session"
-	sessionUser            = "user_id"
+	userIDKey      contextKey = "user_id"
+	sessionAuthKey contextKey = "session_auth"
+	sessionName               = "github-dashboard-session"
+	sessionUser               = "user_id"
 )
 
-// Auth returns an HTTP middleware that validates the user session.
-// Unauthenticated requests receive a 401 response.
-func Auth(store *sessions.CookieStore) func(http.Handler) http.Handler {
+// TokenAuthenticator resolves an API token to the ID of its user (0 if the token is unknown).
+type TokenAuthenticator interface {
+	Authenticate(token string) (int64, error)
+}
+
+// Auth returns an HTTP middleware that validates the user session, or the API token in an
+// "Authorization: Bearer <token>" header when one is sent and tokens is not nil.
+// Unauthenticated requests receive a 401 response. Requests authenticated by the session
+// are marked so that IsSessionAuthenticated reports true for them.
+func Auth(store *sessions.CookieStore, tokens TokenAuthenticator) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if token, ok := bearerToken(r); ok && tokens != nil {
+				userID, err := tokens.Authenticate(token)
+				if err != nil {
+					LogEvent("error", "failed to authenticate API token", map[string]interface{}{"error": err.Error()})
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusInternalServerError)
+					json.NewEncoder(w).Encode(map[string]string{"error": "failed to authenticate"})
+					return
+				}
+				if userID == 0 {
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusUnauthorized)
+					json.NewEncoder(w).Encode(map[string]string{"error": "invalid API token"})
+					return
+				}
+				next.ServeHTTP(w, r.WithContext(ContextWithUserID(r.Context(), userID)))
+				return
+			}
 			session, err := store.Get(r, sessionName)
 			if err != nil {
 				w.Header().Set("Content-Type", "application/json")
@@ -43,11 +70,34 @@ func Auth(store *sessions.CookieStore) func(http.Handler) http.Handler {
 				return
 			}
 			ctx := context.WithValue(r.Context(), userIDKey, userID)
+			ctx = context.WithValue(ctx, sessionAuthKey, true)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
 
+// bearerToken returns the token of an "Authorization: Bearer <token>" header.
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	return strings.TrimSpace(token), true
+}
+
+// ContextWithUserID returns a copy of ctx carrying an authenticated user ID, for
+// authentication paths other than Auth (such as API tokens).
+func ContextWithUserID(ctx context.Context, userID int64) context.Context {
+	return context.WithValue(ctx, userIDKey, userID)
+}
+
+// IsSessionAuthenticated reports whether the request was authenticated by the user's
+// session cookie rather than by an API token.
+func IsSessionAuthenticated(ctx context.Context) bool {
+	session, _ := ctx.Value(sessionAuthKey).(bool)
+	return session
+}
+
 // UserIDFromContext extracts the user ID from the request context.
 func UserIDFromContext(ctx context.Context) int64 {
 	val, ok := ctx.Value(userIDKey).(int64)
@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/sessions"
+)
+
+type staticTokens map[string]int64
+
+func (s staticTokens) Authenticate(token string) (int64, error) {
+	return s[token], nil
+}
+
+func TestAuth(t *testing.T) {
+	tests := []struct {
+		name            string
+		authorization   string
+		session         bool
+		tokens          TokenAuthenticator
+		expectedStatus  int
+		expectedUserID  int64
+		expectedSession bool
+	}{
+		{
+			name:           "Valid API token authenticates its user",
+			authorization:  "Bearer secret",
+			tokens:         staticTokens{"secret": 42},
+			expectedStatus: http.StatusOK,
+			expectedUserID: 42,
+		},
+		{
+			name:           "Bearer scheme is case-insensitive",
+			authorization:  "bearer secret",
+			tokens:         staticTokens{"secret": 42},
+			expectedStatus: http.StatusOK,
+			expectedUserID: 42,
+		},
+		{
+			name:            "Session authenticates its user and is marked",
+			session:         true,
+			tokens:          staticTokens{"secret": 42},
+			expectedStatus:  http.StatusOK,
+			expectedUserID:  7,
+			expectedSession: true,
+		},
+		{
+			name:           "Unknown API token is rejected",
+			authorization:  "Bearer wrong",
+			tokens:         staticTokens{"secret": 42},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Request without session or token is rejected",
+			tokens:         staticTokens{"secret": 42},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Tokens are ignored when no authenticator is configured",
+			authorization:  "Bearer secret",
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	store := sessions.NewCookieStore([]byte("0123456789abcdef0123456789abcdef"))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprintf(w, "%d %v", UserIDFromContext(r.Context()), IsSessionAuthenticated(r.Context()))
+			})
+			handler := Auth(store, tt.tokens)(nextHandler)
+
+			req := httptest.NewRequest(http.MethodGet, "http://example.com/api/events", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			if tt.session {
+				req.AddCookie(sessionCookie(t, store, 7))
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+			expected := fmt.Sprintf("%d %v", tt.expectedUserID, tt.expectedSession)
+			if tt.expectedStatus == http.StatusOK && w.Body.String() != expected {
+				t.Errorf("expected user ID and session flag %q, got %q", expected, w.Body.String())
+			}
+		})
+	}
+}
+
+// sessionCookie returns the cookie of a session signed in as userID.
+func sessionCookie(t *testing.T, store *sessions.CookieStore, userID int64) *http.Cookie {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+	rec := httptest.NewRecorder()
+	session, _ := store.Get(req, sessionName)
+	session.Values[sessionUser] = userID
+	if err := session.Save(req, rec); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return rec.Result().Cookies()[0]
+}
@@ -1,15 +1,22 @@
 package middleware
 
 import (
+	"bufio"
 	"encoding/json"
 	"log"
+	"net"
 	"net/http"
 	"os"
+	"path"
+	"strings"
 	"time"
 )
 
 var jsonLogger = log.New(os.Stdout, "", 0)
 
+// feedPathPrefix is the prefix of feed URLs, whose first segment is a secret feed token.
+const feedPathPrefix = "/api/feeds/"
+
 // LogEntry represents a structured log entry in JSON format.
 type LogEntry struct {
 	Timestamp  string `json:"timestamp"`
@@ -40,6 +47,20 @@ func (rw *responseWriter) Flush() {
 	}
 }
 
+// Hijack lets WebSocket handlers take over the connection.
+func (rw *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, brw, err := http.NewResponseController(rw.ResponseWriter).Hijack()
+	if err == nil {
+		rw.statusCode = http.StatusSwitchingProtocols
+	}
+	return conn, brw, err
+}
+
+// Unwrap returns the underlying ResponseWriter so http.ResponseController can reach it.
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
 // Logger is an HTTP middleware that logs each request in structured JSON format.
 func Logger(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -49,7 +70,7 @@ func Logger(next http.Handler) http.Handler {
 		entry := LogEntry{
 			Timestamp:  start.UTC().Format(time.RFC3339),
 			Method:     r.Method,
-			Path:       r.URL.Path,
+			Path:       redactPath(r.URL.Path),
 			StatusCode: rw.statusCode,
 			Duration:   time.Since(start).String(),
 			RemoteAddr: r.RemoteAddr,
@@ -59,6 +80,20 @@ func Logger(next http.Handler) http.Handler {
 	})
 }
 
+// redactPath hides the token in feed URLs (/api/feeds/{token}/{id}.atom) so access logs
+// do not leak credentials.
+func redactPath(p string) string {
+	rest, ok := strings.CutPrefix(p, feedPathPrefix)
+	if !ok {
+		return p
+	}
+	_, file, ok := strings.Cut(rest, "/")
+	if !ok || strings.Contains(file, "/") || path.Ext(file) == "" {
+		return p
+	}
+	return feedPathPrefix + "[REDACTED]/" + file
+}
+
 // LogEvent logs an application-level event in structured JSON format.
 func LogEvent(level string, message string, fields map[string]interface{}) {
 	entry := map[string]interface{}{
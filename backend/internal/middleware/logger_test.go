@@ -0,0 +1,26 @@
+package middleware
+
+import "testing"
+
+func TestRedactPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		expected string
+	}{
+		{name: "atom feed", path: "/api/feeds/0123abcd/12.atom", expected: "/api/feeds/[REDACTED]/12.atom"},
+		{name: "rss feed", path: "/api/feeds/0123abcd/12.rss", expected: "/api/feeds/[REDACTED]/12.rss"},
+		{name: "feed list", path: "/api/feeds", expected: "/api/feeds"},
+		{name: "feed by id", path: "/api/feeds/12", expected: "/api/feeds/12"},
+		{name: "feed token", path: "/api/feeds/token", expected: "/api/feeds/token"},
+		{name: "nested path without extension", path: "/api/feeds/0123abcd/12", expected: "/api/feeds/0123abcd/12"},
+		{name: "other path", path: "/api/events/12", expected: "/api/events/12"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := redactPath(tt.path); got != tt.expected {
+				t.Errorf("expected redactPath(%q) to be %q, got %q", tt.path, tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// APIToken is a personal access token that authenticates non-browser clients as its user.
+type APIToken struct {
+	ID         int64      `json:"id"`
+	UserID     int64      `json:"-"`
+	Name       string     `json:"name"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// APITokenRequest is the body of POST /api/tokens.
+type APITokenRequest struct {
+	Name string `json:"name"`
+}
+
+// APITokenResponse is returned when a token is created.
+// The token is only ever shown in this response; the server stores a hash of it.
+type APITokenResponse struct {
+	APIToken
+	Token string `json:"token"`
+}
@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+// ContributorStats holds per-sender activity counts over a time window.
+type ContributorStats struct {
+	Login        string  `json:"login"`
+	AvatarURL    *string `json:"avatar_url"`
+	IssuesOpened int     `json:"issues_opened"`
+	PRsMerged    int     `json:"prs_merged"`
+	Reviews      int     `json:"reviews"`
+	Pushes       int     `json:"pushes"`
+	Total        int     `json:"total"`
+}
+
+// ContributorListResponse is the leaderboard returned by GET /api/contributors.
+type ContributorListResponse struct {
+	Since        time.Time          `json:"since"`
+	Until        time.Time          `json:"until"`
+	Sort         string             `json:"sort"`
+	Contributors []ContributorStats `json:"contributors"`
+}
+
+// RepoActivity summarizes a contributor's activity in a single repository.
+type RepoActivity struct {
+	RepoName       string    `json:"repo_name"`
+	Count          int       `json:"count"`
+	LastActivityAt time.Time `json:"last_activity_at"`
+}
+
+// ContributorProfile is a single contributor's activity returned by GET /api/contributors/{login}.
+type ContributorProfile struct {
+	ContributorStats
+	Since        time.Time      `json:"since"`
+	Until        time.Time      `json:"until"`
+	Repos        []RepoActivity `json:"repos"`
+	Activity     []SeriesPoint  `json:"activity"`
+	RecentEvents []Event        `json:"recent_events"`
+}
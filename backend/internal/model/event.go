@@ -1,23 +1,188 @@
 package model
 
-import "time"
+import (
+	"slices"
+	"strings"
+	"time"
+)
 
 // Event represents a GitHub webhook event stored in the database.
 type Event struct {
-	ID              int64      `json:"id"`
-	DeliveryID      string     `json:"delivery_id"`
-	EventType       string     `json:"event_type"`
-	Action          string     `json:"action"`
-	RepoName        string     `json:"repo_name"`
-	SenderLogin     string     `json:"sender_login"`
-	SenderAvatarURL *string    `json:"sender_avatar_url"`
-	Title           *string    `json:"title"`
-	Body            *string    `json:"body"`
-	HTMLURL         string     `json:"html_url"`
-	EventData       *string    `json:"event_data"`
-	OccurredAt      time.Time  `json:"occurred_at"`
-	ReceivedAt      time.Time  `json:"received_at"`
-	CreatedAt       time.Time  `json:"created_at"`
+	ID              int64           `json:"id"`
+	DeliveryID      string          `json:"delivery_id"`
+	EventType       string          `json:"event_type"`
+	Action          string          `json:"action"`
+	RepoName        string          `json:"repo_name"`
+	SenderLogin     string          `json:"sender_login"`
+	SenderAvatarURL *string         `json:"sender_avatar_url"`
+	Title           *string         `json:"title"`
+	Body            *string         `json:"body"`
+	HTMLURL         string          `json:"html_url"`
+	EventData       *string         `json:"event_data"`
+	OccurredAt      time.Time       `json:"occurred_at"`
+	ReceivedAt      time.Time       `json:"received_at"`
+	CreatedAt       time.Time       `json:"created_at"`
+	Relevance       *float64        `json:"relevance,omitempty"`
+	Highlight       *EventHighlight `json:"highlight,omitempty"`
+}
+
+// EventHighlight holds HTML-escaped snippets of an event with search matches wrapped in <mark> tags.
+type EventHighlight struct {
+	Title *string `json:"title,omitempty"`
+	Body  *string `json:"body,omitempty"`
+}
+
+// IssueData is the event_data stored for issues and issue_comment events.
+// Author is the issue author; AuthorAssociation is that of the event's author
+// (the issue author for issues events, the commenter for issue_comment events).
+type IssueData struct {
+	Number            int    `json:"issue_number"`
+	Author            string `json:"issue_author,omitempty"`
+	AuthorAssociation string `json:"author_association,omitempty"`
+	IsPullRequest     bool   `json:"is_pull_request,omitempty"`
+}
+
+// PullRequestData is the event_data stored for pull_request and pull_request_review events.
+type PullRequestData struct {
+	Number       int        `json:"pull_request_number"`
+	Author       string     `json:"pull_request_author,omitempty"`
+	CreatedAt    *time.Time `json:"created_at,omitempty"`
+	MergedAt     *time.Time `json:"merged_at,omitempty"`
+	Additions    int        `json:"additions,omitempty"`
+	Deletions    int        `json:"deletions,omitempty"`
+	ChangedFiles int        `json:"changed_files,omitempty"`
+	ReviewState  string     `json:"review_state,omitempty"`
+}
+
+// DeploymentData is the event_data stored for deployment and deployment_status events.
+type DeploymentData struct {
+	DeploymentID int64  `json:"deployment_id"`
+	Environment  string `json:"environment"`
+	SHA          string `json:"sha,omitempty"`
+	State        string `json:"state,omitempty"`
+}
+
+// WorkflowRunData is the event_data stored for workflow_run events.
+type WorkflowRunData struct {
+	RunID        int64      `json:"workflow_run_id"`
+	Name         string     `json:"name"`
+	HeadBranch   string     `json:"head_branch,omitempty"`
+	HeadSHA      string     `json:"head_sha,omitempty"`
+	Conclusion   string     `json:"conclusion,omitempty"`
+	RunStartedAt *time.Time `json:"run_started_at,omitempty"`
+}
+
+// EventFilter holds the optional conditions used to narrow down an event list.
+// Empty slices and nil times mean "no restriction" for that field.
+type EventFilter struct {
+	EventTypes []string
+	Repos      []string
+	Owners     []string
+	Senders    []string
+	Actions    []string
+	Since      *time.Time
+	Until      *time.Time
+	// SearchTerms are matched against title and body. A term may contain spaces (a phrase).
+	SearchTerms []string
+	// Exclude* fields list values that matching events must not have.
+	ExcludeEventTypes []string
+	ExcludeRepos      []string
+	ExcludeOwners     []string
+	ExcludeSenders    []string
+	ExcludeActions    []string
+	// ExcludeBots drops events sent by GitHub App bot accounts (logins ending in "[bot]").
+	ExcludeBots bool
+	// States must all be matched by an event, and ExcludeStates must all not be.
+	States        []EventState
+	ExcludeStates []EventState
+}
+
+// EventState is a combination of event type and action that describes what an event is,
+// such as a merged pull request. An empty field matches any value.
+type EventState struct {
+	EventType string
+	Action    string
+}
+
+// Matches reports whether e has the state.
+func (s EventState) Matches(e *Event) bool {
+	return (s.EventType == "" || strings.EqualFold(s.EventType, e.EventType)) &&
+		(s.Action == "" || strings.EqualFold(s.Action, e.Action))
+}
+
+// searchOperators are the characters with a special meaning in MySQL BOOLEAN MODE
+// queries; they are not part of the text a search term matches.
+var searchOperators = strings.NewReplacer(
+	"+", " ", "-", " ", "<", " ", ">", " ", "(", " ", ")", " ",
+	"~", " ", "*", " ", `"`, " ", "@", " ",
+)
+
+// NormalizeSearchTerm returns the text a search term matches: operator characters are
+// replaced by spaces and whitespace is collapsed. It returns "" if nothing is left.
+func NormalizeSearchTerm(term string) string {
+	return strings.Join(strings.Fields(searchOperators.Replace(term)), " ")
+}
+
+// Matches reports whether e satisfies the filter. It mirrors the SQL conditions used for
+// stored events, comparing values case-insensitively like the database collation; search
+// terms are matched as case-insensitive substrings of the title or body.
+// It is used to filter live events that are not read from the database.
+func (f *EventFilter) Matches(e *Event) bool {
+	if !matchesAny(f.EventTypes, e.EventType) || !matchesAny(f.Repos, e.RepoName) ||
+		!matchesAny(f.Senders, e.SenderLogin) || !matchesAny(f.Actions, e.Action) {
+		return false
+	}
+	if containsFold(f.ExcludeEventTypes, e.EventType) || containsFold(f.ExcludeRepos, e.RepoName) ||
+		containsFold(f.ExcludeSenders, e.SenderLogin) || containsFold(f.ExcludeActions, e.Action) {
+		return false
+	}
+	if f.ExcludeBots && strings.HasSuffix(e.SenderLogin, "[bot]") {
+		return false
+	}
+	for _, state := range f.States {
+		if !state.Matches(e) {
+			return false
+		}
+	}
+	for _, state := range f.ExcludeStates {
+		if state.Matches(e) {
+			return false
+		}
+	}
+	owner, _, _ := strings.Cut(e.RepoName, "/")
+	if !matchesAny(f.Owners, owner) || containsFold(f.ExcludeOwners, owner) {
+		return false
+	}
+	if f.Since != nil && e.ReceivedAt.Before(*f.Since) {
+		return false
+	}
+	if f.Until != nil && !e.ReceivedAt.Before(*f.Until) {
+		return false
+	}
+	if len(f.SearchTerms) > 0 {
+		var text string
+		if e.Title != nil {
+			text = strings.ToLower(*e.Title)
+		}
+		if e.Body != nil {
+			text += "\n" + strings.ToLower(*e.Body)
+		}
+		for _, term := range f.SearchTerms {
+			if t := NormalizeSearchTerm(term); t != "" && !strings.Contains(text, strings.ToLower(t)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// matchesAny reports whether value is in values, treating an empty list as "any value".
+func matchesAny(values []string, value string) bool {
+	return len(values) == 0 || containsFold(values, value)
+}
+
+func containsFold(values []string, value string) bool {
+	return slices.ContainsFunc(values, func(v string) bool { return strings.EqualFold(v, value) })
 }
 
 // EventListResponse represents a paginated list of events returned by the API.
@@ -27,11 +192,23 @@ type EventListResponse struct {
 }
 
 // Pagination holds pagination metadata.
+// Page is set in page-number mode; NextCursor/PrevCursor in cursor mode.
+// Total and TotalPages are omitted when the total count was not requested.
 type Pagination struct {
-	Page       int `json:"page"`
-	PerPage    int `json:"per_page"`
-	Total      int `json:"total"`
-	TotalPages int `json:"total_pages"`
+	Page       int     `json:"page,omitempty"`
+	PerPage    int     `json:"per_page"`
+	Total      *int    `json:"total,omitempty"`
+	TotalPages *int    `json:"total_pages,omitempty"`
+	NextCursor *string `json:"next_cursor,omitempty"`
+	PrevCursor *string `json:"prev_cursor,omitempty"`
+}
+
+// EventCursor is a position in the event list ordered by (received_at, id) descending.
+// Backward selects the events before (newer than) the position instead of after it.
+type EventCursor struct {
+	ReceivedAt time.Time
+	ID         int64
+	Backward   bool
 }
 
 // WebhookResponse represents the response returned after processing a webhook.
@@ -39,3 +216,22 @@ type WebhookResponse struct {
 	Status  string `json:"status"`
 	EventID *int64 `json:"event_id,omitempty"`
 }
+
+// EventChangeType is what happened to a stored event.
+type EventChangeType string
+
+const (
+	// EventCreated is a newly stored event.
+	EventCreated EventChangeType = "created"
+	// EventUpdated is a stored event whose issue or comment was edited on GitHub.
+	EventUpdated EventChangeType = "updated"
+	// EventDeleted is an event removed because its issue or comment was deleted on GitHub.
+	EventDeleted EventChangeType = "deleted"
+)
+
+// EventChange is a change to the stored events, as published to the live streams. Event
+// is the new version of the event, or the removed event.
+type EventChange struct {
+	Type  EventChangeType
+	Event Event
+}
@@ -0,0 +1,35 @@
+package model
+
+import "time"
+
+// Feed is a saved event filter published as an Atom/RSS feed. Query holds the filter as
+// URL query parameters accepted by GET /api/events (e.g. "repo=acme/api&q=is:merged").
+type Feed struct {
+	ID        int64     `json:"id"`
+	UserID    int64     `json:"-"`
+	Name      string    `json:"name"`
+	Query     string    `json:"query"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// FeedRequest is the body of POST /api/feeds.
+type FeedRequest struct {
+	Name  string `json:"name"`
+	Query string `json:"query"`
+}
+
+// FeedURLs are the feed reader URLs of a feed, built with the owner's feed token.
+type FeedURLs struct {
+	Feed
+	AtomURL string `json:"atom_url"`
+	RSSURL  string `json:"rss_url"`
+}
+
+// FeedTokenResponse is returned when a user's feed token is (re)generated, with the URLs of
+// all of the user's feeds. The token is only ever shown in this response; the server stores
+// a hash of it.
+type FeedTokenResponse struct {
+	Token string     `json:"token"`
+	Feeds []FeedURLs `json:"feeds"`
+}
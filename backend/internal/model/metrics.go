@@ -0,0 +1,93 @@
+package model
+
+import "time"
+
+// DurationStats summarizes a set of durations with nearest-rank percentiles in seconds.
+// Percentiles are nil when Count is zero.
+type DurationStats struct {
+	Count      int    `json:"count"`
+	P50Seconds *int64 `json:"p50_seconds"`
+	P90Seconds *int64 `json:"p90_seconds"`
+}
+
+// SizeStats summarizes pull request sizes (additions + deletions).
+type SizeStats struct {
+	P50          *int        `json:"p50"`
+	P90          *int        `json:"p90"`
+	Distribution []StatCount `json:"distribution"`
+}
+
+// PullRequestMetrics holds delivery-speed metrics for a set of merged pull requests.
+type PullRequestMetrics struct {
+	PullRequests      int           `json:"pull_requests"`
+	TimeToFirstReview DurationStats `json:"time_to_first_review"`
+	TimeToMerge       DurationStats `json:"time_to_merge"`
+	Size              SizeStats     `json:"size"`
+}
+
+// RepoPullRequestMetrics holds pull request metrics for a single repository.
+type RepoPullRequestMetrics struct {
+	RepoName string `json:"repo_name"`
+	PullRequestMetrics
+}
+
+// WeeklyPullRequestMetrics holds pull request metrics for pull requests merged in one week.
+type WeeklyPullRequestMetrics struct {
+	WeekStart time.Time `json:"week_start"`
+	PullRequestMetrics
+}
+
+// PullRequestMetricsResponse is returned by GET /api/metrics/pull-requests.
+// Truncated is set when more pull requests matched than could be analyzed.
+type PullRequestMetricsResponse struct {
+	Since     time.Time                  `json:"since"`
+	Until     time.Time                  `json:"until"`
+	Timezone  string                     `json:"timezone"`
+	Overall   PullRequestMetrics         `json:"overall"`
+	Repos     []RepoPullRequestMetrics   `json:"repos"`
+	Weeks     []WeeklyPullRequestMetrics `json:"weeks"`
+	Truncated bool                       `json:"truncated"`
+}
+
+// DeploymentFrequency is the number of successful production deployments in a range.
+type DeploymentFrequency struct {
+	Deployments int     `json:"deployments"`
+	PerDay      float64 `json:"per_day"`
+}
+
+// ChangeFailureRate is the share of production deployment attempts that failed.
+// Rate is nil when there were no attempts.
+type ChangeFailureRate struct {
+	Failed int      `json:"failed"`
+	Total  int      `json:"total"`
+	Rate   *float64 `json:"rate"`
+}
+
+// DORAMetrics holds the four DORA delivery metrics.
+// UndeployedChanges counts merged pull requests with no later successful deployment and
+// UnresolvedFailures counts failure streaks not yet followed by a successful deployment.
+type DORAMetrics struct {
+	DeploymentFrequency DeploymentFrequency `json:"deployment_frequency"`
+	LeadTimeForChanges  DurationStats       `json:"lead_time_for_changes"`
+	ChangeFailureRate   ChangeFailureRate   `json:"change_failure_rate"`
+	TimeToRestore       DurationStats       `json:"time_to_restore"`
+	UndeployedChanges   int                 `json:"undeployed_changes"`
+	UnresolvedFailures  int                 `json:"unresolved_failures"`
+}
+
+// RepoDORAMetrics holds DORA metrics for a single repository.
+type RepoDORAMetrics struct {
+	RepoName               string   `json:"repo_name"`
+	ProductionEnvironments []string `json:"production_environments"`
+	DORAMetrics
+}
+
+// DORAMetricsResponse is returned by GET /api/metrics/dora.
+// Truncated is set when more events matched than could be analyzed.
+type DORAMetricsResponse struct {
+	Since     time.Time         `json:"since"`
+	Until     time.Time         `json:"until"`
+	Overall   DORAMetrics       `json:"overall"`
+	Repos     []RepoDORAMetrics `json:"repos"`
+	Truncated bool              `json:"truncated"`
+}
@@ -0,0 +1,11 @@
+package model
+
+import "time"
+
+// OutboxMessage is a live update written to the event_outbox table so that every backend
+// replica can pick it up and push it to its own stream clients.
+type OutboxMessage struct {
+	ID        int64
+	Payload   []byte
+	CreatedAt time.Time
+}
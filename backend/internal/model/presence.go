@@ -0,0 +1,13 @@
+package model
+
+// PresenceUser is a signed-in user with open live connections.
+type PresenceUser struct {
+	UserResponse
+	Connections int `json:"connections"`
+}
+
+// PresenceResponse lists the users online on the dashboard. It is returned by
+// GET /api/presence and sent as the data of presence stream messages.
+type PresenceResponse struct {
+	Users []PresenceUser `json:"users"`
+}
@@ -0,0 +1,58 @@
+package model
+
+import "time"
+
+// SLASummary holds first-response SLA results for a set of issues.
+// Responded issues count toward WithinTarget or Breached; unanswered open issues count as
+// Breached once past due and as Pending before that. Issues closed by their author before
+// any maintainer response count only toward Issues. ComplianceRate is
+// WithinTarget / (WithinTarget + Breached) and is nil when both are zero.
+// ResponseTime is measured in business time.
+type SLASummary struct {
+	Issues         int           `json:"issues"`
+	Responded      int           `json:"responded"`
+	WithinTarget   int           `json:"within_target"`
+	Breached       int           `json:"breached"`
+	Pending        int           `json:"pending"`
+	ComplianceRate *float64      `json:"compliance_rate"`
+	ResponseTime   DurationStats `json:"response_time"`
+}
+
+// RepoSLASummary holds first-response SLA results for a single repository.
+type RepoSLASummary struct {
+	RepoName string `json:"repo_name"`
+	SLASummary
+}
+
+// IssueSLAResponse is returned by GET /api/sla/issues.
+// Truncated is set when more issues were opened in the range than could be analyzed.
+type IssueSLAResponse struct {
+	Since         time.Time        `json:"since"`
+	Until         time.Time        `json:"until"`
+	Timezone      string           `json:"timezone"`
+	BusinessHours string           `json:"business_hours"`
+	TargetSeconds int64            `json:"target_seconds"`
+	Overall       SLASummary       `json:"overall"`
+	Repos         []RepoSLASummary `json:"repos"`
+	Truncated     bool             `json:"truncated"`
+}
+
+// SLABreach is an open issue that has not had a maintainer response by its due time.
+// OverdueSeconds is measured in business time.
+type SLABreach struct {
+	RepoName       string    `json:"repo_name"`
+	IssueNumber    int       `json:"issue_number"`
+	Title          *string   `json:"title"`
+	HTMLURL        string    `json:"html_url"`
+	Author         string    `json:"author"`
+	OpenedAt       time.Time `json:"opened_at"`
+	DueAt          time.Time `json:"due_at"`
+	OverdueSeconds int64     `json:"overdue_seconds"`
+}
+
+// SLABreachesResponse is returned by GET /api/sla/breaches, most overdue first.
+type SLABreachesResponse struct {
+	TargetSeconds int64       `json:"target_seconds"`
+	Breaches      []SLABreach `json:"breaches"`
+	Truncated     bool        `json:"truncated"`
+}
@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+// StatsResponse holds aggregated event counts over a time range returned by GET /api/stats.
+type StatsResponse struct {
+	Since       time.Time     `json:"since"`
+	Until       time.Time     `json:"until"`
+	Timezone    string        `json:"timezone"`
+	Bucket      string        `json:"bucket"`
+	Total       int           `json:"total"`
+	ByRepo      []StatCount   `json:"by_repo"`
+	ByEventType []StatCount   `json:"by_event_type"`
+	ByAction    []StatCount   `json:"by_action"`
+	BySender    []StatCount   `json:"by_sender"`
+	Series      []SeriesPoint `json:"series"`
+}
+
+// StatCount is the number of events sharing a single value of a grouping column.
+type StatCount struct {
+	Key   string `json:"key"`
+	Count int    `json:"count"`
+}
+
+// SeriesPoint is the number of events in the time bucket starting at Start (in the requested timezone).
+type SeriesPoint struct {
+	Start time.Time `json:"start"`
+	Count int       `json:"count"`
+}
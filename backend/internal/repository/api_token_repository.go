@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+)
+
+const apiTokenColumns = "id, user_id, name, last_used_at, created_at"
+
+// APITokenRepository handles database operations for personal API tokens.
+type APITokenRepository struct {
+	db *sql.DB
+}
+
+// NewAPITokenRepository creates a new APITokenRepository.
+func NewAPITokenRepository(db *sql.DB) *APITokenRepository {
+	return &APITokenRepository{db: db}
+}
+
+// CreateToken inserts a new token with the given hash and returns it with its ID.
+func (r *APITokenRepository) CreateToken(token *model.APIToken, tokenHash string) (*model.APIToken, error) {
+	result, err := r.db.Exec("INSERT INTO api_tokens (user_id, name, token_hash) VALUES (?, ?, ?)",
+		token.UserID, token.Name, tokenHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create api token: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last insert id: %w", err)
+	}
+	return r.findOne("SELECT "+apiTokenColumns+" FROM api_tokens WHERE id = ?", id)
+}
+
+// ListByUser returns the tokens owned by a user, oldest first.
+func (r *APITokenRepository) ListByUser(userID int64) ([]model.APIToken, error) {
+	rows, err := r.db.Query("SELECT "+apiTokenColumns+" FROM api_tokens WHERE user_id = ? ORDER BY id", userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api tokens: %w", err)
+	}
+	defer rows.Close()
+	tokens := []model.APIToken{}
+	for rows.Next() {
+		var t model.APIToken
+		if err := rows.Scan(&t.ID, &t.UserID, &t.Name, &t.LastUsedAt, &t.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan api token: %w", err)
+		}
+		tokens = append(tokens, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate api tokens: %w", err)
+	}
+	return tokens, nil
+}
+
+// CountByUser returns the number of tokens owned by a user.
+func (r *APITokenRepository) CountByUser(userID int64) (int, error) {
+	var count int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM api_tokens WHERE user_id = ?", userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count api tokens: %w", err)
+	}
+	return count, nil
+}
+
+// FindByTokenHash returns the token whose secret hashes to tokenHash, or nil if not found.
+func (r *APITokenRepository) FindByTokenHash(tokenHash string) (*model.APIToken, error) {
+	return r.findOne("SELECT "+apiTokenColumns+" FROM api_tokens WHERE token_hash = ?", tokenHash)
+}
+
+// TouchLastUsed records that a token was used, unless it was already recorded as used
+// within the last interval.
+func (r *APITokenRepository) TouchLastUsed(id int64, interval time.Duration) error {
+	now := time.Now().UTC()
+	_, err := r.db.Exec("UPDATE api_tokens SET last_used_at = ? WHERE id = ? AND (last_used_at IS NULL OR last_used_at < ?)",
+		now, id, now.Add(-interval))
+	if err != nil {
+		return fmt.Errorf("failed to update api token last use: %w", err)
+	}
+	return nil
+}
+
+// DeleteToken deletes a token owned by a user. Returns false if the token does not exist.
+func (r *APITokenRepository) DeleteToken(userID int64, id int64) (bool, error) {
+	result, err := r.db.Exec("DELETE FROM api_tokens WHERE id = ? AND user_id = ?", id, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete api token: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rowsAffected > 0, nil
+}
+
+func (r *APITokenRepository) findOne(query string, args ...interface{}) (*model.APIToken, error) {
+	var t model.APIToken
+	err := r.db.QueryRow(query, args...).Scan(&t.ID, &t.UserID, &t.Name, &t.LastUsedAt, &t.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find api token: %w", err)
+	}
+	return &t, nil
+}
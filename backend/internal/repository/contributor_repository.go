@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+)
+
+// ContributorSort identifies the activity column the leaderboard is ranked by.
+type ContributorSort string
+
+const (
+	SortByTotal        ContributorSort = "total"
+	SortByIssuesOpened ContributorSort = "issues_opened"
+	SortByPRsMerged    ContributorSort = "prs_merged"
+	SortByReviews      ContributorSort = "reviews"
+	SortByPushes       ContributorSort = "pushes"
+)
+
+// ErrInvalidContributorSort is returned for a sort key other than the ContributorSort values.
+var ErrInvalidContributorSort = errors.New("sort must be one of total, issues_opened, prs_merged, reviews, pushes")
+
+// ParseContributorSort returns the sort named by s, case-insensitively; "" means SortByTotal.
+func ParseContributorSort(s string) (ContributorSort, error) {
+	switch sort := ContributorSort(strings.ToLower(s)); sort {
+	case "":
+		return SortByTotal, nil
+	case SortByTotal, SortByIssuesOpened, SortByPRsMerged, SortByReviews, SortByPushes:
+		return sort, nil
+	default:
+		return "", ErrInvalidContributorSort
+	}
+}
+
+// contributorColumns aggregates one row per sender into model.ContributorStats field order.
+const contributorColumns = `sender_login, MAX(sender_avatar_url),
+	SUM(event_type = 'issues' AND action = 'opened') AS issues_opened,
+	SUM(event_type = 'pull_request' AND action = 'merged') AS prs_merged,
+	SUM(event_type = 'pull_request_review') AS reviews,
+	SUM(event_type = 'push') AS pushes,
+	COUNT(*) AS total`
+
+// ContributorRepository handles per-sender aggregate queries over the events table.
+type ContributorRepository struct {
+	db *sql.DB
+}
+
+// NewContributorRepository creates a new ContributorRepository.
+func NewContributorRepository(db *sql.DB) *ContributorRepository {
+	return &ContributorRepository{db: db}
+}
+
+// ListContributors returns per-sender activity counts for events matching the filter,
+// ranked by sort (descending) and limited to limit senders.
+func (r *ContributorRepository) ListContributors(filter model.EventFilter, sort ContributorSort, limit int) ([]model.ContributorStats, error) {
+	// The sort key is interpolated into the query, so it is validated here as well.
+	sort, err := ParseContributorSort(string(sort))
+	if err != nil {
+		return nil, err
+	}
+	where, args := buildEventWhere(filter)
+	query := "SELECT " + contributorColumns + " FROM events" + where +
+		" GROUP BY sender_login ORDER BY " + string(sort) + " DESC, total DESC, sender_login ASC LIMIT ?"
+	rows, err := r.db.Query(query, append(args, limit)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list contributors: %w", err)
+	}
+	defer rows.Close()
+	contributors := []model.ContributorStats{}
+	for rows.Next() {
+		var c model.ContributorStats
+		if err := rows.Scan(&c.Login, &c.AvatarURL, &c.IssuesOpened, &c.PRsMerged, &c.Reviews, &c.Pushes, &c.Total); err != nil {
+			return nil, fmt.Errorf("failed to scan contributor: %w", err)
+		}
+		contributors = append(contributors, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate contributors: %w", err)
+	}
+	return contributors, nil
+}
+
+// GetContributorStats returns the activity counts of a single sender for events matching
+// the filter, or nil if the sender has no matching events.
+func (r *ContributorRepository) GetContributorStats(filter model.EventFilter, login string) (*model.ContributorStats, error) {
+	filter.Senders = []string{login}
+	where, args := buildEventWhere(filter)
+	query := "SELECT " + contributorColumns + " FROM events" + where + " GROUP BY sender_login"
+	var c model.ContributorStats
+	err := r.db.QueryRow(query, args...).Scan(&c.Login, &c.AvatarURL, &c.IssuesOpened, &c.PRsMerged, &c.Reviews, &c.Pushes, &c.Total)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contributor stats: %w", err)
+	}
+	return &c, nil
+}
+
+// ListContributorRepos returns the repositories a sender has events in, most active first.
+func (r *ContributorRepository) ListContributorRepos(filter model.EventFilter, login string) ([]model.RepoActivity, error) {
+	filter.Senders = []string{login}
+	where, args := buildEventWhere(filter)
+	query := "SELECT repo_name, COUNT(*) AS cnt, MAX(received_at) FROM events" + where +
+		" GROUP BY repo_name ORDER BY cnt DESC, repo_name ASC"
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list contributor repos: %w", err)
+	}
+	defer rows.Close()
+	repos := []model.RepoActivity{}
+	for rows.Next() {
+		var a model.RepoActivity
+		if err := rows.Scan(&a.RepoName, &a.Count, &a.LastActivityAt); err != nil {
+			return nil, fmt.Errorf("failed to scan contributor repo: %w", err)
+		}
+		repos = append(repos, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate contributor repos: %w", err)
+	}
+	return repos, nil
+}
+
+// CountContributorDays returns a sender's number of events per UTC day, keyed by day start.
+func (r *ContributorRepository) CountContributorDays(filter model.EventFilter, login string) (map[time.Time]int, error) {
+	filter.Senders = []string{login}
+	where, args := buildEventWhere(filter)
+	query := "SELECT DATE(received_at) AS day, COUNT(*) FROM events" + where + " GROUP BY day"
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count contributor activity: %w", err)
+	}
+	defer rows.Close()
+	days := make(map[time.Time]int)
+	for rows.Next() {
+		var day time.Time
+		var count int
+		if err := rows.Scan(&day, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan contributor activity: %w", err)
+		}
+		days[day.UTC()] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate contributor activity: %w", err)
+	}
+	return days, nil
+}
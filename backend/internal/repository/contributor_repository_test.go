@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseContributorSort(t *testing.T) {
+	tests := []struct {
+		value        string
+		expectedSort ContributorSort
+		expectedErr  error
+	}{
+		{value: "", expectedSort: SortByTotal},
+		{value: "total", expectedSort: SortByTotal},
+		{value: "PRS_MERGED", expectedSort: SortByPRsMerged},
+		{value: "reviews", expectedSort: SortByReviews},
+		{value: "stars", expectedErr: ErrInvalidContributorSort},
+		{value: "total DESC, sender_login", expectedErr: ErrInvalidContributorSort},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			sort, err := ParseContributorSort(tt.value)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("expected error %v, got %v", tt.expectedErr, err)
+			}
+			if sort != tt.expectedSort {
+				t.Errorf("expected sort %q, got %q", tt.expectedSort, sort)
+			}
+		})
+	}
+}
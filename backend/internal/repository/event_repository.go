@@ -1,12 +1,28 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
 )
 
+// eventColumns is the column list every event query selects, in model.Event field order.
+const eventColumns = "id, delivery_id, event_type, action, repo_name, sender_login, sender_avatar_url, title, body, html_url, event_data, occurred_at, received_at, created_at"
+
+// botLoginSuffix is the suffix GitHub appends to the login of GitHub App bot accounts.
+const botLoginSuffix = "[bot]"
+
+const (
+	// minFullTextTokenLength mirrors MySQL's default innodb_ft_min_token_size.
+	minFullTextTokenLength = 3
+	fullTextMatch          = "MATCH(title, body) AGAINST (? IN BOOLEAN MODE)"
+)
+
 // EventRepository handles database operations for events.
 type EventRepository struct {
 	db *sql.DB
@@ -44,49 +60,205 @@ func (r *EventRepository) InsertEvent(event *model.Event) (*model.Event, bool, e
 	return event, isDuplicate, nil
 }
 
-// ListEvents returns a paginated list of events, optionally filtered by event type.
-func (r *EventRepository) ListEvents(page int, perPage int, eventType string) ([]model.Event, int, error) {
-	countQuery := "SELECT COUNT(*) FROM events"
-	listQuery := "SELECT id, delivery_id, event_type, action, repo_name, sender_login, sender_avatar_url, title, body, html_url, event_data, occurred_at, received_at, created_at FROM events"
-	var args []interface{}
-	if eventType != "" {
-		countQuery += " WHERE event_type = ?"
-		listQuery += " WHERE event_type = ?"
-		args = append(args, eventType)
-	}
-	var total int
-	if err := r.db.QueryRow(countQuery, args...).Scan(&total); err != nil {
-		return nil, 0, fmt.Errorf("failed to count events: %w", err)
+// ListEvents returns a page of events matching the given filter using LIMIT/OFFSET.
+// When the filter contains full-text search terms, results are ordered by relevance.
+func (r *EventRepository) ListEvents(filter model.EventFilter, page int, perPage int) ([]model.Event, error) {
+	where, args := buildEventWhere(filter)
+	relevance, relevanceArgs := buildSearchRelevance(filter.SearchTerms)
+	listQuery := "SELECT " + eventColumns
+	orderBy := " ORDER BY received_at DESC, id DESC"
+	if relevance != "" {
+		listQuery += ", " + relevance + " AS relevance"
+		orderBy = " ORDER BY relevance DESC, received_at DESC, id DESC"
 	}
+	listQuery += " FROM events" + where + orderBy + " LIMIT ? OFFSET ?"
 	offset := (page - 1) * perPage
-	listQuery += " ORDER BY received_at DESC LIMIT ? OFFSET ?"
-	listArgs := append(args, perPage, offset)
+	listArgs := append(append(append([]interface{}{}, relevanceArgs...), args...), perPage, offset)
 	rows, err := r.db.Query(listQuery, listArgs...)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to list events: %w", err)
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+	defer rows.Close()
+	return scanEvents(rows, relevance != "")
+}
+
+// ListEventsByCursor returns up to limit events matching the filter, positioned relative to
+// cursor on the (received_at, id) key. Events are always returned newest first.
+// A nil cursor starts from the newest event. The boolean result reports whether more
+// events exist beyond the returned ones in the cursor's direction.
+func (r *EventRepository) ListEventsByCursor(filter model.EventFilter, cursor *model.EventCursor, limit int) ([]model.Event, bool, error) {
+	where, args := buildEventWhere(filter)
+	orderBy := " ORDER BY received_at DESC, id DESC"
+	if cursor != nil {
+		keyset := "(received_at < ? OR (received_at = ? AND id < ?))"
+		if cursor.Backward {
+			keyset = "(received_at > ? OR (received_at = ? AND id > ?))"
+			orderBy = " ORDER BY received_at ASC, id ASC"
+		}
+		where = appendCondition(where, keyset)
+		args = append(args, cursor.ReceivedAt, cursor.ReceivedAt, cursor.ID)
+	}
+	query := "SELECT " + eventColumns + " FROM events" + where + orderBy + " LIMIT ?"
+	rows, err := r.db.Query(query, append(args, limit+1)...)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to list events: %w", err)
 	}
 	defer rows.Close()
-	var events []model.Event
+	events, err := scanEvents(rows, false)
+	if err != nil {
+		return nil, false, err
+	}
+	hasMore := len(events) > limit
+	if hasMore {
+		events = events[:limit]
+	}
+	if cursor != nil && cursor.Backward {
+		slices.Reverse(events)
+	}
+	return events, hasMore, nil
+}
+
+// StreamEvents calls fn for up to limit events matching the filter, newest first, scanning
+// one row at a time from the database cursor so the result set is never held in memory.
+// Iteration stops at the first error returned by fn, which is returned as is. Cancelling
+// ctx aborts the query.
+func (r *EventRepository) StreamEvents(ctx context.Context, filter model.EventFilter, limit int, fn func(*model.Event) error) error {
+	where, args := buildEventWhere(filter)
+	query := "SELECT " + eventColumns + " FROM events" + where + " ORDER BY received_at DESC, id DESC LIMIT ?"
+	rows, err := r.db.QueryContext(ctx, query, append(args, limit)...)
+	if err != nil {
+		return fmt.Errorf("failed to stream events: %w", err)
+	}
+	defer rows.Close()
+	var e model.Event
 	for rows.Next() {
-		var e model.Event
+		e = model.Event{}
 		if err := rows.Scan(
 			&e.ID, &e.DeliveryID, &e.EventType, &e.Action, &e.RepoName,
 			&e.SenderLogin, &e.SenderAvatarURL, &e.Title, &e.Body,
 			&e.HTMLURL, &e.EventData, &e.OccurredAt, &e.ReceivedAt, &e.CreatedAt,
 		); err != nil {
-			return nil, 0, fmt.Errorf("failed to scan event: %w", err)
+			return fmt.Errorf("failed to scan event: %w", err)
+		}
+		if err := fn(&e); err != nil {
+			return err
 		}
-		events = append(events, e)
 	}
-	if events == nil {
-		events = []model.Event{}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate events: %w", err)
 	}
-	return events, total, nil
+	return nil
+}
+
+// ListEventsAfterID returns up to limit events matching the filter with an ID greater than
+// afterID, in ID order. It is used to replay events a stream client missed while
+// disconnected.
+func (r *EventRepository) ListEventsAfterID(ctx context.Context, filter model.EventFilter, afterID int64, limit int) ([]model.Event, error) {
+	where, args := buildEventWhere(filter)
+	where = appendCondition(where, "id > ?")
+	query := "SELECT " + eventColumns + " FROM events" + where + " ORDER BY id ASC LIMIT ?"
+	rows, err := r.db.QueryContext(ctx, query, append(args, afterID, limit)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events after id: %w", err)
+	}
+	defer rows.Close()
+	return scanEvents(rows, false)
+}
+
+// IssueScope selects the stored issues and issue_comment events of an issue, or only the
+// event of one of its comments when CommentURL is set.
+type IssueScope struct {
+	RepoName   string
+	Number     int
+	CommentURL string
+}
+
+func (s IssueScope) where() (string, []interface{}) {
+	where := " WHERE repo_name = ? AND issue_number = ? AND event_type IN ('issues', 'issue_comment')"
+	args := []interface{}{s.RepoName, s.Number}
+	if s.CommentURL != "" {
+		where += " AND event_type = 'issue_comment' AND html_url = ?"
+		args = append(args, s.CommentURL)
+	}
+	return where, args
+}
+
+// UpdateEvents calls update with each event in scope and stores the title and body of the
+// events for which it returns true. It returns the updated events, in ID order.
+func (r *EventRepository) UpdateEvents(ctx context.Context, scope IssueScope, update func(*model.Event) bool) ([]model.Event, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+	events, err := lockEvents(ctx, tx, scope)
+	if err != nil {
+		return nil, err
+	}
+	updated := []model.Event{}
+	for _, e := range events {
+		if !update(&e) {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, "UPDATE events SET title = ?, body = ? WHERE id = ?", e.Title, e.Body, e.ID); err != nil {
+			return nil, fmt.Errorf("failed to update event: %w", err)
+		}
+		updated = append(updated, e)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit event updates: %w", err)
+	}
+	return updated, nil
+}
+
+// DeleteEvents deletes the events in scope and returns them, in ID order.
+func (r *EventRepository) DeleteEvents(ctx context.Context, scope IssueScope) ([]model.Event, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+	events, err := lockEvents(ctx, tx, scope)
+	if err != nil || len(events) == 0 {
+		return events, err
+	}
+	ids := make([]interface{}, len(events))
+	for i, e := range events {
+		ids[i] = e.ID
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM events WHERE id IN ("+placeholders(len(ids))+")", ids...); err != nil {
+		return nil, fmt.Errorf("failed to delete events: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit event deletion: %w", err)
+	}
+	return events, nil
+}
+
+// lockEvents returns the events in scope, locking them until tx ends.
+func lockEvents(ctx context.Context, tx *sql.Tx, scope IssueScope) ([]model.Event, error) {
+	where, args := scope.where()
+	rows, err := tx.QueryContext(ctx, "SELECT "+eventColumns+" FROM events"+where+" ORDER BY id ASC FOR UPDATE", args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock events: %w", err)
+	}
+	defer rows.Close()
+	return scanEvents(rows, false)
+}
+
+// CountEvents returns the number of events matching the given filter.
+func (r *EventRepository) CountEvents(filter model.EventFilter) (int, error) {
+	where, args := buildEventWhere(filter)
+	var total int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM events"+where, args...).Scan(&total); err != nil {
+		return 0, fmt.Errorf("failed to count events: %w", err)
+	}
+	return total, nil
 }
 
 // GetEventByID returns a single event by its ID.
 func (r *EventRepository) GetEventByID(id int64) (*model.Event, error) {
-	query := "SELECT id, delivery_id, event_type, action, repo_name, sender_login, sender_avatar_url, title, body, html_url, event_data, occurred_at, received_at, created_at FROM events WHERE id = ?"
+	query := "SELECT " + eventColumns + " FROM events WHERE id = ?"
 	var e model.Event
 	err := r.db.QueryRow(query, id).Scan(
 		&e.ID, &e.DeliveryID, &e.EventType, &e.Action, &e.RepoName,
@@ -101,3 +273,191 @@ func (r *EventRepository) GetEventByID(id int64) (*model.Event, error) {
 	}
 	return &e, nil
 }
+
+// scanEvents reads all rows selected with eventColumns (followed by a relevance column
+// when withRelevance is set). It never returns a nil slice so the JSON output is [].
+func scanEvents(rows *sql.Rows, withRelevance bool) ([]model.Event, error) {
+	events := []model.Event{}
+	for rows.Next() {
+		var e model.Event
+		dest := []interface{}{
+			&e.ID, &e.DeliveryID, &e.EventType, &e.Action, &e.RepoName,
+			&e.SenderLogin, &e.SenderAvatarURL, &e.Title, &e.Body,
+			&e.HTMLURL, &e.EventData, &e.OccurredAt, &e.ReceivedAt, &e.CreatedAt,
+		}
+		if withRelevance {
+			dest = append(dest, &e.Relevance)
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate events: %w", err)
+	}
+	return events, nil
+}
+
+// buildEventWhere translates an EventFilter into a WHERE clause and its bind arguments.
+// Every value is passed as a placeholder argument; only fixed column names are
+// interpolated into the SQL. Returns an empty clause when the filter has no conditions.
+func buildEventWhere(filter model.EventFilter) (string, []interface{}) {
+	var conds []string
+	var args []interface{}
+	addIn := func(column string, values []string) {
+		if len(values) == 0 {
+			return
+		}
+		if len(values) == 1 {
+			conds = append(conds, column+" = ?")
+		} else {
+			conds = append(conds, column+" IN ("+placeholders(len(values))+")")
+		}
+		for _, v := range values {
+			args = append(args, v)
+		}
+	}
+	addNotIn := func(column string, values []string) {
+		if len(values) == 0 {
+			return
+		}
+		conds = append(conds, column+" NOT IN ("+placeholders(len(values))+")")
+		for _, v := range values {
+			args = append(args, v)
+		}
+	}
+	addIn("event_type", filter.EventTypes)
+	addIn("repo_name", filter.Repos)
+	addIn("sender_login", filter.Senders)
+	addIn("action", filter.Actions)
+	addNotIn("event_type", filter.ExcludeEventTypes)
+	addNotIn("repo_name", filter.ExcludeRepos)
+	addNotIn("sender_login", filter.ExcludeSenders)
+	addNotIn("action", filter.ExcludeActions)
+	addState := func(state model.EventState, negated bool) {
+		var parts []string
+		if state.EventType != "" {
+			parts = append(parts, "event_type = ?")
+			args = append(args, state.EventType)
+		}
+		if state.Action != "" {
+			parts = append(parts, "action = ?")
+			args = append(args, state.Action)
+		}
+		if len(parts) == 0 {
+			return
+		}
+		cond := "(" + strings.Join(parts, " AND ") + ")"
+		if negated {
+			cond = "NOT " + cond
+		}
+		conds = append(conds, cond)
+	}
+	for _, state := range filter.States {
+		addState(state, false)
+	}
+	for _, state := range filter.ExcludeStates {
+		addState(state, true)
+	}
+	if filter.ExcludeBots {
+		conds = append(conds, "sender_login NOT LIKE ?")
+		args = append(args, "%"+botLoginSuffix)
+	}
+	for _, owner := range filter.ExcludeOwners {
+		conds = append(conds, "repo_name NOT LIKE ?")
+		args = append(args, escapeLike(owner)+"/%")
+	}
+	if len(filter.Owners) > 0 {
+		ownerConds := make([]string, len(filter.Owners))
+		for i, owner := range filter.Owners {
+			ownerConds[i] = "repo_name LIKE ?"
+			args = append(args, escapeLike(owner)+"/%")
+		}
+		if len(ownerConds) == 1 {
+			conds = append(conds, ownerConds[0])
+		} else {
+			conds = append(conds, "("+strings.Join(ownerConds, " OR ")+")")
+		}
+	}
+	if filter.Since != nil {
+		conds = append(conds, "received_at >= ?")
+		args = append(args, *filter.Since)
+	}
+	if filter.Until != nil {
+		conds = append(conds, "received_at < ?")
+		args = append(args, *filter.Until)
+	}
+	fullText, short := splitSearchTerms(filter.SearchTerms)
+	if fullText != "" {
+		conds = append(conds, fullTextMatch)
+		args = append(args, fullText)
+	}
+	for _, term := range short {
+		conds = append(conds, "(title LIKE ? OR body LIKE ?)")
+		pattern := "%" + escapeLike(term) + "%"
+		args = append(args, pattern, pattern)
+	}
+	if len(conds) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conds, " AND "), args
+}
+
+// buildSearchRelevance returns the relevance score expression for the full-text part of
+// the search terms, or an empty string when no term is eligible for full-text matching.
+func buildSearchRelevance(terms []string) (string, []interface{}) {
+	fullText, _ := splitSearchTerms(terms)
+	if fullText == "" {
+		return "", nil
+	}
+	return fullTextMatch, []interface{}{fullText}
+}
+
+// splitSearchTerms separates terms that the FULLTEXT index can serve from terms that are
+// too short to be indexed (innodb_ft_min_token_size). The former are combined into a single
+// BOOLEAN MODE expression requiring every term; the latter are returned for LIKE matching.
+func splitSearchTerms(terms []string) (string, []string) {
+	var required []string
+	var short []string
+	for _, term := range terms {
+		words := strings.Fields(model.NormalizeSearchTerm(term))
+		if len(words) == 0 {
+			continue
+		}
+		indexable := true
+		for _, w := range words {
+			if utf8.RuneCountInString(w) < minFullTextTokenLength {
+				indexable = false
+				break
+			}
+		}
+		if !indexable {
+			short = append(short, strings.Join(words, " "))
+			continue
+		}
+		if len(words) == 1 {
+			required = append(required, "+"+words[0])
+		} else {
+			required = append(required, `+"`+strings.Join(words, " ")+`"`)
+		}
+	}
+	return strings.Join(required, " "), short
+}
+
+// appendCondition adds cond to a WHERE clause produced by buildEventWhere.
+func appendCondition(where string, cond string) string {
+	if where == "" {
+		return " WHERE " + cond
+	}
+	return where + " AND " + cond
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+// escapeLike escapes the LIKE wildcard characters so user input is matched literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+)
+
+func TestBuildEventWhere(t *testing.T) {
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		filter        model.EventFilter
+		expectedWhere string
+		expectedArgs  []interface{}
+	}{
+		{
+			name:          "Empty filter produces no WHERE clause",
+			filter:        model.EventFilter{},
+			expectedWhere: "",
+			expectedArgs:  nil,
+		},
+		{
+			name:          "Single event type uses equality",
+			filter:        model.EventFilter{EventTypes: []string{"issues"}},
+			expectedWhere: " WHERE event_type = ?",
+			expectedArgs:  []interface{}{"issues"},
+		},
+		{
+			name:          "Multiple repos use IN",
+			filter:        model.EventFilter{Repos: []string{"acme/api", "acme/web"}},
+			expectedWhere: " WHERE repo_name IN (?, ?)",
+			expectedArgs:  []interface{}{"acme/api", "acme/web"},
+		},
+		{
+			name:          "Owners match repo prefix with escaped wildcards",
+			filter:        model.EventFilter{Owners: []string{"acme", "my_org%"}},
+			expectedWhere: " WHERE (repo_name LIKE ? OR repo_name LIKE ?)",
+			expectedArgs:  []interface{}{"acme/%", `my\_org\%/%`},
+		},
+		{
+			name: "All conditions are combined with AND",
+			filter: model.EventFilter{
+				EventTypes: []string{"issues", "pull_request"},
+				Repos:      []string{"acme/api"},
+				Owners:     []string{"acme"},
+				Senders:    []string{"alice"},
+				Actions:    []string{"opened"},
+				Since:      &since,
+				Until:      &until,
+			},
+			expectedWhere: " WHERE event_type IN (?, ?) AND repo_name = ? AND sender_login = ? AND action = ? AND repo_name LIKE ? AND received_at >= ? AND received_at < ?",
+			expectedArgs:  []interface{}{"issues", "pull_request", "acme/api", "alice", "opened", "acme/%", since, until},
+		},
+		{
+			name:          "Bots are excluded by login suffix",
+			filter:        model.EventFilter{Senders: []string{"alice"}, ExcludeBots: true},
+			expectedWhere: " WHERE sender_login = ? AND sender_login NOT LIKE ?",
+			expectedArgs:  []interface{}{"alice", "%[bot]"},
+		},
+		{
+			name: "Each state is one compound condition",
+			filter: model.EventFilter{
+				States:        []model.EventState{{EventType: "pull_request", Action: "merged"}, {Action: "opened"}},
+				ExcludeStates: []model.EventState{{EventType: "pull_request", Action: "merged"}},
+			},
+			expectedWhere: " WHERE (event_type = ? AND action = ?) AND (action = ?) AND NOT (event_type = ? AND action = ?)",
+			expectedArgs:  []interface{}{"pull_request", "merged", "opened", "pull_request", "merged"},
+		},
+		{
+			name:          "Long search terms use the full-text index",
+			filter:        model.EventFilter{SearchTerms: []string{"cache", "flaky test"}},
+			expectedWhere: " WHERE MATCH(title, body) AGAINST (? IN BOOLEAN MODE)",
+			expectedArgs:  []interface{}{`+cache +"flaky test"`},
+		},
+		{
+			name:          "Short search terms fall back to LIKE",
+			filter:        model.EventFilter{SearchTerms: []string{"cache", "ci", "5%"}},
+			expectedWhere: " WHERE MATCH(title, body) AGAINST (? IN BOOLEAN MODE) AND (title LIKE ? OR body LIKE ?) AND (title LIKE ? OR body LIKE ?)",
+			expectedArgs:  []interface{}{"+cache", "%ci%", "%ci%", `%5\%%`, `%5\%%`},
+		},
+		{
+			name:          "Boolean mode operators are stripped from search terms",
+			filter:        model.EventFilter{SearchTerms: []string{"-bug*", "+()"}},
+			expectedWhere: " WHERE MATCH(title, body) AGAINST (? IN BOOLEAN MODE)",
+			expectedArgs:  []interface{}{"+bug"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			where, args := buildEventWhere(tt.filter)
+			if where != tt.expectedWhere {
+				t.Errorf("expected where %q, got %q", tt.expectedWhere, where)
+			}
+			if !reflect.DeepEqual(args, tt.expectedArgs) {
+				t.Errorf("expected args %v, got %v", tt.expectedArgs, args)
+			}
+		})
+	}
+}
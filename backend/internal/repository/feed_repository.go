@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+)
+
+const feedColumns = "id, user_id, name, filter_query, created_at, updated_at"
+
+// FeedRepository handles database operations for saved feeds and the per-user feed tokens
+// that grant access to them.
+type FeedRepository struct {
+	db *sql.DB
+}
+
+// NewFeedRepository creates a new FeedRepository.
+func NewFeedRepository(db *sql.DB) *FeedRepository {
+	return &FeedRepository{db: db}
+}
+
+// CreateFeed inserts a new feed and returns it with its ID.
+func (r *FeedRepository) CreateFeed(feed *model.Feed) (*model.Feed, error) {
+	result, err := r.db.Exec("INSERT INTO feeds (user_id, name, filter_query) VALUES (?, ?, ?)",
+		feed.UserID, feed.Name, feed.Query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create feed: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last insert id: %w", err)
+	}
+	return r.FindByID(feed.UserID, id)
+}
+
+// ListByUser returns the feeds owned by a user, oldest first.
+func (r *FeedRepository) ListByUser(userID int64) ([]model.Feed, error) {
+	rows, err := r.db.Query("SELECT "+feedColumns+" FROM feeds WHERE user_id = ? ORDER BY id", userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list feeds: %w", err)
+	}
+	defer rows.Close()
+	feeds := []model.Feed{}
+	for rows.Next() {
+		var f model.Feed
+		if err := rows.Scan(&f.ID, &f.UserID, &f.Name, &f.Query, &f.CreatedAt, &f.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan feed: %w", err)
+		}
+		feeds = append(feeds, f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate feeds: %w", err)
+	}
+	return feeds, nil
+}
+
+// CountByUser returns the number of feeds owned by a user.
+func (r *FeedRepository) CountByUser(userID int64) (int, error) {
+	var count int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM feeds WHERE user_id = ?", userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count feeds: %w", err)
+	}
+	return count, nil
+}
+
+// FindByID returns a feed owned by a user, or nil if not found.
+func (r *FeedRepository) FindByID(userID int64, id int64) (*model.Feed, error) {
+	return r.findOne("SELECT "+feedColumns+" FROM feeds WHERE id = ? AND user_id = ?", id, userID)
+}
+
+// FindByTokenHash returns the feed with the given ID if it belongs to the user whose feed
+// token hashes to tokenHash, or nil if not found.
+func (r *FeedRepository) FindByTokenHash(tokenHash string, id int64) (*model.Feed, error) {
+	return r.findOne("SELECT "+feedColumns+" FROM feeds WHERE id = ? AND user_id = "+
+		"(SELECT user_id FROM feed_tokens WHERE token_hash = ?)", id, tokenHash)
+}
+
+// SetUserTokenHash sets a user's feed token, replacing any previous one.
+func (r *FeedRepository) SetUserTokenHash(userID int64, tokenHash string) error {
+	_, err := r.db.Exec("INSERT INTO feed_tokens (user_id, token_hash) VALUES (?, ?)"+
+		" ON DUPLICATE KEY UPDATE token_hash = VALUES(token_hash), created_at = CURRENT_TIMESTAMP", userID, tokenHash)
+	if err != nil {
+		return fmt.Errorf("failed to set feed token: %w", err)
+	}
+	return nil
+}
+
+// DeleteUserToken deletes a user's feed token. Returns false if the user has none.
+func (r *FeedRepository) DeleteUserToken(userID int64) (bool, error) {
+	result, err := r.db.Exec("DELETE FROM feed_tokens WHERE user_id = ?", userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete feed token: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rowsAffected > 0, nil
+}
+
+// DeleteFeed deletes a feed owned by a user. Returns false if the feed does not exist.
+func (r *FeedRepository) DeleteFeed(userID int64, id int64) (bool, error) {
+	result, err := r.db.Exec("DELETE FROM feeds WHERE id = ? AND user_id = ?", id, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete feed: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rowsAffected > 0, nil
+}
+
+func (r *FeedRepository) findOne(query string, args ...interface{}) (*model.Feed, error) {
+	var f model.Feed
+	err := r.db.QueryRow(query, args...).Scan(&f.ID, &f.UserID, &f.Name, &f.Query, &f.CreatedAt, &f.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find feed: %w", err)
+	}
+	return &f, nil
+}
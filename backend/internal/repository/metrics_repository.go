@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+)
+
+// MergedPullRequest is the timeline of a merged pull request assembled from stored events.
+type MergedPullRequest struct {
+	RepoName      string
+	Number        int
+	CreatedAt     time.Time
+	MergedAt      time.Time
+	FirstReviewAt *time.Time
+	Additions     int
+	Deletions     int
+}
+
+// DeploymentOutcome is a finished deployment attempt, from either a deployment_status
+// event (Environment set) or a completed workflow_run event (Workflow set).
+type DeploymentOutcome struct {
+	RepoName    string
+	Environment string
+	Workflow    string
+	Success     bool
+	At          time.Time
+}
+
+// MetricsRepository handles queries that assemble delivery metrics from the events table.
+type MetricsRepository struct {
+	db *sql.DB
+}
+
+// NewMetricsRepository creates a new MetricsRepository.
+func NewMetricsRepository(db *sql.DB) *MetricsRepository {
+	return &MetricsRepository{db: db}
+}
+
+// ListMergedPullRequests returns up to limit merged pull requests matching the filter,
+// newest first, each with the time of its first review by someone other than the author.
+// Reviews submitted after the merge are not counted as the first review.
+// Merge events stored before event_data was recorded (no PR number) are skipped.
+func (r *MetricsRepository) ListMergedPullRequests(filter model.EventFilter, limit int) ([]MergedPullRequest, error) {
+	where, args := buildEventWhere(filter)
+	where = appendCondition(where, "event_type = 'pull_request' AND action = 'merged' AND pr_number IS NOT NULL")
+	query := `SELECT m.repo_name, m.event_data, m.occurred_at,
+		(SELECT MIN(rv.occurred_at) FROM events rv
+			WHERE rv.event_type = 'pull_request_review'
+				AND rv.repo_name = m.repo_name AND rv.pr_number = m.pr_number
+				AND rv.sender_login <> COALESCE(JSON_UNQUOTE(JSON_EXTRACT(m.event_data, '$.pull_request_author')), '')
+				AND rv.occurred_at <= m.occurred_at
+		) AS first_review_at
+		FROM events m` + where + " ORDER BY m.received_at DESC LIMIT ?"
+	rows, err := r.db.Query(query, append(args, limit)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list merged pull requests: %w", err)
+	}
+	defer rows.Close()
+	var prs []MergedPullRequest
+	for rows.Next() {
+		var pr MergedPullRequest
+		var rawData string
+		if err := rows.Scan(&pr.RepoName, &rawData, &pr.MergedAt, &pr.FirstReviewAt); err != nil {
+			return nil, fmt.Errorf("failed to scan merged pull request: %w", err)
+		}
+		var data model.PullRequestData
+		if err := json.Unmarshal([]byte(rawData), &data); err != nil || data.CreatedAt == nil {
+			continue
+		}
+		pr.Number = data.Number
+		pr.CreatedAt = data.CreatedAt.UTC()
+		if data.MergedAt != nil {
+			pr.MergedAt = data.MergedAt.UTC()
+		}
+		if pr.FirstReviewAt != nil && pr.FirstReviewAt.After(pr.MergedAt) {
+			pr.FirstReviewAt = nil
+		}
+		pr.Additions = data.Additions
+		pr.Deletions = data.Deletions
+		prs = append(prs, pr)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate merged pull requests: %w", err)
+	}
+	return prs, nil
+}
+
+// workflowFailureConclusions are the workflow_run conclusions counted as failed deployments.
+// Other non-success conclusions (cancelled, skipped, neutral, ...) are ignored.
+var workflowFailureConclusions = map[string]bool{
+	"failure":         true,
+	"timed_out":       true,
+	"startup_failure": true,
+}
+
+// ListDeploymentOutcomes returns up to limit finished deployment attempts matching the
+// filter, oldest first: terminal deployment statuses (success, failure, error) and
+// completed workflow runs with a success or failure conclusion.
+func (r *MetricsRepository) ListDeploymentOutcomes(filter model.EventFilter, limit int) ([]DeploymentOutcome, error) {
+	where, args := buildEventWhere(filter)
+	where = appendCondition(where, "((event_type = 'deployment_status' AND action IN ('success', 'failure', 'error'))"+
+		" OR (event_type = 'workflow_run' AND action = 'completed'))")
+	query := "SELECT repo_name, event_type, action, event_data, occurred_at FROM events" + where +
+		" ORDER BY occurred_at ASC, id ASC LIMIT ?"
+	rows, err := r.db.Query(query, append(args, limit)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployment outcomes: %w", err)
+	}
+	defer rows.Close()
+	var outcomes []DeploymentOutcome
+	for rows.Next() {
+		var o DeploymentOutcome
+		var eventType, action string
+		var rawData sql.NullString
+		if err := rows.Scan(&o.RepoName, &eventType, &action, &rawData, &o.At); err != nil {
+			return nil, fmt.Errorf("failed to scan deployment outcome: %w", err)
+		}
+		if !rawData.Valid {
+			continue
+		}
+		if eventType == "deployment_status" {
+			var data model.DeploymentData
+			if err := json.Unmarshal([]byte(rawData.String), &data); err != nil {
+				continue
+			}
+			o.Environment = data.Environment
+			o.Success = action == "success"
+		} else {
+			var data model.WorkflowRunData
+			if err := json.Unmarshal([]byte(rawData.String), &data); err != nil {
+				continue
+			}
+			if data.Conclusion != "success" && !workflowFailureConclusions[data.Conclusion] {
+				continue
+			}
+			o.Workflow = data.Name
+			o.Success = data.Conclusion == "success"
+		}
+		outcomes = append(outcomes, o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate deployment outcomes: %w", err)
+	}
+	return outcomes, nil
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+)
+
+func TestListMergedPullRequestsFirstReview(t *testing.T) {
+	created := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	merged := created.Add(4 * time.Hour)
+	reviewed := created.Add(time.Hour)
+	data := func(mergedAt *time.Time) string {
+		s := `{"pull_request_number":7,"pull_request_author":"alice","created_at":"` + created.Format(time.RFC3339) + `"`
+		if mergedAt != nil {
+			s += `,"merged_at":"` + mergedAt.Format(time.RFC3339) + `"`
+		}
+		return s + "}"
+	}
+	earlierMerge := created.Add(30 * time.Minute)
+
+	tests := []struct {
+		name                  string
+		data                  string
+		firstReviewAt         interface{}
+		expectedMergedAt      time.Time
+		expectedFirstReviewAt *time.Time
+	}{
+		{
+			name:                  "Review before the merge is the first review",
+			data:                  data(nil),
+			firstReviewAt:         reviewed,
+			expectedMergedAt:      merged,
+			expectedFirstReviewAt: &reviewed,
+		},
+		{
+			name:                  "No review before the merge",
+			data:                  data(nil),
+			firstReviewAt:         nil,
+			expectedMergedAt:      merged,
+			expectedFirstReviewAt: nil,
+		},
+		{
+			name:                  "Review after the merged_at in event_data is dropped",
+			data:                  data(&earlierMerge),
+			firstReviewAt:         reviewed,
+			expectedMergedAt:      earlierMerge,
+			expectedFirstReviewAt: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer db.Close()
+			rows := sqlmock.NewRows([]string{"repo_name", "event_data", "occurred_at", "first_review_at"}).
+				AddRow("acme/api", tt.data, merged, tt.firstReviewAt)
+			mock.ExpectQuery(`AND rv\.occurred_at <= m\.occurred_at`).WillReturnRows(rows)
+
+			prs, err := NewMetricsRepository(db).ListMergedPullRequests(model.EventFilter{}, 10)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(prs) != 1 {
+				t.Fatalf("expected 1 pull request, got %d", len(prs))
+			}
+			if !prs[0].MergedAt.Equal(tt.expectedMergedAt) {
+				t.Errorf("expected merged at %v, got %v", tt.expectedMergedAt, prs[0].MergedAt)
+			}
+			got := prs[0].FirstReviewAt
+			if (got == nil) != (tt.expectedFirstReviewAt == nil) || (got != nil && !got.Equal(*tt.expectedFirstReviewAt)) {
+				t.Errorf("expected first review at %v, got %v", tt.expectedFirstReviewAt, got)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+)
+
+// OutboxRepository handles database operations for the event_outbox table.
+type OutboxRepository struct {
+	db *sql.DB
+}
+
+// NewOutboxRepository creates a new OutboxRepository.
+func NewOutboxRepository(db *sql.DB) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+// Append inserts a message with the given JSON payload.
+func (r *OutboxRepository) Append(ctx context.Context, payload []byte) error {
+	if _, err := r.db.ExecContext(ctx, "INSERT INTO event_outbox (payload) VALUES (?)", payload); err != nil {
+		return fmt.Errorf("failed to append outbox message: %w", err)
+	}
+	return nil
+}
+
+// LatestID returns the ID of the newest message, or 0 if the outbox is empty.
+func (r *OutboxRepository) LatestID(ctx context.Context) (int64, error) {
+	var id int64
+	if err := r.db.QueryRowContext(ctx, "SELECT COALESCE(MAX(id), 0) FROM event_outbox").Scan(&id); err != nil {
+		return 0, fmt.Errorf("failed to get latest outbox id: %w", err)
+	}
+	return id, nil
+}
+
+// ListAfter returns up to limit messages with an ID greater than afterID, in ID order.
+func (r *OutboxRepository) ListAfter(ctx context.Context, afterID int64, limit int) ([]model.OutboxMessage, error) {
+	rows, err := r.db.QueryContext(ctx,
+		"SELECT id, payload, created_at FROM event_outbox WHERE id > ? ORDER BY id ASC LIMIT ?", afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list outbox messages: %w", err)
+	}
+	defer rows.Close()
+	var messages []model.OutboxMessage
+	for rows.Next() {
+		var m model.OutboxMessage
+		if err := rows.Scan(&m.ID, &m.Payload, &m.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan outbox message: %w", err)
+		}
+		messages = append(messages, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate outbox messages: %w", err)
+	}
+	return messages, nil
+}
+
+// DeleteBefore removes the messages created before the given time and returns how many
+// were removed.
+func (r *OutboxRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM event_outbox WHERE created_at < ?", before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete outbox messages: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return n, nil
+}
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+)
+
+// OpenedIssue is an issue opened event assembled from stored events.
+type OpenedIssue struct {
+	RepoName          string
+	Number            int
+	Title             *string
+	HTMLURL           string
+	Author            string
+	AuthorAssociation string
+	OpenedAt          time.Time
+}
+
+// IssueActivity is a comment on, or the closing of, an issue. AuthorAssociation is the
+// commenter's association for comments and is empty for closes.
+type IssueActivity struct {
+	RepoName          string
+	Number            int
+	Closed            bool
+	SenderLogin       string
+	AuthorAssociation string
+	At                time.Time
+}
+
+// SLARepository handles queries that assemble issue response timelines from the events table.
+type SLARepository struct {
+	db *sql.DB
+}
+
+// NewSLARepository creates a new SLARepository.
+func NewSLARepository(db *sql.DB) *SLARepository {
+	return &SLARepository{db: db}
+}
+
+// ListOpenedIssues returns up to limit issues opened within the filter, newest first.
+// Events stored before event_data was recorded (no issue number) are skipped.
+func (r *SLARepository) ListOpenedIssues(filter model.EventFilter, limit int) ([]OpenedIssue, error) {
+	where, args := buildEventWhere(filter)
+	where = appendCondition(where, "event_type = 'issues' AND action = 'opened' AND issue_number IS NOT NULL")
+	query := "SELECT repo_name, title, html_url, event_data, occurred_at FROM events" + where +
+		" ORDER BY received_at DESC, id DESC LIMIT ?"
+	rows, err := r.db.Query(query, append(args, limit)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list opened issues: %w", err)
+	}
+	defer rows.Close()
+	var issues []OpenedIssue
+	for rows.Next() {
+		var issue OpenedIssue
+		var rawData string
+		if err := rows.Scan(&issue.RepoName, &issue.Title, &issue.HTMLURL, &rawData, &issue.OpenedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan opened issue: %w", err)
+		}
+		var data model.IssueData
+		if err := json.Unmarshal([]byte(rawData), &data); err != nil {
+			continue
+		}
+		issue.Number = data.Number
+		issue.Author = data.Author
+		issue.AuthorAssociation = data.AuthorAssociation
+		issues = append(issues, issue)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate opened issues: %w", err)
+	}
+	return issues, nil
+}
+
+// ListIssueActivity returns up to limit comments on and closes of the given issues (issue
+// numbers keyed by repository) that happened at or after since, oldest first. Comments on
+// pull requests are skipped.
+func (r *SLARepository) ListIssueActivity(issues map[string][]int, since time.Time, limit int) ([]IssueActivity, error) {
+	if len(issues) == 0 {
+		return nil, nil
+	}
+	var scopes []string
+	var args []interface{}
+	for _, repo := range sortedRepos(issues) {
+		numbers := issues[repo]
+		scopes = append(scopes, "(repo_name = ? AND issue_number IN ("+placeholders(len(numbers))+"))")
+		args = append(args, repo)
+		for _, n := range numbers {
+			args = append(args, n)
+		}
+	}
+	args = append(args, since)
+	query := "SELECT repo_name, event_type, sender_login, event_data, occurred_at FROM events" +
+		" WHERE (" + strings.Join(scopes, " OR ") + ") AND occurred_at >= ?" +
+		" AND ((event_type = 'issue_comment' AND action = 'created') OR (event_type = 'issues' AND action = 'closed'))" +
+		" ORDER BY occurred_at ASC, id ASC LIMIT ?"
+	rows, err := r.db.Query(query, append(args, limit)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list issue activity: %w", err)
+	}
+	defer rows.Close()
+	var activity []IssueActivity
+	for rows.Next() {
+		var a IssueActivity
+		var eventType, rawData string
+		if err := rows.Scan(&a.RepoName, &eventType, &a.SenderLogin, &rawData, &a.At); err != nil {
+			return nil, fmt.Errorf("failed to scan issue activity: %w", err)
+		}
+		var data model.IssueData
+		if err := json.Unmarshal([]byte(rawData), &data); err != nil || data.IsPullRequest {
+			continue
+		}
+		a.Number = data.Number
+		a.Closed = eventType == "issues"
+		if !a.Closed {
+			a.AuthorAssociation = data.AuthorAssociation
+		}
+		activity = append(activity, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate issue activity: %w", err)
+	}
+	return activity, nil
+}
+
+func sortedRepos(issues map[string][]int) []string {
+	repos := make([]string, 0, len(issues))
+	for repo := range issues {
+		repos = append(repos, repo)
+	}
+	sort.Strings(repos)
+	return repos
+}
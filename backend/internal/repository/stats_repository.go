@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+)
+
+// StatsGroup identifies a column events can be grouped by.
+type StatsGroup string
+
+const (
+	GroupByRepo      StatsGroup = "repo_name"
+	GroupByEventType StatsGroup = "event_type"
+	GroupByAction    StatsGroup = "action"
+	GroupBySender    StatsGroup = "sender_login"
+)
+
+// SlotCount is the number of events in the slot-th interval after a query's origin.
+type SlotCount struct {
+	Slot  int
+	Count int
+}
+
+// StatsRepository handles aggregate queries over the events table.
+type StatsRepository struct {
+	db *sql.DB
+}
+
+// NewStatsRepository creates a new StatsRepository.
+func NewStatsRepository(db *sql.DB) *StatsRepository {
+	return &StatsRepository{db: db}
+}
+
+// CountByGroup returns the number of events matching the filter for each value of group,
+// largest first, limited to the top limit values.
+func (r *StatsRepository) CountByGroup(filter model.EventFilter, group StatsGroup, limit int) ([]model.StatCount, error) {
+	switch group {
+	case GroupByRepo, GroupByEventType, GroupByAction, GroupBySender:
+	default:
+		return nil, fmt.Errorf("unsupported stats group: %s", group)
+	}
+	where, args := buildEventWhere(filter)
+	column := string(group)
+	query := "SELECT " + column + ", COUNT(*) AS cnt FROM events" + where +
+		" GROUP BY " + column + " ORDER BY cnt DESC, " + column + " ASC LIMIT ?"
+	rows, err := r.db.Query(query, append(args, limit)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count events by %s: %w", column, err)
+	}
+	defer rows.Close()
+	counts := []model.StatCount{}
+	for rows.Next() {
+		var c model.StatCount
+		if err := rows.Scan(&c.Key, &c.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan stats row: %w", err)
+		}
+		counts = append(counts, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate stats rows: %w", err)
+	}
+	return counts, nil
+}
+
+// CountByInterval returns the number of events matching the filter in each fixed-size
+// interval after origin (slot 0 starts at origin). Empty slots are omitted.
+// The computation uses plain DATETIME arithmetic so it does not depend on the
+// MySQL time zone tables or the session time zone.
+func (r *StatsRepository) CountByInterval(filter model.EventFilter, origin time.Time, interval time.Duration) ([]SlotCount, error) {
+	where, args := buildEventWhere(filter)
+	query := "SELECT TIMESTAMPDIFF(SECOND, ?, received_at) DIV ? AS slot, COUNT(*) FROM events" + where +
+		" GROUP BY slot ORDER BY slot"
+	queryArgs := append([]interface{}{origin, int64(interval / time.Second)}, args...)
+	rows, err := r.db.Query(query, queryArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count events by interval: %w", err)
+	}
+	defer rows.Close()
+	var slots []SlotCount
+	for rows.Next() {
+		var s SlotCount
+		if err := rows.Scan(&s.Slot, &s.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan interval row: %w", err)
+		}
+		slots = append(slots, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate interval rows: %w", err)
+	}
+	return slots, nil
+}
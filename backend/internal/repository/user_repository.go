@@ -95,3 +95,33 @@ func (r *UserRepository) FindByID(id int64) (*model.User, error) {
 	u.AccessToken = token
 	return &u, nil
 }
+
+// ListProfiles returns the public profiles of the users with the given IDs, ordered by
+// login. Unknown IDs are skipped.
+func (r *UserRepository) ListProfiles(ids []int64) ([]model.UserResponse, error) {
+	profiles := []model.UserResponse{}
+	if len(ids) == 0 {
+		return profiles, nil
+	}
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	query := "SELECT id, login, display_name, avatar_url FROM users WHERE id IN (" + placeholders(len(ids)) + ") ORDER BY login"
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user profiles: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var u model.UserResponse
+		if err := rows.Scan(&u.ID, &u.Login, &u.DisplayName, &u.AvatarURL); err != nil {
+			return nil, fmt.Errorf("failed to scan user profile: %w", err)
+		}
+		profiles = append(profiles, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate user profiles: %w", err)
+	}
+	return profiles, nil
+}
@@ -0,0 +1,169 @@
+package search
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+)
+
+// MaxFilterValues limits how many values a single multi-valued filter field may carry.
+const MaxFilterValues = 20
+
+// FilterList holds the values of one multi-valued filter field. Name is the field's name in
+// the API the values came from, used in error messages.
+type FilterList struct {
+	Name   string
+	Values []string
+}
+
+// FilterInput is an event filter as received by one of the APIs (query parameters,
+// GraphQL, gRPC), with times already decoded.
+type FilterInput struct {
+	EventTypes FilterList
+	Repos      FilterList
+	Owners     FilterList
+	Senders    FilterList
+	Actions    FilterList
+	Since      *time.Time
+	Until      *time.Time
+	// Q is a search string whose qualifiers are merged into the filter.
+	Q string
+}
+
+// BuildFilter validates in and compiles it into a model.EventFilter. It is shared by every
+// API that accepts event filters so they apply the same rules: times are converted to UTC;
+// q is parsed (returning ParseErrors on failure) and combined with the other fields with
+// AND; list values are then trimmed, with empty and duplicate values dropped, and limited
+// to MaxFilterValues; and since must be earlier than until.
+func BuildFilter(in FilterInput) (model.EventFilter, error) {
+	filter := model.EventFilter{
+		EventTypes: trimValues(in.EventTypes.Values),
+		Repos:      trimValues(in.Repos.Values),
+		Owners:     trimValues(in.Owners.Values),
+		Senders:    trimValues(in.Senders.Values),
+		Actions:    trimValues(in.Actions.Values),
+	}
+	if in.Since != nil {
+		since := in.Since.UTC()
+		filter.Since = &since
+	}
+	if in.Until != nil {
+		until := in.Until.UTC()
+		filter.Until = &until
+	}
+	if in.Q != "" {
+		query, err := Parse(in.Q)
+		if err != nil {
+			return filter, err
+		}
+		var q model.EventFilter
+		query.Apply(&q)
+		mergeFilter(&filter, q)
+	}
+	lists := []struct {
+		name string
+		dst  *[]string
+	}{
+		{in.EventTypes.Name, &filter.EventTypes},
+		{in.Repos.Name, &filter.Repos},
+		{in.Owners.Name, &filter.Owners},
+		{in.Senders.Name, &filter.Senders},
+		{in.Actions.Name, &filter.Actions},
+		{in.EventTypes.Name, &filter.ExcludeEventTypes},
+		{in.Repos.Name, &filter.ExcludeRepos},
+		{in.Owners.Name, &filter.ExcludeOwners},
+		{in.Senders.Name, &filter.ExcludeSenders},
+		{in.Actions.Name, &filter.ExcludeActions},
+	}
+	for _, l := range lists {
+		values, err := cleanFilterList(FilterList{Name: l.name, Values: *l.dst})
+		if err != nil {
+			return filter, err
+		}
+		*l.dst = values
+	}
+	if filter.Since != nil && filter.Until != nil && !filter.Since.Before(*filter.Until) {
+		return filter, errors.New("since must be earlier than until")
+	}
+	return filter, nil
+}
+
+// mergeFilter narrows filter, built from the list fields and times of a FilterInput, to
+// the events that also match q, built from its search string.
+func mergeFilter(filter *model.EventFilter, q model.EventFilter) {
+	intersectList(&filter.EventTypes, &filter.ExcludeEventTypes, q.EventTypes)
+	intersectList(&filter.Repos, &filter.ExcludeRepos, q.Repos)
+	intersectList(&filter.Owners, &filter.ExcludeOwners, q.Owners)
+	intersectList(&filter.Senders, &filter.ExcludeSenders, q.Senders)
+	intersectList(&filter.Actions, &filter.ExcludeActions, q.Actions)
+	filter.ExcludeEventTypes = append(filter.ExcludeEventTypes, q.ExcludeEventTypes...)
+	filter.ExcludeRepos = append(filter.ExcludeRepos, q.ExcludeRepos...)
+	filter.ExcludeOwners = append(filter.ExcludeOwners, q.ExcludeOwners...)
+	filter.ExcludeSenders = append(filter.ExcludeSenders, q.ExcludeSenders...)
+	filter.ExcludeActions = append(filter.ExcludeActions, q.ExcludeActions...)
+	filter.SearchTerms = append(filter.SearchTerms, q.SearchTerms...)
+	filter.States = append(filter.States, q.States...)
+	filter.ExcludeStates = append(filter.ExcludeStates, q.ExcludeStates...)
+	if q.Since != nil && (filter.Since == nil || q.Since.After(*filter.Since)) {
+		filter.Since = q.Since
+	}
+	if q.Until != nil && (filter.Until == nil || q.Until.Before(*filter.Until)) {
+		filter.Until = q.Until
+	}
+}
+
+// intersectList restricts the values allowed by *include to those also in values, compared
+// case-insensitively like the database collation. An empty list allows any value. When no
+// value is in both, the values are also added to *exclude, so that nothing matches.
+func intersectList(include, exclude *[]string, values []string) {
+	if len(values) == 0 {
+		return
+	}
+	if len(*include) == 0 {
+		*include = values
+		return
+	}
+	var both []string
+	for _, v := range *include {
+		if slices.ContainsFunc(values, func(q string) bool { return strings.EqualFold(q, v) }) {
+			both = append(both, v)
+		}
+	}
+	if len(both) == 0 {
+		*exclude = append(*exclude, *include...)
+		return
+	}
+	*include = both
+}
+
+// trimValues returns the values with surrounding spaces removed and empty ones dropped.
+func trimValues(values []string) []string {
+	var trimmed []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			trimmed = append(trimmed, v)
+		}
+	}
+	return trimmed
+}
+
+func cleanFilterList(l FilterList) ([]string, error) {
+	seen := make(map[string]bool)
+	var values []string
+	for _, v := range l.Values {
+		v = strings.TrimSpace(v)
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		values = append(values, v)
+	}
+	if len(values) > MaxFilterValues {
+		return nil, fmt.Errorf("too many values for %s (max %d)", l.Name, MaxFilterValues)
+	}
+	return values, nil
+}
@@ -0,0 +1,117 @@
+package search
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+)
+
+func TestBuildFilter(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	since := time.Date(2026, 1, 1, 9, 0, 0, 0, tokyo)
+	until := time.Date(2026, 2, 1, 9, 0, 0, 0, tokyo)
+	var tooMany []string
+	for i := range MaxFilterValues + 1 {
+		tooMany = append(tooMany, fmt.Sprintf("user%d", i))
+	}
+
+	tests := []struct {
+		name          string
+		input         FilterInput
+		expected      model.EventFilter
+		expectedError string
+	}{
+		{
+			name:     "Empty input is an empty filter",
+			input:    FilterInput{},
+			expected: model.EventFilter{},
+		},
+		{
+			name: "List values are trimmed and deduplicated",
+			input: FilterInput{
+				Repos:   FilterList{Name: "repo", Values: []string{" acme/api", "acme/api", "", "acme/web"}},
+				Senders: FilterList{Name: "sender", Values: []string{"alice"}},
+			},
+			expected: model.EventFilter{Repos: []string{"acme/api", "acme/web"}, Senders: []string{"alice"}},
+		},
+		{
+			name:  "Times are converted to UTC",
+			input: FilterInput{Since: &since, Until: &until},
+			expected: model.EventFilter{
+				Since: ptr(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)),
+				Until: ptr(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+		},
+		{
+			name:  "Search string qualifiers are merged",
+			input: FilterInput{Repos: FilterList{Name: "repo", Values: []string{"acme/api"}}, Q: "author:alice cache"},
+			expected: model.EventFilter{
+				Repos:       []string{"acme/api"},
+				Senders:     []string{"alice"},
+				SearchTerms: []string{"cache"},
+			},
+		},
+		{
+			name: "Search string qualifiers narrow the values of the same field",
+			input: FilterInput{
+				Repos: FilterList{Name: "repo", Values: []string{"acme/api", "acme/web"}},
+				Q:     "repo:ACME/WEB repo:acme/cli",
+			},
+			expected: model.EventFilter{Repos: []string{"acme/web"}},
+		},
+		{
+			name: "Search string qualifiers without common values match nothing",
+			input: FilterInput{
+				Repos: FilterList{Name: "repo", Values: []string{"acme/api"}},
+				Q:     "repo:acme/web -repo:acme/api",
+			},
+			expected: model.EventFilter{Repos: []string{"acme/api"}, ExcludeRepos: []string{"acme/api"}},
+		},
+		{
+			name:          "Search string qualifiers count towards the value limit",
+			input:         FilterInput{Senders: FilterList{Name: "senders"}, Q: "-author:" + strings.Join(tooMany, " -author:")},
+			expectedError: "too many values for senders",
+		},
+		{
+			name:          "Too many values are rejected with the API's field name",
+			input:         FilterInput{Senders: FilterList{Name: "senders", Values: tooMany}},
+			expectedError: "too many values for senders",
+		},
+		{
+			name:          "Since must be earlier than until",
+			input:         FilterInput{Since: &until, Until: &since},
+			expectedError: "since must be earlier than until",
+		},
+		{
+			name:          "Invalid search string",
+			input:         FilterInput{Q: "autor:alice"},
+			expectedError: "unknown qualifier",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := BuildFilter(tt.input)
+			if tt.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedError) {
+					t.Fatalf("expected error containing %q, got %v", tt.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(filter, tt.expected) {
+				t.Errorf("expected filter %+v, got %+v", tt.expected, filter)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
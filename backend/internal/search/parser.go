@@ -0,0 +1,333 @@
+package search
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// maxNodes limits how many terms and qualifiers a single query may contain.
+const maxNodes = 30
+
+// qualifierFields maps each accepted qualifier key (including aliases) to its field.
+var qualifierFields = map[string]Field{
+	"repo":   FieldRepo,
+	"org":    FieldOwner,
+	"owner":  FieldOwner,
+	"user":   FieldOwner,
+	"type":   FieldType,
+	"author": FieldAuthor,
+	"sender": FieldAuthor,
+	"action": FieldAction,
+}
+
+// dateQualifiers are the qualifier keys accepted for time ranges.
+var dateQualifiers = map[string]bool{
+	"created":  true,
+	"received": true,
+}
+
+var typeAliases = map[string]string{
+	"pr":           "pull_request",
+	"pull_request": "pull_request",
+	"issue":        "issues",
+	"issues":       "issues",
+}
+
+var (
+	qualifierKeyPattern = regexp.MustCompile(`^[A-Za-z]+$`)
+	identifierPattern   = regexp.MustCompile(`^[a-z_]+$`)
+)
+
+// ParseError describes a problem at a specific byte offset of the search string.
+type ParseError struct {
+	Position int    `json:"position"`
+	Token    string `json:"token"`
+	Message  string `json:"message"`
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%s at position %d (%q)", e.Message, e.Position, e.Token)
+}
+
+// ParseErrors is returned by Parse when the search string contains one or more errors.
+type ParseErrors []*ParseError
+
+func (e ParseErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, pe := range e {
+		msgs[i] = pe.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// token is a whitespace-delimited element of the search string.
+type token struct {
+	pos     int
+	raw     string
+	negated bool
+	key     string // qualifier key (lower-cased), empty for free text
+	value   string // unquoted value
+	quoted  bool
+}
+
+// Parse parses a search string into a Query.
+// Every problem found is reported in the returned ParseErrors, not only the first one.
+func Parse(input string) (*Query, error) {
+	tokens, errs := tokenize(input)
+	q := &Query{}
+	for _, tok := range tokens {
+		node, err := parseToken(tok)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		q.Nodes = append(q.Nodes, node)
+	}
+	if len(q.Nodes) > maxNodes {
+		errs = append(errs, &ParseError{
+			Position: q.Nodes[maxNodes].Pos(),
+			Token:    tokens[maxNodes].raw,
+			Message:  fmt.Sprintf("query has too many terms (max %d)", maxNodes),
+		})
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return q, nil
+}
+
+func tokenize(input string) ([]token, ParseErrors) {
+	var tokens []token
+	var errs ParseErrors
+	i := 0
+	for i < len(input) {
+		if isSpace(input[i]) {
+			i++
+			continue
+		}
+		start := i
+		tok := token{pos: start}
+		if input[i] == '-' && i+1 < len(input) && !isSpace(input[i+1]) {
+			tok.negated = true
+			i++
+		}
+		if input[i] == '"' {
+			end := strings.IndexByte(input[i+1:], '"')
+			if end < 0 {
+				errs = append(errs, &ParseError{Position: start, Token: input[start:], Message: "unterminated quoted phrase"})
+				break
+			}
+			tok.value = input[i+1 : i+1+end]
+			tok.quoted = true
+			i += end + 2
+			tok.raw = input[start:i]
+			tokens = append(tokens, tok)
+			continue
+		}
+		wordStart := i
+		for i < len(input) && !isSpace(input[i]) {
+			if input[i] == ':' && i+1 < len(input) && input[i+1] == '"' {
+				end := strings.IndexByte(input[i+2:], '"')
+				if end < 0 {
+					errs = append(errs, &ParseError{Position: start, Token: input[start:], Message: "unterminated quoted value"})
+					return tokens, errs
+				}
+				tok.key = input[wordStart:i]
+				tok.value = input[i+2 : i+2+end]
+				tok.quoted = true
+				i += end + 3
+				break
+			}
+			i++
+		}
+		tok.raw = input[start:i]
+		if tok.key == "" {
+			word := input[wordStart:i]
+			if k, v, ok := strings.Cut(word, ":"); ok && qualifierKeyPattern.MatchString(k) {
+				tok.key, tok.value = k, v
+			} else {
+				tok.value = word
+			}
+		}
+		tok.key = strings.ToLower(tok.key)
+		tokens = append(tokens, tok)
+	}
+	return tokens, errs
+}
+
+func parseToken(tok token) (Node, *ParseError) {
+	fail := func(format string, args ...interface{}) *ParseError {
+		return &ParseError{Position: tok.pos, Token: tok.raw, Message: fmt.Sprintf(format, args...)}
+	}
+	if tok.key != "" {
+		if _, known := qualifierFields[tok.key]; !known && tok.key != "is" && !dateQualifiers[tok.key] {
+			if suggestion := suggestQualifier(tok.key); suggestion != "" {
+				return nil, fail("unknown qualifier %q; did you mean %q?", tok.key, suggestion)
+			}
+			// Not a qualifier after all (e.g. "error:" in free text): treat it as a search term.
+			tok.key, tok.value = "", strings.TrimPrefix(tok.raw, "-")
+		}
+	}
+	if tok.key == "" {
+		if tok.negated {
+			return nil, fail("negation is only supported for qualifiers")
+		}
+		return &TextNode{Position: tok.pos, Value: tok.value, Phrase: tok.quoted}, nil
+	}
+	if tok.value == "" {
+		return nil, fail("missing value for qualifier %q", tok.key)
+	}
+	switch {
+	case tok.key == "is":
+		state := strings.ToLower(tok.value)
+		if _, ok := isStates[state]; !ok {
+			return nil, fail("unknown state %q for is: (expected one of %s)", tok.value, strings.Join(sortedKeys(isStates), ", "))
+		}
+		return &IsNode{Position: tok.pos, State: state, Negated: tok.negated}, nil
+	case dateQualifiers[tok.key]:
+		if tok.negated {
+			return nil, fail("negation is not supported for %s:", tok.key)
+		}
+		from, to, err := parseDateRange(tok.value)
+		if err != nil {
+			return nil, fail("%s", err.Error())
+		}
+		return &DateNode{Position: tok.pos, From: from, To: to}, nil
+	}
+	field := qualifierFields[tok.key]
+	value := tok.value
+	switch field {
+	case FieldRepo:
+		owner, name, ok := strings.Cut(value, "/")
+		if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+			return nil, fail("repo must be in owner/name form")
+		}
+	case FieldType:
+		value = strings.ToLower(value)
+		if alias, ok := typeAliases[value]; ok {
+			value = alias
+		} else if !identifierPattern.MatchString(value) {
+			return nil, fail("invalid event type %q", tok.value)
+		}
+	}
+	return &FieldNode{Position: tok.pos, Field: field, Value: value, Negated: tok.negated}, nil
+}
+
+// parseDateRange parses a created: value: DATE, >DATE, >=DATE, <DATE, <=DATE or FROM..TO
+// (either side of ".." may be "*"). DATE is YYYY-MM-DD or an RFC3339 timestamp.
+// The returned range is half-open: [from, to).
+func parseDateRange(value string) (*time.Time, *time.Time, error) {
+	if lo, hi, ok := strings.Cut(value, ".."); ok {
+		var from, to *time.Time
+		if lo != "*" {
+			start, _, err := parseDate(lo)
+			if err != nil {
+				return nil, nil, err
+			}
+			from = &start
+		}
+		if hi != "*" {
+			_, end, err := parseDate(hi)
+			if err != nil {
+				return nil, nil, err
+			}
+			to = &end
+		}
+		if from != nil && to != nil && !from.Before(*to) {
+			return nil, nil, fmt.Errorf("date range %q is empty", value)
+		}
+		return from, to, nil
+	}
+	for _, op := range []string{">=", "<=", ">", "<"} {
+		if !strings.HasPrefix(value, op) {
+			continue
+		}
+		start, end, err := parseDate(strings.TrimPrefix(value, op))
+		if err != nil {
+			return nil, nil, err
+		}
+		switch op {
+		case ">=":
+			return &start, nil, nil
+		case ">":
+			return &end, nil, nil
+		case "<=":
+			return nil, &end, nil
+		default:
+			return nil, &start, nil
+		}
+	}
+	start, end, err := parseDate(value)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &start, &end, nil
+}
+
+// parseDate returns the first instant of the given date or timestamp and the first instant after it.
+func parseDate(s string) (time.Time, time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t, t.AddDate(0, 0, 1), nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		t = t.UTC()
+		return t, t.Add(time.Second), nil
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("invalid date %q: expected YYYY-MM-DD or RFC3339 timestamp", s)
+}
+
+// suggestQualifier returns the known qualifier closest to key, or "" if none is close enough.
+func suggestQualifier(key string) string {
+	candidates := []string{"is"}
+	for k := range qualifierFields {
+		candidates = append(candidates, k)
+	}
+	for k := range dateQualifiers {
+		candidates = append(candidates, k)
+	}
+	sort.Strings(candidates)
+	best, bestDist := "", 3
+	for _, c := range candidates {
+		if d := editDistance(key, c); d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+	return best
+}
+
+func editDistance(a string, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func isSpace(b byte) bool {
+	return unicode.IsSpace(rune(b))
+}
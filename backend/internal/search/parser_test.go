@@ -0,0 +1,158 @@
+package search
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+)
+
+func date(s string) *time.Time {
+	t, _ := time.Parse(time.DateOnly, s)
+	return &t
+}
+
+func TestParseAndApply(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected model.EventFilter
+	}{
+		{
+			name:  "Qualifiers and phrases are compiled into the filter",
+			input: `repo:acme/api type:pr author:alice is:merged created:>2026-01-01 "flaky test" cache`,
+			expected: model.EventFilter{
+				Repos:       []string{"acme/api"},
+				EventTypes:  []string{"pull_request"},
+				Senders:     []string{"alice"},
+				Since:       date("2026-01-02"),
+				SearchTerms: []string{"flaky test", "cache"},
+				States:      []model.EventState{{EventType: "pull_request", Action: "merged"}},
+			},
+		},
+		{
+			name:  "Negated qualifiers become exclusions",
+			input: `-repo:acme/legacy -author:dependabot -is:issue org:acme`,
+			expected: model.EventFilter{
+				Owners:         []string{"acme"},
+				ExcludeRepos:   []string{"acme/legacy"},
+				ExcludeSenders: []string{"dependabot"},
+				ExcludeStates:  []model.EventState{{EventType: "issues"}},
+			},
+		},
+		{
+			name:  "Negated is:merged excludes only merged pull requests",
+			input: `-is:merged`,
+			expected: model.EventFilter{
+				ExcludeStates: []model.EventState{{EventType: "pull_request", Action: "merged"}},
+			},
+		},
+		{
+			name:  "Several is: states must all hold",
+			input: `is:merged is:opened is:open`,
+			expected: model.EventFilter{
+				States: []model.EventState{{EventType: "pull_request", Action: "merged"}, {Action: "opened"}},
+			},
+		},
+		{
+			name:  "Date ranges are intersected",
+			input: `created:2026-01-01..2026-01-31 created:<=2026-01-15 received:>=2026-01-03`,
+			expected: model.EventFilter{
+				Since: date("2026-01-03"),
+				Until: date("2026-01-16"),
+			},
+		},
+		{
+			name:  "Quoted qualifier values and unrelated colons",
+			input: `author:"alice" error:timeout`,
+			expected: model.EventFilter{
+				Senders:     []string{"alice"},
+				SearchTerms: []string{"error:timeout"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var filter model.EventFilter
+			q.Apply(&filter)
+			if !reflect.DeepEqual(filter, tt.expected) {
+				t.Errorf("expected filter %+v, got %+v", tt.expected, filter)
+			}
+		})
+	}
+}
+
+func TestIsStatesMatchEvents(t *testing.T) {
+	mergedPR := &model.Event{EventType: "pull_request", Action: "merged"}
+	closedPR := &model.Event{EventType: "pull_request", Action: "closed"}
+	mergedOther := &model.Event{EventType: "merge_group", Action: "merged"}
+	openedIssue := &model.Event{EventType: "issues", Action: "opened"}
+	tests := []struct {
+		name     string
+		input    string
+		expected []bool // for mergedPR, closedPR, mergedOther, openedIssue
+	}{
+		{name: "is:merged", input: "is:merged", expected: []bool{true, false, false, false}},
+		{name: "-is:merged keeps other pull requests and other merged events", input: "-is:merged", expected: []bool{false, true, true, true}},
+		{name: "Two states are combined with AND", input: "is:merged is:opened", expected: []bool{false, false, false, false}},
+		{name: "State and negated state", input: "is:pr -is:merged", expected: []bool{false, true, false, false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var filter model.EventFilter
+			q.Apply(&filter)
+			for i, e := range []*model.Event{mergedPR, closedPR, mergedOther, openedIssue} {
+				if got := filter.Matches(e); got != tt.expected[i] {
+					t.Errorf("expected Matches(%s %s) to be %v, got %v", e.EventType, e.Action, tt.expected[i], got)
+				}
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name             string
+		input            string
+		expectedPosition int
+		expectedMessage  string
+	}{
+		{name: "Unknown qualifier with suggestion", input: "autor:alice", expectedPosition: 0, expectedMessage: `did you mean "author"?`},
+		{name: "Invalid repo", input: "cache repo:acme", expectedPosition: 6, expectedMessage: "owner/name"},
+		{name: "Unknown state", input: "is:draft", expectedPosition: 0, expectedMessage: "unknown state"},
+		{name: "Invalid date", input: "created:>yesterday", expectedPosition: 0, expectedMessage: "invalid date"},
+		{name: "Empty date range", input: "created:2026-02-01..2026-01-01", expectedPosition: 0, expectedMessage: "empty"},
+		{name: "Unterminated phrase", input: `bug "flaky test`, expectedPosition: 4, expectedMessage: "unterminated"},
+		{name: "Negated text", input: "-cache", expectedPosition: 0, expectedMessage: "negation"},
+		{name: "Missing value", input: "author:", expectedPosition: 0, expectedMessage: "missing value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.input)
+			var parseErrs ParseErrors
+			if !errors.As(err, &parseErrs) || len(parseErrs) == 0 {
+				t.Fatalf("expected ParseErrors, got %v", err)
+			}
+			if parseErrs[0].Position != tt.expectedPosition {
+				t.Errorf("expected position %d, got %d", tt.expectedPosition, parseErrs[0].Position)
+			}
+			if !strings.Contains(parseErrs[0].Message, tt.expectedMessage) {
+				t.Errorf("expected message containing %q, got %q", tt.expectedMessage, parseErrs[0].Message)
+			}
+		})
+	}
+}
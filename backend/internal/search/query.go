@@ -0,0 +1,135 @@
+// Package search parses GitHub-style search strings such as
+// `repo:acme/api type:pull_request author:alice is:merged created:>2026-01-01 "flaky test"`
+// into a typed AST and compiles it into an event filter.
+package search
+
+import (
+	"slices"
+	"time"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+)
+
+// Query is the parsed form of a search string. Nodes are combined with AND.
+type Query struct {
+	Nodes []Node
+}
+
+// Node is a single element of a search query.
+type Node interface {
+	// Pos returns the byte offset of the node in the original search string.
+	Pos() int
+}
+
+// Field identifies the event column a qualifier restricts.
+type Field string
+
+const (
+	FieldRepo   Field = "repo"
+	FieldOwner  Field = "owner"
+	FieldType   Field = "type"
+	FieldAuthor Field = "author"
+	FieldAction Field = "action"
+)
+
+// TextNode is a free-text term or a quoted phrase matched against title and body.
+type TextNode struct {
+	Position int
+	Value    string
+	Phrase   bool
+}
+
+// FieldNode is a `key:value` qualifier restricting a single column, e.g. repo:acme/api.
+type FieldNode struct {
+	Position int
+	Field    Field
+	Value    string
+	Negated  bool
+}
+
+// IsNode is an `is:` qualifier describing the state of an event, e.g. is:merged.
+type IsNode struct {
+	Position int
+	State    string
+	Negated  bool
+}
+
+// DateNode is a `created:` qualifier restricting the event time to [From, To).
+// A nil bound means the range is open on that side.
+type DateNode struct {
+	Position int
+	From     *time.Time
+	To       *time.Time
+}
+
+func (n *TextNode) Pos() int  { return n.Position }
+func (n *FieldNode) Pos() int { return n.Position }
+func (n *IsNode) Pos() int    { return n.Position }
+func (n *DateNode) Pos() int  { return n.Position }
+
+// isStates maps each is: value to the event type and/or action it implies.
+var isStates = map[string]model.EventState{
+	"merged": {EventType: "pull_request", Action: "merged"},
+	"opened": {Action: "opened"},
+	"open":   {Action: "opened"},
+	"pr":     {EventType: "pull_request"},
+	"issue":  {EventType: "issues"},
+}
+
+// Apply adds the query's conditions to filter. Values for the same field are combined
+// with OR (as with repeated query parameters) and different fields with AND. Each is:
+// state is a condition of its own on event type and action, so all of them must hold.
+// Multiple date ranges are intersected.
+func (q *Query) Apply(filter *model.EventFilter) {
+	for _, node := range q.Nodes {
+		switch n := node.(type) {
+		case *TextNode:
+			filter.SearchTerms = append(filter.SearchTerms, n.Value)
+		case *FieldNode:
+			applyField(filter, n.Field, n.Value, n.Negated)
+		case *IsNode:
+			state := isStates[n.State]
+			target := pick(n.Negated, &filter.States, &filter.ExcludeStates)
+			if !slices.Contains(*target, state) {
+				*target = append(*target, state)
+			}
+		case *DateNode:
+			if n.From != nil && (filter.Since == nil || n.From.After(*filter.Since)) {
+				from := *n.From
+				filter.Since = &from
+			}
+			if n.To != nil && (filter.Until == nil || n.To.Before(*filter.Until)) {
+				to := *n.To
+				filter.Until = &to
+			}
+		}
+	}
+}
+
+func applyField(filter *model.EventFilter, field Field, value string, negated bool) {
+	var target *[]string
+	switch field {
+	case FieldRepo:
+		target = pick(negated, &filter.Repos, &filter.ExcludeRepos)
+	case FieldOwner:
+		target = pick(negated, &filter.Owners, &filter.ExcludeOwners)
+	case FieldType:
+		target = pick(negated, &filter.EventTypes, &filter.ExcludeEventTypes)
+	case FieldAuthor:
+		target = pick(negated, &filter.Senders, &filter.ExcludeSenders)
+	case FieldAction:
+		target = pick(negated, &filter.Actions, &filter.ExcludeActions)
+	default:
+		return
+	}
+	if !slices.Contains(*target, value) {
+		*target = append(*target, value)
+	}
+}
+
+func pick[T any](negated bool, include *[]T, exclude *[]T) *[]T {
+	if negated {
+		return exclude
+	}
+	return include
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/middleware"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/repository"
+)
+
+const (
+	maxAPITokenNameLength = 100
+	maxAPITokensPerUser   = 20
+	// apiTokenTouchInterval limits how often a token's last_used_at is written.
+	apiTokenTouchInterval = time.Minute
+)
+
+var (
+	// ErrInvalidAPIToken is returned when a token's name is missing or too long.
+	ErrInvalidAPIToken = fmt.Errorf("name is required (max %d characters)", maxAPITokenNameLength)
+	// ErrTooManyAPITokens is returned when a user already owns maxAPITokensPerUser tokens.
+	ErrTooManyAPITokens = fmt.Errorf("a user may not have more than %d API tokens", maxAPITokensPerUser)
+)
+
+// APITokenService manages personal API tokens, which authenticate non-browser clients
+// (such as gRPC callers) as the user who created them. Tokens are random secrets that
+// are returned once and stored only as SHA-256 hashes.
+type APITokenService struct {
+	repo *repository.APITokenRepository
+}
+
+// NewAPITokenService creates a new APITokenService.
+func NewAPITokenService(repo *repository.APITokenRepository) *APITokenService {
+	return &APITokenService{repo: repo}
+}
+
+// CreateToken creates a token for a user and returns it with its secret.
+func (s *APITokenService) CreateToken(userID int64, name string) (*model.APIToken, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxAPITokenNameLength {
+		return nil, "", ErrInvalidAPIToken
+	}
+	count, err := s.repo.CountByUser(userID)
+	if err != nil {
+		return nil, "", err
+	}
+	if count >= maxAPITokensPerUser {
+		return nil, "", ErrTooManyAPITokens
+	}
+	secret, secretHash, err := generateSecretToken()
+	if err != nil {
+		return nil, "", err
+	}
+	token, err := s.repo.CreateToken(&model.APIToken{UserID: userID, Name: name}, secretHash)
+	if err != nil {
+		return nil, "", err
+	}
+	return token, secret, nil
+}
+
+// ListTokens returns the tokens owned by a user.
+func (s *APITokenService) ListTokens(userID int64) ([]model.APIToken, error) {
+	return s.repo.ListByUser(userID)
+}
+
+// DeleteToken revokes a token owned by a user. Returns false if it does not exist.
+func (s *APITokenService) DeleteToken(userID int64, id int64) (bool, error) {
+	return s.repo.DeleteToken(userID, id)
+}
+
+// Authenticate returns the ID of the user a token belongs to, or 0 if the token is unknown.
+func (s *APITokenService) Authenticate(secret string) (int64, error) {
+	if secret == "" {
+		return 0, nil
+	}
+	token, err := s.repo.FindByTokenHash(hashSecretToken(secret))
+	if err != nil || token == nil {
+		return 0, err
+	}
+	if err := s.repo.TouchLastUsed(token.ID, apiTokenTouchInterval); err != nil {
+		middleware.LogEvent("warn", "failed to record API token use", map[string]interface{}{
+			"token_id": token.ID,
+			"error":    err.Error(),
+		})
+	}
+	return token.UserID, nil
+}
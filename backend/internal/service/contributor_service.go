@@ -0,0 +1,78 @@
+package service
+
+import (
+	"time"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/repository"
+)
+
+const (
+	defaultContributorRange    = 30 * 24 * time.Hour
+	contributorRecentEvents    = 20
+	maxContributorActivityDays = 366
+)
+
+// ContributorService computes contributor leaderboards and activity profiles.
+type ContributorService struct {
+	repo      *repository.ContributorRepository
+	eventRepo *repository.EventRepository
+}
+
+// NewContributorService creates a new ContributorService.
+func NewContributorService(repo *repository.ContributorRepository, eventRepo *repository.EventRepository) *ContributorService {
+	return &ContributorService{repo: repo, eventRepo: eventRepo}
+}
+
+// ListContributors ranks senders of events matching the filter by the given activity.
+// A missing time range defaults to the last 30 days.
+func (s *ContributorService) ListContributors(filter model.EventFilter, sort repository.ContributorSort, limit int) (*model.ContributorListResponse, error) {
+	applyDefaultRange(&filter, defaultContributorRange)
+	contributors, err := s.repo.ListContributors(filter, sort, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &model.ContributorListResponse{
+		Since:        *filter.Since,
+		Until:        *filter.Until,
+		Sort:         string(sort),
+		Contributors: contributors,
+	}, nil
+}
+
+// GetContributor returns the activity profile of login for events matching the filter,
+// or nil if the contributor has no events in the window.
+func (s *ContributorService) GetContributor(filter model.EventFilter, login string) (*model.ContributorProfile, error) {
+	applyDefaultRange(&filter, defaultContributorRange)
+	stats, err := s.repo.GetContributorStats(filter, login)
+	if err != nil || stats == nil {
+		return nil, err
+	}
+	repos, err := s.repo.ListContributorRepos(filter, login)
+	if err != nil {
+		return nil, err
+	}
+	days, err := s.repo.CountContributorDays(filter, login)
+	if err != nil {
+		return nil, err
+	}
+	eventFilter := filter
+	eventFilter.Senders = []string{login}
+	recent, _, err := s.eventRepo.ListEventsByCursor(eventFilter, nil, contributorRecentEvents)
+	if err != nil {
+		return nil, err
+	}
+	activity := []model.SeriesPoint{}
+	start := bucketStart(filter.Since.UTC(), BucketDay)
+	for day := start; day.Before(*filter.Until) && len(activity) < maxContributorActivityDays; day = day.AddDate(0, 0, 1) {
+		activity = append(activity, model.SeriesPoint{Start: day, Count: days[day]})
+	}
+	return &model.ContributorProfile{
+		ContributorStats: *stats,
+		Since:            *filter.Since,
+		Until:            *filter.Until,
+		Repos:            repos,
+		Activity:         activity,
+		RecentEvents:     recent,
+	}, nil
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/repository"
+)
+
+var contributorStatsColumns = []string{"sender_login", "avatar_url", "issues_opened", "prs_merged", "reviews", "pushes", "total"}
+
+func newTestContributorService(t *testing.T) (*ContributorService, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock := newMockDB(t)
+	return NewContributorService(repository.NewContributorRepository(db), repository.NewEventRepository(db)), mock
+}
+
+func TestListContributors(t *testing.T) {
+	since := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		filter        model.EventFilter
+		sort          repository.ContributorSort
+		expectedQuery string
+		expectedErr   error
+	}{
+		{
+			name:          "Ranks by the requested column",
+			filter:        model.EventFilter{Since: &since, Until: &until},
+			sort:          repository.SortByPRsMerged,
+			expectedQuery: `GROUP BY sender_login ORDER BY prs_merged DESC, total DESC, sender_login ASC LIMIT \?`,
+		},
+		{
+			name:          "Defaults to the last 30 days",
+			sort:          repository.SortByTotal,
+			expectedQuery: `WHERE received_at >= \? AND received_at < \? GROUP BY sender_login ORDER BY total DESC`,
+		},
+		{
+			name:        "Rejects an unknown sort before querying",
+			filter:      model.EventFilter{Since: &since, Until: &until},
+			sort:        "total; DROP TABLE events",
+			expectedErr: repository.ErrInvalidContributorSort,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, mock := newTestContributorService(t)
+			if tt.expectedQuery != "" {
+				mock.ExpectQuery(tt.expectedQuery).WillReturnRows(
+					sqlmock.NewRows(contributorStatsColumns).AddRow("alice", nil, 1, 2, 3, 4, 10))
+			}
+			result, err := s.ListContributors(tt.filter, tt.sort, 10)
+			if tt.expectedErr != nil {
+				if !errors.Is(err, tt.expectedErr) {
+					t.Errorf("expected error %v, got %v", tt.expectedErr, err)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				if result.Sort != string(tt.sort) {
+					t.Errorf("expected sort %q, got %q", tt.sort, result.Sort)
+				}
+				if span := result.Until.Sub(result.Since); tt.filter.Since == nil && span != defaultContributorRange {
+					t.Errorf("expected a default range of %v, got %v", defaultContributorRange, span)
+				}
+				if len(result.Contributors) != 1 || result.Contributors[0].Login != "alice" || result.Contributors[0].PRsMerged != 2 {
+					t.Errorf("expected alice with 2 merged PRs, got %+v", result.Contributors)
+				}
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestGetContributor(t *testing.T) {
+	since := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	until := time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)
+	filter := model.EventFilter{Since: &since, Until: &until}
+
+	t.Run("Unknown contributor", func(t *testing.T) {
+		s, mock := newTestContributorService(t)
+		mock.ExpectQuery(`GROUP BY sender_login$`).WillReturnRows(sqlmock.NewRows(contributorStatsColumns))
+		profile, err := s.GetContributor(filter, "nobody")
+		if err != nil || profile != nil {
+			t.Errorf("expected no profile and no error, got %+v and %v", profile, err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("Profile with daily activity", func(t *testing.T) {
+		s, mock := newTestContributorService(t)
+		mock.ExpectQuery(`GROUP BY sender_login$`).
+			WithArgs("alice", since, until).
+			WillReturnRows(sqlmock.NewRows(contributorStatsColumns).AddRow("alice", nil, 1, 0, 0, 2, 3))
+		mock.ExpectQuery(`GROUP BY repo_name`).
+			WillReturnRows(sqlmock.NewRows([]string{"repo_name", "cnt", "last"}).AddRow("acme/api", 3, until))
+		mock.ExpectQuery(`GROUP BY day`).
+			WillReturnRows(sqlmock.NewRows([]string{"day", "count"}).
+				AddRow(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), 1).
+				AddRow(time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC), 2))
+		mock.ExpectQuery(`FROM events WHERE sender_login = \?`).WillReturnRows(eventRows(3, 2, 1))
+
+		profile, err := s.GetContributor(filter, "alice")
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		expectedActivity := []int{1, 0, 2}
+		if len(profile.Activity) != len(expectedActivity) {
+			t.Fatalf("expected %d days of activity, got %+v", len(expectedActivity), profile.Activity)
+		}
+		for i, point := range profile.Activity {
+			expectedStart := time.Date(2026, 3, 1+i, 0, 0, 0, 0, time.UTC)
+			if !point.Start.Equal(expectedStart) || point.Count != expectedActivity[i] {
+				t.Errorf("expected day %d to be %v with %d events, got %+v", i, expectedStart, expectedActivity[i], point)
+			}
+		}
+		if profile.Total != 3 || len(profile.Repos) != 1 || len(profile.RecentEvents) != 3 {
+			t.Errorf("expected 3 events in 1 repo with 3 recent events, got %+v", profile)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+	})
+}
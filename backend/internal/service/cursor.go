@@ -0,0 +1,43 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+)
+
+// ErrInvalidCursor is returned when a pagination cursor cannot be decoded.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// cursorPayload is the JSON form of an EventCursor before base64 encoding.
+// Clients must treat the encoded cursor as opaque.
+type cursorPayload struct {
+	ReceivedAt time.Time `json:"t"`
+	ID         int64     `json:"i"`
+	Backward   bool      `json:"b,omitempty"`
+}
+
+func encodeCursor(e model.Event, backward bool) string {
+	data, _ := json.Marshal(cursorPayload{ReceivedAt: e.ReceivedAt, ID: e.ID, Backward: backward})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(s string) (*model.EventCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var p cursorPayload
+	if err := json.Unmarshal(data, &p); err != nil || p.ID <= 0 {
+		return nil, ErrInvalidCursor
+	}
+	return &model.EventCursor{ReceivedAt: p.ReceivedAt, ID: p.ID, Backward: p.Backward}, nil
+}
+
+// CursorForEvent returns the cursor that continues a list after e, as used in next_cursor.
+func CursorForEvent(e model.Event) string {
+	return encodeCursor(e, false)
+}
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/repository"
+)
+
+var eventColumns = []string{
+	"id", "delivery_id", "event_type", "action", "repo_name", "sender_login", "sender_avatar_url",
+	"title", "body", "html_url", "event_data", "occurred_at", "received_at", "created_at",
+}
+
+// eventTime is the received_at of the event with the given ID in eventRows.
+func eventTime(id int64) time.Time {
+	return time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC).Add(time.Duration(id) * time.Minute)
+}
+
+// eventRows returns one "issues opened" row per ID, received one minute apart.
+func eventRows(ids ...int64) *sqlmock.Rows {
+	rows := sqlmock.NewRows(eventColumns)
+	for _, id := range ids {
+		at := eventTime(id)
+		rows.AddRow(id, fmt.Sprintf("delivery-%d", id), "issues", "opened", "acme/api", "alice", nil,
+			fmt.Sprintf("Issue %d", id), nil, "https://github.com/acme/api/issues/1", nil, at, at, at)
+	}
+	return rows
+}
+
+func newMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db, mock
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	at := time.Date(2026, 3, 1, 12, 30, 0, 123456789, time.UTC)
+	for _, backward := range []bool{false, true} {
+		encoded := encodeCursor(model.Event{ID: 42, ReceivedAt: at}, backward)
+		decoded, err := decodeCursor(encoded)
+		if err != nil {
+			t.Fatalf("expected no error decoding %q, got %v", encoded, err)
+		}
+		expected := model.EventCursor{ReceivedAt: at, ID: 42, Backward: backward}
+		if !decoded.ReceivedAt.Equal(expected.ReceivedAt) || decoded.ID != expected.ID || decoded.Backward != expected.Backward {
+			t.Errorf("expected cursor %+v, got %+v", expected, *decoded)
+		}
+	}
+}
+
+func TestDecodeInvalidCursor(t *testing.T) {
+	valid := encodeCursor(model.Event{ID: 42, ReceivedAt: time.Now()}, false)
+	encode := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{name: "Not base64", cursor: "not a cursor!"},
+		{name: "Padded base64", cursor: valid + "=="},
+		{name: "Truncated", cursor: valid[:len(valid)-4]},
+		{name: "Not JSON", cursor: encode("hello")},
+		{name: "Missing id", cursor: encode(`{"t":"2026-03-01T12:00:00Z"}`)},
+		{name: "Negative id", cursor: encode(`{"t":"2026-03-01T12:00:00Z","i":-1}`)},
+		{name: "Invalid time", cursor: encode(`{"t":"yesterday","i":1}`)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeCursor(tt.cursor); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("expected ErrInvalidCursor, got %v", err)
+			}
+		})
+	}
+}
+
+func TestListEventsByCursor(t *testing.T) {
+	forward := func(id int64) string {
+		return encodeCursor(model.Event{ID: id, ReceivedAt: eventTime(id)}, false)
+	}
+	backward := func(id int64) string {
+		return encodeCursor(model.Event{ID: id, ReceivedAt: eventTime(id)}, true)
+	}
+	tests := []struct {
+		name          string
+		cursor        string
+		rows          []int64 // returned by the database, in query order
+		expectedQuery string
+		expectedIDs   []int64
+		expectedNext  string
+		expectedPrev  string
+	}{
+		{
+			name:          "First page with more events",
+			rows:          []int64{9, 8, 7},
+			expectedQuery: `ORDER BY received_at DESC, id DESC LIMIT \?`,
+			expectedIDs:   []int64{9, 8},
+			expectedNext:  forward(8),
+		},
+		{
+			name:          "Only page",
+			rows:          []int64{9},
+			expectedQuery: `ORDER BY received_at DESC, id DESC LIMIT \?`,
+			expectedIDs:   []int64{9},
+		},
+		{
+			name:          "Forward page from a cursor",
+			cursor:        forward(8),
+			rows:          []int64{7, 6},
+			expectedQuery: `WHERE \(received_at < \? OR \(received_at = \? AND id < \?\)\) ORDER BY received_at DESC, id DESC`,
+			expectedIDs:   []int64{7, 6},
+			expectedPrev:  backward(7),
+		},
+		{
+			name:          "Backward page with more newer events",
+			cursor:        backward(5),
+			rows:          []int64{6, 7, 8},
+			expectedQuery: `WHERE \(received_at > \? OR \(received_at = \? AND id > \?\)\) ORDER BY received_at ASC, id ASC`,
+			expectedIDs:   []int64{7, 6},
+			expectedNext:  forward(6),
+			expectedPrev:  backward(7),
+		},
+		{
+			name:          "Backward page reaching the newest event",
+			cursor:        backward(7),
+			rows:          []int64{8},
+			expectedQuery: `ORDER BY received_at ASC, id ASC`,
+			expectedIDs:   []int64{8},
+			expectedNext:  forward(8),
+		},
+		{
+			name:          "Empty backward page continues after the position",
+			cursor:        backward(9),
+			rows:          nil,
+			expectedQuery: `ORDER BY received_at ASC, id ASC`,
+			expectedNext:  forward(9),
+		},
+		{
+			name:          "Empty forward page",
+			cursor:        forward(1),
+			rows:          nil,
+			expectedQuery: `ORDER BY received_at DESC, id DESC`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+			mock.ExpectQuery(tt.expectedQuery).WillReturnRows(eventRows(tt.rows...))
+			s := NewEventService(repository.NewEventRepository(db))
+
+			result, err := s.ListEventsByCursor(model.EventFilter{}, tt.cursor, 2, false)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			var ids []int64
+			for _, e := range result.Events {
+				ids = append(ids, e.ID)
+			}
+			if !slices.Equal(ids, tt.expectedIDs) {
+				t.Errorf("expected ids %v, got %v", tt.expectedIDs, ids)
+			}
+			if got := derefCursor(result.Pagination.NextCursor); got != tt.expectedNext {
+				t.Errorf("expected next cursor %q, got %q", tt.expectedNext, got)
+			}
+			if got := derefCursor(result.Pagination.PrevCursor); got != tt.expectedPrev {
+				t.Errorf("expected prev cursor %q, got %q", tt.expectedPrev, got)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestListEventsByCursorRejectsInvalidCursor(t *testing.T) {
+	db, mock := newMockDB(t)
+	s := NewEventService(repository.NewEventRepository(db))
+	if _, err := s.ListEventsByCursor(model.EventFilter{}, "bogus", 20, false); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("expected ErrInvalidCursor, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func derefCursor(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
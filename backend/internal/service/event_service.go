@@ -1,8 +1,11 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/middleware"
@@ -22,9 +25,17 @@ func NewEventService(repo *repository.EventRepository) *EventService {
 	return &EventService{repo: repo}
 }
 
-// ProcessWebhook parses a GitHub webhook payload and stores the event.
-// Returns the response, the saved event (nil if duplicate/ignored), and an error.
-func (s *EventService) ProcessWebhook(deliveryID string, eventType string, payload []byte) (*model.WebhookResponse, *model.Event, error) {
+// ProcessWebhook parses a GitHub webhook payload and applies it to the stored events: new
+// events are stored, and edited or deleted issues and comments update or delete theirs.
+// Returns the response, the resulting changes (none if duplicate/ignored), and an error.
+func (s *EventService) ProcessWebhook(deliveryID string, eventType string, payload []byte) (*model.WebhookResponse, []model.EventChange, error) {
+	edit, err := parseEdit(eventType, payload)
+	if err != nil {
+		return nil, nil, err
+	}
+	if edit != nil {
+		return s.applyEdit(deliveryID, edit)
+	}
 	event, err := s.parsePayload(deliveryID, eventType, payload)
 	if err != nil {
 		return nil, nil, err
@@ -47,25 +58,196 @@ func (s *EventService) ProcessWebhook(deliveryID string, eventType string, paylo
 		"event_id":    saved.ID,
 		"event_type":  saved.EventType,
 	})
-	return &model.WebhookResponse{Status: "received", EventID: &saved.ID}, saved, nil
+	changes := []model.EventChange{{Type: model.EventCreated, Event: *saved}}
+	return &model.WebhookResponse{Status: "received", EventID: &saved.ID}, changes, nil
+}
+
+// eventEdit is an edited or deleted issue or comment, to apply to its stored events.
+type eventEdit struct {
+	scope  repository.IssueScope
+	delete bool
+	// title is the issue's title, set on every event in scope. body is the issue's body,
+	// set on its issues events, or the comment's body.
+	title *string
+	body  *string
+}
+
+// parseEdit returns the edit described by an edited or deleted issues or issue_comment
+// payload, or nil for other payloads.
+func parseEdit(eventType string, payload []byte) (*eventEdit, error) {
+	switch eventType {
+	case "issues":
+		var p issuePayload
+		if err := json.Unmarshal(payload, &p); err != nil {
+			return nil, fmt.Errorf("failed to parse issue payload: %w", err)
+		}
+		if p.Action != "edited" && p.Action != "deleted" {
+			return nil, nil
+		}
+		return &eventEdit{
+			scope:  repository.IssueScope{RepoName: p.Repository.FullName, Number: p.Issue.Number},
+			delete: p.Action == "deleted",
+			title:  ptrString(p.Issue.Title),
+			body:   ptrString(truncateString(p.Issue.Body, maxBodyLength)),
+		}, nil
+	case "issue_comment":
+		var p issueCommentPayload
+		if err := json.Unmarshal(payload, &p); err != nil {
+			return nil, fmt.Errorf("failed to parse issue_comment payload: %w", err)
+		}
+		if (p.Action != "edited" && p.Action != "deleted") || p.Comment.HTMLURL == "" {
+			return nil, nil
+		}
+		return &eventEdit{
+			scope: repository.IssueScope{
+				RepoName:   p.Repository.FullName,
+				Number:     p.Issue.Number,
+				CommentURL: p.Comment.HTMLURL,
+			},
+			delete: p.Action == "deleted",
+			title:  ptrString(p.Issue.Title),
+			body:   ptrString(truncateString(p.Comment.Body, maxBodyLength)),
+		}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// applyEdit updates or deletes the stored events of an edited or deleted issue or comment.
+func (s *EventService) applyEdit(deliveryID string, edit *eventEdit) (*model.WebhookResponse, []model.EventChange, error) {
+	ctx := context.Background()
+	changeType := model.EventUpdated
+	var events []model.Event
+	var err error
+	if edit.delete {
+		changeType = model.EventDeleted
+		events, err = s.repo.DeleteEvents(ctx, edit.scope)
+	} else {
+		events, err = s.repo.UpdateEvents(ctx, edit.scope, edit.apply)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to apply %s change: %w", changeType, err)
+	}
+	if len(events) == 0 {
+		return &model.WebhookResponse{Status: "ignored"}, nil, nil
+	}
+	middleware.LogEvent("info", "events changed", map[string]interface{}{
+		"delivery_id": deliveryID,
+		"change":      changeType,
+		"events":      len(events),
+	})
+	changes := make([]model.EventChange, len(events))
+	for i, e := range events {
+		changes[i] = model.EventChange{Type: changeType, Event: e}
+	}
+	return &model.WebhookResponse{Status: string(changeType)}, changes, nil
+}
+
+// apply sets the edited title and body on e, reporting whether it changed.
+func (edit *eventEdit) apply(e *model.Event) bool {
+	changed := !equalStrings(e.Title, edit.title)
+	e.Title = edit.title
+	// Comment events keep the comment's body when their issue is edited.
+	if edit.scope.CommentURL != "" || e.EventType == "issues" {
+		changed = changed || !equalStrings(e.Body, edit.body)
+		e.Body = edit.body
+	}
+	return changed
+}
+
+func equalStrings(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// ListEvents returns a page of events matching the filter in page-number mode.
+// The total count is only computed when includeTotal is set.
+func (s *EventService) ListEvents(filter model.EventFilter, page int, perPage int, includeTotal bool) (*model.EventListResponse, error) {
+	events, err := s.repo.ListEvents(filter, page, perPage)
+	if err != nil {
+		return nil, err
+	}
+	s.highlightEvents(events, filter)
+	pagination := model.Pagination{Page: page, PerPage: perPage}
+	if includeTotal {
+		total, err := s.repo.CountEvents(filter)
+		if err != nil {
+			return nil, err
+		}
+		totalPages := (total + perPage - 1) / perPage
+		pagination.Total = &total
+		pagination.TotalPages = &totalPages
+	}
+	return &model.EventListResponse{Events: events, Pagination: pagination}, nil
+}
+
+// errExportLimitReached stops StreamEvents once the export row cap has been written.
+var errExportLimitReached = errors.New("export limit reached")
+
+// ExportEvents calls fn for up to maxRows events matching the filter, newest first, streaming
+// them from the database. It reports whether more events matched than were exported.
+func (s *EventService) ExportEvents(ctx context.Context, filter model.EventFilter, maxRows int, fn func(*model.Event) error) (bool, error) {
+	rows := 0
+	err := s.repo.StreamEvents(ctx, filter, maxRows+1, func(e *model.Event) error {
+		if rows == maxRows {
+			return errExportLimitReached
+		}
+		rows++
+		return fn(e)
+	})
+	if errors.Is(err, errExportLimitReached) {
+		return true, nil
+	}
+	return false, err
 }
 
-// ListEvents returns a paginated list of events.
-func (s *EventService) ListEvents(page int, perPage int, eventType string) (*model.EventListResponse, error) {
-	events, total, err := s.repo.ListEvents(page, perPage, eventType)
+// ListEventsByCursor returns events matching the filter in cursor mode, ordered by
+// (received_at, id) descending. An empty cursor starts from the newest event.
+// Returns ErrInvalidCursor if the cursor cannot be decoded.
+func (s *EventService) ListEventsByCursor(filter model.EventFilter, cursor string, perPage int, includeTotal bool) (*model.EventListResponse, error) {
+	var pos *model.EventCursor
+	if cursor != "" {
+		decoded, err := decodeCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		pos = decoded
+	}
+	events, hasMore, err := s.repo.ListEventsByCursor(filter, pos, perPage)
 	if err != nil {
 		return nil, err
 	}
-	totalPages := (total + perPage - 1) / perPage
-	return &model.EventListResponse{
-		Events: events,
-		Pagination: model.Pagination{
-			Page:       page,
-			PerPage:    perPage,
-			Total:      total,
-			TotalPages: totalPages,
-		},
-	}, nil
+	s.highlightEvents(events, filter)
+	pagination := model.Pagination{PerPage: perPage}
+	if len(events) == 0 && pos != nil && pos.Backward {
+		// Nothing is newer than the position yet. The list continues with the events after
+		// it; the client can retry its cursor later to pick up events received meanwhile.
+		next := encodeCursor(model.Event{ReceivedAt: pos.ReceivedAt, ID: pos.ID}, false)
+		pagination.NextCursor = &next
+	}
+	if len(events) > 0 {
+		backward := pos != nil && pos.Backward
+		// Moving forward there are older events if the query found more; newer ones exist
+		// whenever we arrived from a cursor. Moving backward the roles are swapped.
+		if backward || hasMore {
+			next := encodeCursor(events[len(events)-1], false)
+			pagination.NextCursor = &next
+		}
+		if (backward && hasMore) || (!backward && pos != nil) {
+			prev := encodeCursor(events[0], true)
+			pagination.PrevCursor = &prev
+		}
+	}
+	if includeTotal {
+		total, err := s.repo.CountEvents(filter)
+		if err != nil {
+			return nil, err
+		}
+		pagination.Total = &total
+	}
+	return &model.EventListResponse{Events: events, Pagination: pagination}, nil
 }
 
 // GetEventByID returns a single event by ID.
@@ -73,12 +255,39 @@ func (s *EventService) GetEventByID(id int64) (*model.Event, error) {
 	return s.repo.GetEventByID(id)
 }
 
+// ListEventsAfterID returns up to limit events matching the filter with an ID greater than
+// afterID, oldest first.
+func (s *EventService) ListEventsAfterID(ctx context.Context, filter model.EventFilter, afterID int64, limit int) ([]model.Event, error) {
+	return s.repo.ListEventsAfterID(ctx, filter, afterID, limit)
+}
+
+func (s *EventService) highlightEvents(events []model.Event, filter model.EventFilter) {
+	if len(filter.SearchTerms) == 0 {
+		return
+	}
+	for i := range events {
+		highlightEvent(&events[i], filter.SearchTerms)
+	}
+}
+
 func (s *EventService) parsePayload(deliveryID string, eventType string, payload []byte) (*model.Event, error) {
 	switch eventType {
 	case "issues":
 		return s.parseIssueEvent(deliveryID, payload)
+	case "issue_comment":
+		return s.parseIssueCommentEvent(deliveryID, payload)
 	case "pull_request":
 		return s.parsePullRequestEvent(deliveryID, payload)
+	case "pull_request_review":
+		return s.parsePullRequestReviewEvent(deliveryID, payload)
+	case "push":
+		return s.parsePushEvent(deliveryID, payload)
+	case "deployment":
+		return s.parseDeploymentEvent(deliveryID, payload)
+	case "deployment_status":
+		return s.parseDeploymentStatusEvent(deliveryID, payload)
+	case "workflow_run":
+		return s.parseWorkflowRunEvent(deliveryID, payload)
 	default:
 		return nil, nil
 	}
@@ -87,9 +296,16 @@ func (s *EventService) parsePayload(deliveryID string, eventType string, payload
 type issuePayload struct {
 	Action string `json:"action"`
 	Issue  struct {
-		Title   string `json:"title"`
-		Body    string `json:"body"`
-		HTMLURL string `json:"html_url"`
+		Number            int        `json:"number"`
+		Title             string     `json:"title"`
+		Body              string     `json:"body"`
+		HTMLURL           string     `json:"html_url"`
+		AuthorAssociation string     `json:"author_association"`
+		CreatedAt         time.Time  `json:"created_at"`
+		ClosedAt          *time.Time `json:"closed_at"`
+		User              struct {
+			Login string `json:"login"`
+		} `json:"user"`
 	} `json:"issue"`
 	Repository struct {
 		FullName string `json:"full_name"`
@@ -105,9 +321,22 @@ func (s *EventService) parseIssueEvent(deliveryID string, payload []byte) (*mode
 	if err := json.Unmarshal(payload, &p); err != nil {
 		return nil, fmt.Errorf("failed to parse issue payload: %w", err)
 	}
-	if p.Action != "opened" {
+	occurredAt := time.Now().UTC()
+	switch p.Action {
+	case "opened":
+		occurredAt = timeOrNow(p.Issue.CreatedAt)
+	case "closed":
+		if p.Issue.ClosedAt != nil {
+			occurredAt = timeOrNow(*p.Issue.ClosedAt)
+		}
+	default:
 		return nil, nil
 	}
+	data := model.IssueData{
+		Number:            p.Issue.Number,
+		Author:            p.Issue.User.Login,
+		AuthorAssociation: p.Issue.AuthorAssociation,
+	}
 	body := truncateString(p.Issue.Body, maxBodyLength)
 	return &model.Event{
 		DeliveryID:      deliveryID,
@@ -119,7 +348,64 @@ func (s *EventService) parseIssueEvent(deliveryID string, payload []byte) (*mode
 		Title:           ptrString(p.Issue.Title),
 		Body:            ptrString(body),
 		HTMLURL:         p.Issue.HTMLURL,
-		OccurredAt:      time.Now().UTC(),
+		EventData:       marshalEventData(data),
+		OccurredAt:      occurredAt,
+		ReceivedAt:      time.Now().UTC(),
+	}, nil
+}
+
+type issueCommentPayload struct {
+	Action string `json:"action"`
+	Issue  struct {
+		Number      int       `json:"number"`
+		Title       string    `json:"title"`
+		PullRequest *struct{} `json:"pull_request"`
+		User        struct {
+			Login string `json:"login"`
+		} `json:"user"`
+	} `json:"issue"`
+	Comment struct {
+		Body              string    `json:"body"`
+		HTMLURL           string    `json:"html_url"`
+		AuthorAssociation string    `json:"author_association"`
+		CreatedAt         time.Time `json:"created_at"`
+	} `json:"comment"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+	Sender struct {
+		Login     string `json:"login"`
+		AvatarURL string `json:"avatar_url"`
+	} `json:"sender"`
+}
+
+func (s *EventService) parseIssueCommentEvent(deliveryID string, payload []byte) (*model.Event, error) {
+	var p issueCommentPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse issue_comment payload: %w", err)
+	}
+	if p.Action != "created" {
+		return nil, nil
+	}
+	data := model.IssueData{
+		Number:            p.Issue.Number,
+		Author:            p.Issue.User.Login,
+		AuthorAssociation: p.Comment.AuthorAssociation,
+		IsPullRequest:     p.Issue.PullRequest != nil,
+	}
+	body := truncateString(p.Comment.Body, maxBodyLength)
+	return &model.Event{
+		DeliveryID:      deliveryID,
+		EventType:       "issue_comment",
+		Action:          p.Action,
+		RepoName:        p.Repository.FullName,
+		SenderLogin:     p.Sender.Login,
+		SenderAvatarURL: ptrString(p.Sender.AvatarURL),
+		Title:           ptrString(p.Issue.Title),
+		Body:            ptrString(body),
+		HTMLURL:         p.Comment.HTMLURL,
+		EventData:       marshalEventData(data),
+		OccurredAt:      timeOrNow(p.Comment.CreatedAt),
 		ReceivedAt:      time.Now().UTC(),
 	}, nil
 }
@@ -127,10 +413,19 @@ func (s *EventService) parseIssueEvent(deliveryID string, payload []byte) (*mode
 type pullRequestPayload struct {
 	Action      string `json:"action"`
 	PullRequest struct {
-		Title   string `json:"title"`
-		Body    string `json:"body"`
-		HTMLURL string `json:"html_url"`
-		Merged  bool   `json:"merged"`
+		Number       int        `json:"number"`
+		Title        string     `json:"title"`
+		Body         string     `json:"body"`
+		HTMLURL      string     `json:"html_url"`
+		Merged       bool       `json:"merged"`
+		CreatedAt    time.Time  `json:"created_at"`
+		MergedAt     *time.Time `json:"merged_at"`
+		Additions    int        `json:"additions"`
+		Deletions    int        `json:"deletions"`
+		ChangedFiles int        `json:"changed_files"`
+		User         struct {
+			Login string `json:"login"`
+		} `json:"user"`
 	} `json:"pull_request"`
 	Repository struct {
 		FullName string `json:"full_name"`
@@ -146,25 +441,320 @@ func (s *EventService) parsePullRequestEvent(deliveryID string, payload []byte)
 	if err := json.Unmarshal(payload, &p); err != nil {
 		return nil, fmt.Errorf("failed to parse pull_request payload: %w", err)
 	}
-	if p.Action != "closed" || !p.PullRequest.Merged {
+	var action string
+	occurredAt := time.Now().UTC()
+	switch {
+	case p.Action == "opened":
+		action = "opened"
+		occurredAt = timeOrNow(p.PullRequest.CreatedAt)
+	case p.Action == "closed" && p.PullRequest.Merged:
+		action = "merged"
+		if p.PullRequest.MergedAt != nil {
+			occurredAt = timeOrNow(*p.PullRequest.MergedAt)
+		}
+	default:
 		return nil, nil
 	}
+	data := model.PullRequestData{
+		Number:       p.PullRequest.Number,
+		Author:       p.PullRequest.User.Login,
+		CreatedAt:    utcPtr(p.PullRequest.CreatedAt),
+		MergedAt:     p.PullRequest.MergedAt,
+		Additions:    p.PullRequest.Additions,
+		Deletions:    p.PullRequest.Deletions,
+		ChangedFiles: p.PullRequest.ChangedFiles,
+	}
 	body := truncateString(p.PullRequest.Body, maxBodyLength)
 	return &model.Event{
 		DeliveryID:      deliveryID,
 		EventType:       "pull_request",
-		Action:          "merged",
+		Action:          action,
 		RepoName:        p.Repository.FullName,
 		SenderLogin:     p.Sender.Login,
 		SenderAvatarURL: ptrString(p.Sender.AvatarURL),
 		Title:           ptrString(p.PullRequest.Title),
 		Body:            ptrString(body),
 		HTMLURL:         p.PullRequest.HTMLURL,
-		OccurredAt:      time.Now().UTC(),
+		EventData:       marshalEventData(data),
+		OccurredAt:      occurredAt,
 		ReceivedAt:      time.Now().UTC(),
 	}, nil
 }
 
+type pullRequestReviewPayload struct {
+	Action string `json:"action"`
+	Review struct {
+		Body        string    `json:"body"`
+		HTMLURL     string    `json:"html_url"`
+		State       string    `json:"state"`
+		SubmittedAt time.Time `json:"submitted_at"`
+	} `json:"review"`
+	PullRequest struct {
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+		User   struct {
+			Login string `json:"login"`
+		} `json:"user"`
+	} `json:"pull_request"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+	Sender struct {
+		Login     string `json:"login"`
+		AvatarURL string `json:"avatar_url"`
+	} `json:"sender"`
+}
+
+func (s *EventService) parsePullRequestReviewEvent(deliveryID string, payload []byte) (*model.Event, error) {
+	var p pullRequestReviewPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse pull_request_review payload: %w", err)
+	}
+	if p.Action != "submitted" {
+		return nil, nil
+	}
+	data := model.PullRequestData{
+		Number:      p.PullRequest.Number,
+		Author:      p.PullRequest.User.Login,
+		ReviewState: p.Review.State,
+	}
+	body := truncateString(p.Review.Body, maxBodyLength)
+	return &model.Event{
+		DeliveryID:      deliveryID,
+		EventType:       "pull_request_review",
+		Action:          p.Action,
+		RepoName:        p.Repository.FullName,
+		SenderLogin:     p.Sender.Login,
+		SenderAvatarURL: ptrString(p.Sender.AvatarURL),
+		Title:           ptrString(p.PullRequest.Title),
+		Body:            ptrString(body),
+		HTMLURL:         p.Review.HTMLURL,
+		EventData:       marshalEventData(data),
+		OccurredAt:      timeOrNow(p.Review.SubmittedAt),
+		ReceivedAt:      time.Now().UTC(),
+	}, nil
+}
+
+type pushPayload struct {
+	Ref        string     `json:"ref"`
+	Deleted    bool       `json:"deleted"`
+	Compare    string     `json:"compare"`
+	Commits    []struct{} `json:"commits"`
+	HeadCommit *struct {
+		Message   string    `json:"message"`
+		Timestamp time.Time `json:"timestamp"`
+	} `json:"head_commit"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+	Sender struct {
+		Login     string `json:"login"`
+		AvatarURL string `json:"avatar_url"`
+	} `json:"sender"`
+}
+
+func (s *EventService) parsePushEvent(deliveryID string, payload []byte) (*model.Event, error) {
+	var p pushPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse push payload: %w", err)
+	}
+	if p.Deleted || p.HeadCommit == nil {
+		return nil, nil
+	}
+	branch := strings.TrimPrefix(strings.TrimPrefix(p.Ref, "refs/heads/"), "refs/tags/")
+	title := fmt.Sprintf("Pushed %d commit(s) to %s", len(p.Commits), branch)
+	body := truncateString(p.HeadCommit.Message, maxBodyLength)
+	return &model.Event{
+		DeliveryID:      deliveryID,
+		EventType:       "push",
+		Action:          "pushed",
+		RepoName:        p.Repository.FullName,
+		SenderLogin:     p.Sender.Login,
+		SenderAvatarURL: ptrString(p.Sender.AvatarURL),
+		Title:           ptrString(title),
+		Body:            ptrString(body),
+		HTMLURL:         p.Compare,
+		OccurredAt:      timeOrNow(p.HeadCommit.Timestamp),
+		ReceivedAt:      time.Now().UTC(),
+	}, nil
+}
+
+type deploymentPayload struct {
+	Action     string `json:"action"`
+	Deployment struct {
+		ID          int64     `json:"id"`
+		Ref         string    `json:"ref"`
+		SHA         string    `json:"sha"`
+		Environment string    `json:"environment"`
+		Description string    `json:"description"`
+		CreatedAt   time.Time `json:"created_at"`
+	} `json:"deployment"`
+	DeploymentStatus struct {
+		State       string    `json:"state"`
+		Description string    `json:"description"`
+		TargetURL   string    `json:"target_url"`
+		LogURL      string    `json:"log_url"`
+		CreatedAt   time.Time `json:"created_at"`
+	} `json:"deployment_status"`
+	Repository struct {
+		FullName string `json:"full_name"`
+		HTMLURL  string `json:"html_url"`
+	} `json:"repository"`
+	Sender struct {
+		Login     string `json:"login"`
+		AvatarURL string `json:"avatar_url"`
+	} `json:"sender"`
+}
+
+func (s *EventService) parseDeploymentEvent(deliveryID string, payload []byte) (*model.Event, error) {
+	var p deploymentPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse deployment payload: %w", err)
+	}
+	if p.Action != "created" {
+		return nil, nil
+	}
+	data := model.DeploymentData{
+		DeploymentID: p.Deployment.ID,
+		Environment:  p.Deployment.Environment,
+		SHA:          p.Deployment.SHA,
+	}
+	title := fmt.Sprintf("Deploy %s to %s", p.Deployment.Ref, p.Deployment.Environment)
+	return &model.Event{
+		DeliveryID:      deliveryID,
+		EventType:       "deployment",
+		Action:          p.Action,
+		RepoName:        p.Repository.FullName,
+		SenderLogin:     p.Sender.Login,
+		SenderAvatarURL: ptrString(p.Sender.AvatarURL),
+		Title:           ptrString(title),
+		Body:            ptrString(truncateString(p.Deployment.Description, maxBodyLength)),
+		HTMLURL:         p.Repository.HTMLURL + "/deployments",
+		EventData:       marshalEventData(data),
+		OccurredAt:      timeOrNow(p.Deployment.CreatedAt),
+		ReceivedAt:      time.Now().UTC(),
+	}, nil
+}
+
+func (s *EventService) parseDeploymentStatusEvent(deliveryID string, payload []byte) (*model.Event, error) {
+	var p deploymentPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse deployment_status payload: %w", err)
+	}
+	if p.Action != "created" || p.DeploymentStatus.State == "" {
+		return nil, nil
+	}
+	data := model.DeploymentData{
+		DeploymentID: p.Deployment.ID,
+		Environment:  p.Deployment.Environment,
+		SHA:          p.Deployment.SHA,
+		State:        p.DeploymentStatus.State,
+	}
+	htmlURL := p.DeploymentStatus.TargetURL
+	if htmlURL == "" {
+		htmlURL = p.DeploymentStatus.LogURL
+	}
+	if htmlURL == "" {
+		htmlURL = p.Repository.HTMLURL + "/deployments"
+	}
+	title := fmt.Sprintf("Deployment to %s: %s", p.Deployment.Environment, p.DeploymentStatus.State)
+	return &model.Event{
+		DeliveryID:      deliveryID,
+		EventType:       "deployment_status",
+		Action:          p.DeploymentStatus.State,
+		RepoName:        p.Repository.FullName,
+		SenderLogin:     p.Sender.Login,
+		SenderAvatarURL: ptrString(p.Sender.AvatarURL),
+		Title:           ptrString(title),
+		Body:            ptrString(truncateString(p.DeploymentStatus.Description, maxBodyLength)),
+		HTMLURL:         htmlURL,
+		EventData:       marshalEventData(data),
+		OccurredAt:      timeOrNow(p.DeploymentStatus.CreatedAt),
+		ReceivedAt:      time.Now().UTC(),
+	}, nil
+}
+
+type workflowRunPayload struct {
+	Action      string `json:"action"`
+	WorkflowRun struct {
+		ID           int64     `json:"id"`
+		Name         string    `json:"name"`
+		RunNumber    int       `json:"run_number"`
+		HeadBranch   string    `json:"head_branch"`
+		HeadSHA      string    `json:"head_sha"`
+		Event        string    `json:"event"`
+		Conclusion   string    `json:"conclusion"`
+		HTMLURL      string    `json:"html_url"`
+		RunStartedAt time.Time `json:"run_started_at"`
+		UpdatedAt    time.Time `json:"updated_at"`
+	} `json:"workflow_run"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+	Sender struct {
+		Login     string `json:"login"`
+		AvatarURL string `json:"avatar_url"`
+	} `json:"sender"`
+}
+
+func (s *EventService) parseWorkflowRunEvent(deliveryID string, payload []byte) (*model.Event, error) {
+	var p workflowRunPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse workflow_run payload: %w", err)
+	}
+	if p.Action != "completed" {
+		return nil, nil
+	}
+	run := p.WorkflowRun
+	data := model.WorkflowRunData{
+		RunID:        run.ID,
+		Name:         run.Name,
+		HeadBranch:   run.HeadBranch,
+		HeadSHA:      run.HeadSHA,
+		Conclusion:   run.Conclusion,
+		RunStartedAt: utcPtr(run.RunStartedAt),
+	}
+	title := fmt.Sprintf("%s #%d %s", run.Name, run.RunNumber, run.Conclusion)
+	return &model.Event{
+		DeliveryID:      deliveryID,
+		EventType:       "workflow_run",
+		Action:          p.Action,
+		RepoName:        p.Repository.FullName,
+		SenderLogin:     p.Sender.Login,
+		SenderAvatarURL: ptrString(p.Sender.AvatarURL),
+		Title:           ptrString(title),
+		HTMLURL:         run.HTMLURL,
+		EventData:       marshalEventData(data),
+		OccurredAt:      timeOrNow(run.UpdatedAt),
+		ReceivedAt:      time.Now().UTC(),
+	}, nil
+}
+
+// timeOrNow returns t in UTC, or the current time if t is zero (missing in the payload).
+func timeOrNow(t time.Time) time.Time {
+	if t.IsZero() {
+		return time.Now().UTC()
+	}
+	return t.UTC()
+}
+
+// marshalEventData encodes structured event details for the event_data JSON column.
+func marshalEventData(v interface{}) *string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	return ptrString(string(data))
+}
+
+func utcPtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	t = t.UTC()
+	return &t
+}
+
 func ptrString(s string) *string {
 	if s == "" {
 		return nil
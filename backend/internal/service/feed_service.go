@@ -0,0 +1,90 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/repository"
+)
+
+const (
+	maxFeedNameLength  = 100
+	maxFeedQueryLength = 2000
+	maxFeedsPerUser    = 50
+)
+
+var (
+	// ErrInvalidFeed is returned when a feed's name or query is missing or too long.
+	ErrInvalidFeed = fmt.Errorf("name is required (max %d characters) and query must not exceed %d characters",
+		maxFeedNameLength, maxFeedQueryLength)
+	// ErrTooManyFeeds is returned when a user already owns maxFeedsPerUser feeds.
+	ErrTooManyFeeds = fmt.Errorf("a user may not have more than %d feeds", maxFeedsPerUser)
+)
+
+// FeedService manages saved feeds and the feed tokens that grant access to them. Each user
+// has at most one token, which authorizes all of that user's feeds. Tokens are random
+// secrets that are returned once and stored only as SHA-256 hashes.
+type FeedService struct {
+	repo *repository.FeedRepository
+}
+
+// NewFeedService creates a new FeedService.
+func NewFeedService(repo *repository.FeedRepository) *FeedService {
+	return &FeedService{repo: repo}
+}
+
+// CreateFeed saves a feed for a user. query must already have been validated as event
+// list filters.
+func (s *FeedService) CreateFeed(userID int64, name string, query string) (*model.Feed, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxFeedNameLength || len(query) > maxFeedQueryLength {
+		return nil, ErrInvalidFeed
+	}
+	count, err := s.repo.CountByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxFeedsPerUser {
+		return nil, ErrTooManyFeeds
+	}
+	return s.repo.CreateFeed(&model.Feed{UserID: userID, Name: name, Query: query})
+}
+
+// ListFeeds returns the feeds owned by a user.
+func (s *FeedService) ListFeeds(userID int64) ([]model.Feed, error) {
+	return s.repo.ListByUser(userID)
+}
+
+// DeleteFeed deletes a feed owned by a user. Returns false if it does not exist.
+func (s *FeedService) DeleteFeed(userID int64, id int64) (bool, error) {
+	return s.repo.DeleteFeed(userID, id)
+}
+
+// RegenerateToken issues a new feed token for a user and returns it, revoking the previous
+// one and with it every feed URL built from it.
+func (s *FeedService) RegenerateToken(userID int64) (string, error) {
+	token, tokenHash, err := generateSecretToken()
+	if err != nil {
+		return "", err
+	}
+	if err := s.repo.SetUserTokenHash(userID, tokenHash); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// RevokeToken deletes a user's feed token. Returns false if the user has none.
+func (s *FeedService) RevokeToken(userID int64) (bool, error) {
+	return s.repo.DeleteUserToken(userID)
+}
+
+// GetFeedByToken returns the feed with the given ID if the token belongs to its owner, or
+// nil if the token is unknown or the feed belongs to someone else.
+func (s *FeedService) GetFeedByToken(token string, id int64) (*model.Feed, error) {
+	if token == "" {
+		return nil, nil
+	}
+	return s.repo.FindByTokenHash(hashSecretToken(token), id)
+}
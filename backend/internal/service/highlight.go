@@ -0,0 +1,118 @@
+package service
+
+import (
+	"html"
+	"strings"
+	"unicode"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+)
+
+// snippetContext is the number of characters kept on each side of the first body match.
+const snippetContext = 60
+
+type matchRange struct {
+	start, end int
+}
+
+// highlightEvent sets e.Highlight to HTML-escaped snippets of the title and body
+// with every occurrence of the search terms wrapped in <mark> tags. Terms are stripped of
+// search operators first, so they match the text that was actually searched.
+// The highlight is left nil when neither field contains a term.
+func highlightEvent(e *model.Event, terms []string) {
+	var needles [][]rune
+	for _, term := range terms {
+		if t := model.NormalizeSearchTerm(term); t != "" {
+			needles = append(needles, toLowerRunes([]rune(t)))
+		}
+	}
+	if len(needles) == 0 {
+		return
+	}
+	var hl model.EventHighlight
+	if e.Title != nil {
+		text := []rune(*e.Title)
+		if matches := findMatches(text, needles); len(matches) > 0 {
+			hl.Title = ptrString(markRanges(text, matches, 0, len(text)))
+		}
+	}
+	if e.Body != nil {
+		text := []rune(*e.Body)
+		if matches := findMatches(text, needles); len(matches) > 0 {
+			start := max(0, matches[0].start-snippetContext)
+			end := min(len(text), matches[0].end+snippetContext)
+			snippet := markRanges(text, matches, start, end)
+			if start > 0 {
+				snippet = "…" + snippet
+			}
+			if end < len(text) {
+				snippet += "…"
+			}
+			hl.Body = &snippet
+		}
+	}
+	if hl.Title != nil || hl.Body != nil {
+		e.Highlight = &hl
+	}
+}
+
+// findMatches returns the non-overlapping, case-insensitive occurrences of the needles in text,
+// ordered by position. When several needles match at the same position the longest wins.
+func findMatches(text []rune, needles [][]rune) []matchRange {
+	lower := toLowerRunes(text)
+	var matches []matchRange
+	for i := 0; i < len(lower); {
+		best := 0
+		for _, n := range needles {
+			if len(n) > best && hasPrefixRunes(lower[i:], n) {
+				best = len(n)
+			}
+		}
+		if best == 0 {
+			i++
+			continue
+		}
+		matches = append(matches, matchRange{start: i, end: i + best})
+		i += best
+	}
+	return matches
+}
+
+// markRanges escapes text[from:to] and wraps the given match ranges in <mark> tags.
+func markRanges(text []rune, matches []matchRange, from int, to int) string {
+	var sb strings.Builder
+	pos := from
+	for _, m := range matches {
+		if m.end <= from || m.start >= to {
+			continue
+		}
+		start, end := max(m.start, from), min(m.end, to)
+		sb.WriteString(html.EscapeString(string(text[pos:start])))
+		sb.WriteString("<mark>")
+		sb.WriteString(html.EscapeString(string(text[start:end])))
+		sb.WriteString("</mark>")
+		pos = end
+	}
+	sb.WriteString(html.EscapeString(string(text[pos:to])))
+	return sb.String()
+}
+
+func toLowerRunes(rs []rune) []rune {
+	out := make([]rune, len(rs))
+	for i, r := range rs {
+		out[i] = unicode.ToLower(r)
+	}
+	return out
+}
+
+func hasPrefixRunes(s []rune, prefix []rune) bool {
+	if len(prefix) > len(s) {
+		return false
+	}
+	for i := range prefix {
+		if s[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+)
+
+func TestHighlightEvent(t *testing.T) {
+	longBody := strings.Repeat("a", 100) + " flaky test " + strings.Repeat("b", 100)
+	tests := []struct {
+		name          string
+		title         string
+		body          string
+		terms         []string
+		expectedTitle *string
+		expectedBody  *string
+	}{
+		{
+			name:          "case-insensitive title match",
+			title:         "Fix Bug in parser",
+			terms:         []string{"bug"},
+			expectedTitle: ptrString("Fix <mark>Bug</mark> in parser"),
+		},
+		{
+			name:          "operator characters are stripped",
+			title:         "bug in the parser",
+			terms:         []string{"-bug", "pars*", "+the"},
+			expectedTitle: ptrString("<mark>bug</mark> in <mark>the</mark> <mark>pars</mark>er"),
+		},
+		{
+			name:         "phrases are matched with collapsed whitespace",
+			title:        "a flaky   test",
+			body:         "this flaky test fails",
+			terms:        []string{`"flaky  test"`},
+			expectedBody: ptrString("this <mark>flaky test</mark> fails"),
+		},
+		{
+			name:          "longest needle wins at the same position",
+			title:         "testing",
+			terms:         []string{"test", "testing"},
+			expectedTitle: ptrString("<mark>testing</mark>"),
+		},
+		{
+			name:          "text is HTML-escaped",
+			title:         "<b>bug</b> & more",
+			terms:         []string{"bug"},
+			expectedTitle: ptrString("&lt;b&gt;<mark>bug</mark>&lt;/b&gt; &amp; more"),
+		},
+		{
+			name:         "body snippet is cut around the first match",
+			body:         longBody,
+			terms:        []string{"flaky"},
+			expectedBody: ptrString("…" + strings.Repeat("a", 59) + " <mark>flaky</mark> test " + strings.Repeat("b", 54) + "…"),
+		},
+		{
+			name:  "only operators",
+			title: "a - b",
+			terms: []string{"-", "**"},
+		},
+		{
+			name:  "no match",
+			title: "unrelated",
+			terms: []string{"bug"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := model.Event{}
+			if tt.title != "" {
+				e.Title = &tt.title
+			}
+			if tt.body != "" {
+				e.Body = &tt.body
+			}
+			highlightEvent(&e, tt.terms)
+			if tt.expectedTitle == nil && tt.expectedBody == nil {
+				if e.Highlight != nil {
+					t.Errorf("expected no highlight, got %+v", e.Highlight)
+				}
+				return
+			}
+			if e.Highlight == nil {
+				t.Fatal("expected a highlight, got nil")
+			}
+			if !equalStringPtr(e.Highlight.Title, tt.expectedTitle) {
+				t.Errorf("expected title %s, got %s", describe(tt.expectedTitle), describe(e.Highlight.Title))
+			}
+			if !equalStringPtr(e.Highlight.Body, tt.expectedBody) {
+				t.Errorf("expected body %s, got %s", describe(tt.expectedBody), describe(e.Highlight.Body))
+			}
+		})
+	}
+}
+
+func equalStringPtr(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func describe(s *string) string {
+	if s == nil {
+		return "nil"
+	}
+	return "\"" + *s + "\""
+}
@@ -0,0 +1,314 @@
+package service
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/repository"
+)
+
+const (
+	defaultMetricsRange    = 90 * 24 * time.Hour
+	maxMetricsRange        = 366 * 24 * time.Hour
+	maxMetricsPullRequests = 5000
+	maxMetricsDeployments  = 20000
+)
+
+// ErrRangeTooLong is returned when a metrics time range exceeds maxMetricsRange.
+var ErrRangeTooLong = errors.New("time range must not exceed 366 days")
+
+// prSizeBuckets are the upper bounds (exclusive) of the pull request size labels.
+var prSizeBuckets = []struct {
+	label string
+	limit int
+}{
+	{"XS", 10},
+	{"S", 50},
+	{"M", 250},
+	{"L", 1000},
+	{"XL", math.MaxInt},
+}
+
+// DORAConfig decides which deployment attempts count as production deployments.
+type DORAConfig struct {
+	// ProductionEnvironments maps a repository full name to its production environments;
+	// the "" key holds the default for repositories without an entry.
+	ProductionEnvironments map[string][]string
+	// DeployWorkflows lists workflow names whose runs count as production deployments.
+	DeployWorkflows []string
+}
+
+func (c DORAConfig) productionEnvironments(repo string) []string {
+	if envs, ok := c.ProductionEnvironments[repo]; ok {
+		return envs
+	}
+	return c.ProductionEnvironments[""]
+}
+
+func (c DORAConfig) isProduction(o repository.DeploymentOutcome) bool {
+	if o.Workflow != "" {
+		return slices.Contains(c.DeployWorkflows, o.Workflow)
+	}
+	return slices.Contains(c.productionEnvironments(o.RepoName), o.Environment)
+}
+
+// MetricsService computes delivery metrics from stored events.
+type MetricsService struct {
+	repo *repository.MetricsRepository
+	dora DORAConfig
+}
+
+// NewMetricsService creates a new MetricsService.
+func NewMetricsService(repo *repository.MetricsRepository, dora DORAConfig) *MetricsService {
+	return &MetricsService{repo: repo, dora: dora}
+}
+
+// GetPullRequestMetrics returns time-to-first-review, time-to-merge and size metrics for
+// pull requests merged within the filter's range (default: last 90 days), overall,
+// per repository and per week (weeks start on Monday in loc).
+func (s *MetricsService) GetPullRequestMetrics(filter model.EventFilter, loc *time.Location) (*model.PullRequestMetricsResponse, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	applyDefaultRange(&filter, defaultMetricsRange)
+	if filter.Until.Sub(*filter.Since) > maxMetricsRange {
+		return nil, ErrRangeTooLong
+	}
+	prs, err := s.repo.ListMergedPullRequests(filter, maxMetricsPullRequests+1)
+	if err != nil {
+		return nil, err
+	}
+	resp := &model.PullRequestMetricsResponse{
+		Since:     filter.Since.In(loc),
+		Until:     filter.Until.In(loc),
+		Timezone:  loc.String(),
+		Truncated: len(prs) > maxMetricsPullRequests,
+		Repos:     []model.RepoPullRequestMetrics{},
+		Weeks:     []model.WeeklyPullRequestMetrics{},
+	}
+	if resp.Truncated {
+		prs = prs[:maxMetricsPullRequests]
+	}
+	resp.Overall = summarizePullRequests(prs)
+	byRepo := make(map[string][]repository.MergedPullRequest)
+	byWeek := make(map[int64][]repository.MergedPullRequest)
+	for _, pr := range prs {
+		byRepo[pr.RepoName] = append(byRepo[pr.RepoName], pr)
+		week := bucketStart(pr.MergedAt.In(loc), BucketWeek).Unix()
+		byWeek[week] = append(byWeek[week], pr)
+	}
+	for _, repo := range sortedKeys(byRepo) {
+		resp.Repos = append(resp.Repos, model.RepoPullRequestMetrics{
+			RepoName:           repo,
+			PullRequestMetrics: summarizePullRequests(byRepo[repo]),
+		})
+	}
+	for week := bucketStart(filter.Since.In(loc), BucketWeek); week.Before(*filter.Until); week = nextBucket(week, BucketWeek) {
+		resp.Weeks = append(resp.Weeks, model.WeeklyPullRequestMetrics{
+			WeekStart:          week,
+			PullRequestMetrics: summarizePullRequests(byWeek[week.Unix()]),
+		})
+	}
+	return resp, nil
+}
+
+// GetDORAMetrics returns deployment frequency, lead time for changes, change failure rate
+// and time to restore within the filter's range (default: last 90 days), overall and per
+// repository. Production deployment attempts are terminal deployment statuses for the
+// repository's production environments and completed runs of the configured deploy
+// workflows. Lead time runs from pull request creation to the first successful production
+// deployment at or after its merge; time to restore runs from the first failure of a streak
+// to the next successful deployment.
+func (s *MetricsService) GetDORAMetrics(filter model.EventFilter) (*model.DORAMetricsResponse, error) {
+	applyDefaultRange(&filter, defaultMetricsRange)
+	span := filter.Until.Sub(*filter.Since)
+	if span > maxMetricsRange {
+		return nil, ErrRangeTooLong
+	}
+	outcomes, err := s.repo.ListDeploymentOutcomes(filter, maxMetricsDeployments+1)
+	if err != nil {
+		return nil, err
+	}
+	prs, err := s.repo.ListMergedPullRequests(filter, maxMetricsPullRequests+1)
+	if err != nil {
+		return nil, err
+	}
+	resp := &model.DORAMetricsResponse{
+		Since:     *filter.Since,
+		Until:     *filter.Until,
+		Repos:     []model.RepoDORAMetrics{},
+		Truncated: len(outcomes) > maxMetricsDeployments || len(prs) > maxMetricsPullRequests,
+	}
+	if len(outcomes) > maxMetricsDeployments {
+		outcomes = outcomes[:maxMetricsDeployments]
+	}
+	if len(prs) > maxMetricsPullRequests {
+		prs = prs[:maxMetricsPullRequests]
+	}
+	attemptsByRepo := make(map[string][]repository.DeploymentOutcome)
+	for _, o := range outcomes {
+		if s.dora.isProduction(o) {
+			attemptsByRepo[o.RepoName] = append(attemptsByRepo[o.RepoName], o)
+		}
+	}
+	prsByRepo := make(map[string][]repository.MergedPullRequest)
+	for _, pr := range prs {
+		prsByRepo[pr.RepoName] = append(prsByRepo[pr.RepoName], pr)
+	}
+	repos := make(map[string]bool)
+	for repo := range attemptsByRepo {
+		repos[repo] = true
+	}
+	for repo := range prsByRepo {
+		repos[repo] = true
+	}
+	var leadTimes, restoreTimes []time.Duration
+	for _, repo := range sortedKeys(repos) {
+		metrics, repoLeadTimes, repoRestoreTimes := computeDORA(attemptsByRepo[repo], prsByRepo[repo], span)
+		leadTimes = append(leadTimes, repoLeadTimes...)
+		restoreTimes = append(restoreTimes, repoRestoreTimes...)
+		resp.Overall.DeploymentFrequency.Deployments += metrics.DeploymentFrequency.Deployments
+		resp.Overall.ChangeFailureRate.Failed += metrics.ChangeFailureRate.Failed
+		resp.Overall.ChangeFailureRate.Total += metrics.ChangeFailureRate.Total
+		resp.Overall.UndeployedChanges += metrics.UndeployedChanges
+		resp.Overall.UnresolvedFailures += metrics.UnresolvedFailures
+		resp.Repos = append(resp.Repos, model.RepoDORAMetrics{
+			RepoName:               repo,
+			ProductionEnvironments: s.dora.productionEnvironments(repo),
+			DORAMetrics:            metrics,
+		})
+	}
+	resp.Overall.DeploymentFrequency.PerDay = perDay(resp.Overall.DeploymentFrequency.Deployments, span)
+	resp.Overall.ChangeFailureRate.Rate = failureRate(resp.Overall.ChangeFailureRate)
+	resp.Overall.LeadTimeForChanges = summarizeDurations(leadTimes)
+	resp.Overall.TimeToRestore = summarizeDurations(restoreTimes)
+	return resp, nil
+}
+
+// computeDORA computes the metrics of a single repository from its production deployment
+// attempts (oldest first) and merged pull requests. It also returns the raw lead and
+// restore times so they can be aggregated across repositories.
+func computeDORA(attempts []repository.DeploymentOutcome, prs []repository.MergedPullRequest, span time.Duration) (model.DORAMetrics, []time.Duration, []time.Duration) {
+	var metrics model.DORAMetrics
+	var successes []time.Time
+	var restoreTimes []time.Duration
+	var failingSince *time.Time
+	for _, a := range attempts {
+		metrics.ChangeFailureRate.Total++
+		if !a.Success {
+			metrics.ChangeFailureRate.Failed++
+			if failingSince == nil {
+				at := a.At
+				failingSince = &at
+			}
+			continue
+		}
+		successes = append(successes, a.At)
+		if failingSince != nil {
+			restoreTimes = append(restoreTimes, a.At.Sub(*failingSince))
+			failingSince = nil
+		}
+	}
+	if failingSince != nil {
+		metrics.UnresolvedFailures = 1
+	}
+	var leadTimes []time.Duration
+	for _, pr := range prs {
+		i := sort.Search(len(successes), func(i int) bool { return !successes[i].Before(pr.MergedAt) })
+		if i == len(successes) {
+			metrics.UndeployedChanges++
+			continue
+		}
+		leadTimes = append(leadTimes, successes[i].Sub(pr.CreatedAt))
+	}
+	metrics.DeploymentFrequency = model.DeploymentFrequency{
+		Deployments: len(successes),
+		PerDay:      perDay(len(successes), span),
+	}
+	metrics.ChangeFailureRate.Rate = failureRate(metrics.ChangeFailureRate)
+	metrics.LeadTimeForChanges = summarizeDurations(slices.Clone(leadTimes))
+	metrics.TimeToRestore = summarizeDurations(slices.Clone(restoreTimes))
+	return metrics, leadTimes, restoreTimes
+}
+
+func perDay(count int, span time.Duration) float64 {
+	days := span.Hours() / 24
+	if days <= 0 {
+		return 0
+	}
+	return math.Round(float64(count)/days*100) / 100
+}
+
+func failureRate(cfr model.ChangeFailureRate) *float64 {
+	if cfr.Total == 0 {
+		return nil
+	}
+	rate := math.Round(float64(cfr.Failed)/float64(cfr.Total)*1000) / 1000
+	return &rate
+}
+
+func summarizePullRequests(prs []repository.MergedPullRequest) model.PullRequestMetrics {
+	var toReview, toMerge []time.Duration
+	sizes := make([]int, 0, len(prs))
+	distribution := make([]model.StatCount, len(prSizeBuckets))
+	for i, b := range prSizeBuckets {
+		distribution[i].Key = b.label
+	}
+	for _, pr := range prs {
+		toMerge = append(toMerge, pr.MergedAt.Sub(pr.CreatedAt))
+		if pr.FirstReviewAt != nil {
+			toReview = append(toReview, pr.FirstReviewAt.Sub(pr.CreatedAt))
+		}
+		size := pr.Additions + pr.Deletions
+		sizes = append(sizes, size)
+		for i, b := range prSizeBuckets {
+			if size < b.limit {
+				distribution[i].Count++
+				break
+			}
+		}
+	}
+	metrics := model.PullRequestMetrics{
+		PullRequests:      len(prs),
+		TimeToFirstReview: summarizeDurations(toReview),
+		TimeToMerge:       summarizeDurations(toMerge),
+		Size:              model.SizeStats{Distribution: distribution},
+	}
+	if len(sizes) > 0 {
+		sort.Ints(sizes)
+		p50, p90 := sizes[percentileIndex(len(sizes), 0.5)], sizes[percentileIndex(len(sizes), 0.9)]
+		metrics.Size.P50, metrics.Size.P90 = &p50, &p90
+	}
+	return metrics
+}
+
+func summarizeDurations(durations []time.Duration) model.DurationStats {
+	stats := model.DurationStats{Count: len(durations)}
+	if len(durations) == 0 {
+		return stats
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	p50 := int64(durations[percentileIndex(len(durations), 0.5)] / time.Second)
+	p90 := int64(durations[percentileIndex(len(durations), 0.9)] / time.Second)
+	stats.P50Seconds, stats.P90Seconds = &p50, &p90
+	return stats
+}
+
+// percentileIndex returns the nearest-rank index of percentile p in a sorted slice of length n.
+func percentileIndex(n int, p float64) int {
+	idx := int(math.Ceil(p*float64(n))) - 1
+	return max(0, min(idx, n-1))
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/repository"
+)
+
+func TestSummarizePullRequests(t *testing.T) {
+	base := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	review := func(d time.Duration) *time.Time {
+		t := base.Add(d)
+		return &t
+	}
+	prs := []repository.MergedPullRequest{
+		{CreatedAt: base, MergedAt: base.Add(1 * time.Hour), FirstReviewAt: review(10 * time.Minute), Additions: 3, Deletions: 2},
+		{CreatedAt: base, MergedAt: base.Add(2 * time.Hour), FirstReviewAt: review(20 * time.Minute), Additions: 30},
+		{CreatedAt: base, MergedAt: base.Add(3 * time.Hour), Additions: 200, Deletions: 100},
+		{CreatedAt: base, MergedAt: base.Add(10 * time.Hour), FirstReviewAt: review(5 * time.Hour), Additions: 2000},
+	}
+
+	metrics := summarizePullRequests(prs)
+
+	if metrics.PullRequests != 4 {
+		t.Errorf("expected 4 pull requests, got %d", metrics.PullRequests)
+	}
+	if metrics.TimeToMerge.Count != 4 || *metrics.TimeToMerge.P50Seconds != 7200 || *metrics.TimeToMerge.P90Seconds != 36000 {
+		t.Errorf("unexpected time to merge: %+v", metrics.TimeToMerge)
+	}
+	if metrics.TimeToFirstReview.Count != 3 || *metrics.TimeToFirstReview.P50Seconds != 1200 || *metrics.TimeToFirstReview.P90Seconds != 18000 {
+		t.Errorf("unexpected time to first review: %+v", metrics.TimeToFirstReview)
+	}
+	if *metrics.Size.P50 != 30 || *metrics.Size.P90 != 2000 {
+		t.Errorf("unexpected size percentiles: p50=%d p90=%d", *metrics.Size.P50, *metrics.Size.P90)
+	}
+	expected := map[string]int{"XS": 1, "S": 1, "M": 0, "L": 1, "XL": 1}
+	for _, bucket := range metrics.Size.Distribution {
+		if bucket.Count != expected[bucket.Key] {
+			t.Errorf("expected %d pull requests of size %s, got %d", expected[bucket.Key], bucket.Key, bucket.Count)
+		}
+	}
+}
+
+func TestSummarizePullRequestsEmpty(t *testing.T) {
+	metrics := summarizePullRequests(nil)
+	if metrics.PullRequests != 0 || metrics.TimeToMerge.P50Seconds != nil || metrics.Size.P50 != nil {
+		t.Errorf("expected empty metrics, got %+v", metrics)
+	}
+}
+
+func TestComputeDORA(t *testing.T) {
+	base := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	at := func(h int) time.Time { return base.Add(time.Duration(h) * time.Hour) }
+	attempts := []repository.DeploymentOutcome{
+		{At: at(1), Success: true},
+		{At: at(5), Success: false},
+		{At: at(6), Success: false},
+		{At: at(8), Success: true},
+		{At: at(20), Success: false},
+	}
+	prs := []repository.MergedPullRequest{
+		{CreatedAt: at(0), MergedAt: at(1)},
+		{CreatedAt: at(2), MergedAt: at(4)},
+		{CreatedAt: at(10), MergedAt: at(12)},
+	}
+
+	metrics, leadTimes, restoreTimes := computeDORA(attempts, prs, 2*24*time.Hour)
+
+	if metrics.DeploymentFrequency.Deployments != 2 || metrics.DeploymentFrequency.PerDay != 1 {
+		t.Errorf("unexpected deployment frequency: %+v", metrics.DeploymentFrequency)
+	}
+	if metrics.ChangeFailureRate.Failed != 3 || metrics.ChangeFailureRate.Total != 5 || *metrics.ChangeFailureRate.Rate != 0.6 {
+		t.Errorf("unexpected change failure rate: %+v", metrics.ChangeFailureRate)
+	}
+	if len(restoreTimes) != 1 || restoreTimes[0] != 3*time.Hour || metrics.UnresolvedFailures != 1 {
+		t.Errorf("unexpected restore times %v (unresolved %d)", restoreTimes, metrics.UnresolvedFailures)
+	}
+	if len(leadTimes) != 2 || leadTimes[0] != 1*time.Hour || leadTimes[1] != 6*time.Hour || metrics.UndeployedChanges != 1 {
+		t.Errorf("unexpected lead times %v (undeployed %d)", leadTimes, metrics.UndeployedChanges)
+	}
+}
+
+func TestDORAConfigIsProduction(t *testing.T) {
+	cfg := DORAConfig{
+		ProductionEnvironments: map[string][]string{"": {"production"}, "acme/api": {"prod", "prod-eu"}},
+		DeployWorkflows:        []string{"Deploy"},
+	}
+	tests := []struct {
+		outcome  repository.DeploymentOutcome
+		expected bool
+	}{
+		{repository.DeploymentOutcome{RepoName: "acme/web", Environment: "production"}, true},
+		{repository.DeploymentOutcome{RepoName: "acme/web", Environment: "staging"}, false},
+		{repository.DeploymentOutcome{RepoName: "acme/api", Environment: "production"}, false},
+		{repository.DeploymentOutcome{RepoName: "acme/api", Environment: "prod-eu"}, true},
+		{repository.DeploymentOutcome{RepoName: "acme/api", Workflow: "Deploy"}, true},
+		{repository.DeploymentOutcome{RepoName: "acme/api", Workflow: "CI"}, false},
+	}
+	for _, tt := range tests {
+		if got := cfg.isProduction(tt.outcome); got != tt.expected {
+			t.Errorf("isProduction(%+v) = %v, expected %v", tt.outcome, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/middleware"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/repository"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/sse"
+)
+
+// PresenceService reports the users connected to the live streams of this replica from a
+// browser session and pushes presence messages when they change. Presence is not shared
+// between replicas, so it must only be used with a single replica.
+type PresenceService struct {
+	hub   *sse.Hub
+	users *repository.UserRepository
+}
+
+// NewPresenceService creates a new PresenceService.
+func NewPresenceService(hub *sse.Hub, users *repository.UserRepository) *PresenceService {
+	return &PresenceService{hub: hub, users: users}
+}
+
+// Online returns the users with open live connections, ordered by login.
+func (s *PresenceService) Online() (*model.PresenceResponse, error) {
+	online := s.hub.Online()
+	ids := make([]int64, 0, len(online))
+	for id := range online {
+		ids = append(ids, id)
+	}
+	profiles, err := s.users.ListProfiles(ids)
+	if err != nil {
+		return nil, err
+	}
+	users := make([]model.PresenceUser, len(profiles))
+	for i, p := range profiles {
+		users[i] = model.PresenceUser{UserResponse: p, Connections: online[p.ID]}
+	}
+	return &model.PresenceResponse{Users: users}, nil
+}
+
+// Run publishes the online users as a presence message whenever a user comes online or
+// goes offline, until ctx is done. Should be called in a goroutine.
+func (s *PresenceService) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.hub.PresenceChanged():
+		}
+		presence, err := s.Online()
+		if err != nil {
+			middleware.LogEvent("error", "failed to list online users", map[string]interface{}{
+				"error": err.Error(),
+			})
+			continue
+		}
+		s.hub.Publish(sse.Message{Type: sse.MessagePresence, Data: presence})
+	}
+}
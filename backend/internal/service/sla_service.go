@@ -0,0 +1,285 @@
+package service
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/repository"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/sla"
+)
+
+const (
+	defaultSLARange = 30 * 24 * time.Hour
+	maxSLAIssues    = 5000
+	maxSLAActivity  = 50000
+)
+
+// ErrTooMuchActivity is returned when the tracked issues have more comments and closes
+// than the SLA evaluation loads.
+var ErrTooMuchActivity = errors.New("too much issue activity to evaluate; narrow the time range or repositories")
+
+// maintainerAssociations are the GitHub author associations treated as maintainers when no
+// maintainer list is configured for a repository.
+var maintainerAssociations = []string{"OWNER", "MEMBER", "COLLABORATOR"}
+
+// SLAConfig defines the issue first-response SLA.
+type SLAConfig struct {
+	// Maintainers maps a repository full name to the logins whose responses count;
+	// the "" key holds the default for repositories without an entry. Repositories with no
+	// list fall back to the author association of the response.
+	Maintainers map[string][]string
+	// Calendar defines the business hours the SLA clock runs during.
+	Calendar *sla.Calendar
+	// ResponseTarget is the first-response target in business time.
+	// Zero means one business day.
+	ResponseTarget time.Duration
+}
+
+func (c SLAConfig) target() time.Duration {
+	if c.ResponseTarget > 0 {
+		return c.ResponseTarget
+	}
+	return c.Calendar.DayLength()
+}
+
+func (c SLAConfig) maintainers(repo string) []string {
+	if logins, ok := c.Maintainers[repo]; ok {
+		return logins
+	}
+	return c.Maintainers[""]
+}
+
+// isMaintainer reports whether login is a maintainer of repo. association is the login's
+// author association on the event, or "" when the event does not carry one.
+func (c SLAConfig) isMaintainer(repo string, login string, association string) bool {
+	if isBotLogin(login) {
+		return false
+	}
+	if logins := c.maintainers(repo); len(logins) > 0 {
+		return slices.ContainsFunc(logins, func(l string) bool { return strings.EqualFold(l, login) })
+	}
+	return slices.Contains(maintainerAssociations, association)
+}
+
+// issueOutcome is the SLA evaluation of a single issue.
+type issueOutcome struct {
+	issue     repository.OpenedIssue
+	dueAt     time.Time
+	responded bool
+	// responseTime is the business time to the first maintainer response.
+	responseTime time.Duration
+	withinTarget bool
+	// closed is set when the issue was closed without a maintainer response.
+	closed   bool
+	breached bool
+}
+
+// SLAService evaluates the issue first-response SLA from stored events.
+type SLAService struct {
+	repo   *repository.SLARepository
+	config SLAConfig
+}
+
+// NewSLAService creates a new SLAService.
+func NewSLAService(repo *repository.SLARepository, config SLAConfig) *SLAService {
+	return &SLAService{repo: repo, config: config}
+}
+
+// GetIssueSLA returns first-response SLA compliance for issues opened within the filter's
+// range (default: last 30 days), overall and per repository. Only the repo/owner filters
+// and the range apply. Issues opened by maintainers or bots are not tracked.
+func (s *SLAService) GetIssueSLA(filter model.EventFilter) (*model.IssueSLAResponse, error) {
+	applyDefaultRange(&filter, defaultSLARange)
+	outcomes, truncated, err := s.evaluate(filter, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	resp := &model.IssueSLAResponse{
+		Since:         *filter.Since,
+		Until:         *filter.Until,
+		Timezone:      s.config.Calendar.Location().String(),
+		BusinessHours: s.config.Calendar.String(),
+		TargetSeconds: int64(s.config.target() / time.Second),
+		Repos:         []model.RepoSLASummary{},
+		Truncated:     truncated,
+	}
+	byRepo := make(map[string][]issueOutcome)
+	for _, o := range outcomes {
+		byRepo[o.issue.RepoName] = append(byRepo[o.issue.RepoName], o)
+	}
+	resp.Overall = summarizeSLA(outcomes)
+	for _, repo := range sortedKeys(byRepo) {
+		resp.Repos = append(resp.Repos, model.RepoSLASummary{
+			RepoName:   repo,
+			SLASummary: summarizeSLA(byRepo[repo]),
+		})
+	}
+	return resp, nil
+}
+
+// ListBreaches returns the issues opened within the filter's range (default: last 30 days)
+// that are still open without a maintainer response past their due time, most overdue first.
+func (s *SLAService) ListBreaches(filter model.EventFilter) (*model.SLABreachesResponse, error) {
+	now := time.Now().UTC()
+	applyDefaultRange(&filter, defaultSLARange)
+	outcomes, truncated, err := s.evaluate(filter, now)
+	if err != nil {
+		return nil, err
+	}
+	resp := &model.SLABreachesResponse{
+		TargetSeconds: int64(s.config.target() / time.Second),
+		Breaches:      []model.SLABreach{},
+		Truncated:     truncated,
+	}
+	for _, o := range outcomes {
+		if o.responded || o.closed || !o.breached {
+			continue
+		}
+		resp.Breaches = append(resp.Breaches, model.SLABreach{
+			RepoName:       o.issue.RepoName,
+			IssueNumber:    o.issue.Number,
+			Title:          o.issue.Title,
+			HTMLURL:        o.issue.HTMLURL,
+			Author:         o.issue.Author,
+			OpenedAt:       o.issue.OpenedAt,
+			DueAt:          o.dueAt,
+			OverdueSeconds: int64(s.config.Calendar.BusinessDuration(o.dueAt, now) / time.Second),
+		})
+	}
+	slices.SortStableFunc(resp.Breaches, func(a, b model.SLABreach) int {
+		return a.DueAt.Compare(b.DueAt)
+	})
+	return resp, nil
+}
+
+// evaluate loads the issues opened within the filter's range (Since and Until must be set)
+// and the later activity on the tracked ones, and evaluates each tracked issue as of now.
+func (s *SLAService) evaluate(filter model.EventFilter, now time.Time) ([]issueOutcome, bool, error) {
+	if filter.Until.Sub(*filter.Since) > maxMetricsRange {
+		return nil, false, ErrRangeTooLong
+	}
+	issues, err := s.repo.ListOpenedIssues(model.EventFilter{
+		Repos:         filter.Repos,
+		Owners:        filter.Owners,
+		ExcludeRepos:  filter.ExcludeRepos,
+		ExcludeOwners: filter.ExcludeOwners,
+		Since:         filter.Since,
+		Until:         filter.Until,
+	}, maxSLAIssues+1)
+	if err != nil {
+		return nil, false, err
+	}
+	truncated := len(issues) > maxSLAIssues
+	if truncated {
+		issues = issues[:maxSLAIssues]
+	}
+	seen := make(map[string]bool)
+	var tracked []repository.OpenedIssue
+	numbers := make(map[string][]int)
+	for _, issue := range issues {
+		key := issueKey(issue.RepoName, issue.Number)
+		if seen[key] || isBotLogin(issue.Author) ||
+			s.config.isMaintainer(issue.RepoName, issue.Author, issue.AuthorAssociation) {
+			continue
+		}
+		seen[key] = true
+		tracked = append(tracked, issue)
+		numbers[issue.RepoName] = append(numbers[issue.RepoName], issue.Number)
+	}
+	activity, err := s.repo.ListIssueActivity(numbers, *filter.Since, maxSLAActivity+1)
+	if err != nil {
+		return nil, false, err
+	}
+	// Dropping activity would turn answered issues into breaches, so refuse instead.
+	if len(activity) > maxSLAActivity {
+		return nil, false, ErrTooMuchActivity
+	}
+	activityByIssue := make(map[string][]repository.IssueActivity)
+	for _, a := range activity {
+		key := issueKey(a.RepoName, a.Number)
+		activityByIssue[key] = append(activityByIssue[key], a)
+	}
+	outcomes := make([]issueOutcome, 0, len(tracked))
+	for _, issue := range tracked {
+		outcomes = append(outcomes, s.evaluateIssue(issue, activityByIssue[issueKey(issue.RepoName, issue.Number)], now))
+	}
+	return outcomes, truncated, nil
+}
+
+// evaluateIssue evaluates one issue against its comments and closes (oldest first).
+// A comment counts as a response when its author is a maintainer; a close counts when the
+// closer is a listed maintainer or, with no list configured, anyone other than the issue
+// author (closing someone else's issue requires triage access).
+func (s *SLAService) evaluateIssue(issue repository.OpenedIssue, activity []repository.IssueActivity, now time.Time) issueOutcome {
+	cal := s.config.Calendar
+	o := issueOutcome{issue: issue, dueAt: cal.Add(issue.OpenedAt, s.config.target())}
+	hasList := len(s.config.maintainers(issue.RepoName)) > 0
+	for _, a := range activity {
+		if a.At.Before(issue.OpenedAt) {
+			continue
+		}
+		isResponse := false
+		if a.SenderLogin != issue.Author {
+			if a.Closed && !hasList {
+				isResponse = !isBotLogin(a.SenderLogin)
+			} else {
+				isResponse = s.config.isMaintainer(issue.RepoName, a.SenderLogin, a.AuthorAssociation)
+			}
+		}
+		if isResponse {
+			o.responded = true
+			o.responseTime = cal.BusinessDuration(issue.OpenedAt, a.At)
+			o.withinTarget = !a.At.After(o.dueAt)
+			o.breached = !o.withinTarget
+			return o
+		}
+		if a.Closed {
+			o.closed = true
+			return o
+		}
+	}
+	o.breached = now.After(o.dueAt)
+	return o
+}
+
+func summarizeSLA(outcomes []issueOutcome) model.SLASummary {
+	summary := model.SLASummary{Issues: len(outcomes)}
+	var responseTimes []time.Duration
+	for _, o := range outcomes {
+		switch {
+		case o.responded:
+			summary.Responded++
+			responseTimes = append(responseTimes, o.responseTime)
+			if o.withinTarget {
+				summary.WithinTarget++
+			} else {
+				summary.Breached++
+			}
+		case o.closed:
+		case o.breached:
+			summary.Breached++
+		default:
+			summary.Pending++
+		}
+	}
+	if decided := summary.WithinTarget + summary.Breached; decided > 0 {
+		rate := math.Round(float64(summary.WithinTarget)/float64(decided)*1000) / 1000
+		summary.ComplianceRate = &rate
+	}
+	summary.ResponseTime = summarizeDurations(responseTimes)
+	return summary
+}
+
+func issueKey(repo string, number int) string {
+	return repo + "#" + strconv.Itoa(number)
+}
+
+// isBotLogin reports whether login belongs to a GitHub App bot account.
+func isBotLogin(login string) bool {
+	return strings.HasSuffix(login, "[bot]")
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/repository"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/sla"
+)
+
+var (
+	openedIssueColumns   = []string{"repo_name", "title", "html_url", "event_data", "occurred_at"}
+	issueActivityColumns = []string{"repo_name", "event_type", "sender_login", "event_data", "occurred_at"}
+)
+
+func newTestSLAService(t *testing.T) (*SLAService, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock := newMockDB(t)
+	cal, err := sla.NewCalendar("09:00-18:00", "Mon-Fri", "UTC")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	config := SLAConfig{Maintainers: map[string][]string{"": {"bob"}}, Calendar: cal}
+	return NewSLAService(repository.NewSLARepository(db), config), mock
+}
+
+func TestGetIssueSLALoadsActivityOfTrackedIssues(t *testing.T) {
+	since := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)
+	opened := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	svc, mock := newTestSLAService(t)
+
+	mock.ExpectQuery(`action = 'opened' AND issue_number IS NOT NULL`).
+		WillReturnRows(sqlmock.NewRows(openedIssueColumns).
+			AddRow("acme/web", "Crash", "https://github.com/acme/web/issues/3", `{"issue_number":3,"issue_author":"carol"}`, opened).
+			AddRow("acme/api", "Bug", "https://github.com/acme/api/issues/1", `{"issue_number":1,"issue_author":"carol"}`, opened).
+			AddRow("acme/api", "Plan", "https://github.com/acme/api/issues/2", `{"issue_number":2,"issue_author":"bob"}`, opened))
+	mock.ExpectQuery(`WHERE \(\(repo_name = \? AND issue_number IN \(\?\)\) OR \(repo_name = \? AND issue_number IN \(\?\)\)\) AND occurred_at >= \?`).
+		WithArgs("acme/api", 1, "acme/web", 3, since, maxSLAActivity+1).
+		WillReturnRows(sqlmock.NewRows(issueActivityColumns).
+			AddRow("acme/api", "issue_comment", "bob", `{"issue_number":1}`, opened.Add(time.Hour)))
+
+	resp, err := svc.GetIssueSLA(model.EventFilter{Since: &since, Until: &until})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Overall.Issues != 2 || resp.Overall.Responded != 1 || resp.Overall.WithinTarget != 1 {
+		t.Errorf("expected 2 tracked issues with 1 answered within target, got %+v", resp.Overall)
+	}
+	if resp.Truncated {
+		t.Errorf("expected the response not to be truncated")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestGetIssueSLASkipsActivityWithoutTrackedIssues(t *testing.T) {
+	since := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)
+	svc, mock := newTestSLAService(t)
+
+	mock.ExpectQuery(`action = 'opened' AND issue_number IS NOT NULL`).
+		WillReturnRows(sqlmock.NewRows(openedIssueColumns).
+			AddRow("acme/api", "Plan", "https://github.com/acme/api/issues/2", `{"issue_number":2,"issue_author":"bob"}`, since))
+
+	resp, err := svc.GetIssueSLA(model.EventFilter{Since: &since, Until: &until})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Overall.Issues != 0 {
+		t.Errorf("expected no tracked issues, got %d", resp.Overall.Issues)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
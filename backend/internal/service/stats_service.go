@@ -0,0 +1,263 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/repository"
+)
+
+// Supported time bucket sizes for the stats series.
+const (
+	BucketHour = "hour"
+	BucketDay  = "day"
+	BucketWeek = "week"
+)
+
+const (
+	defaultStatsRange    = 7 * 24 * time.Hour
+	maxStatsBuckets      = 1000
+	statsCacheTTL        = 30 * time.Second
+	statsCacheMaxEntries = 256
+	// statsSlot is the granularity used in SQL. Every real-world UTC offset is a multiple
+	// of 15 minutes, so slots can be folded into local hour/day/week buckets exactly.
+	statsSlot = 15 * time.Minute
+)
+
+// ErrTooManyBuckets is returned when the requested range would produce too many series points.
+var ErrTooManyBuckets = fmt.Errorf("time range produces more than %d buckets", maxStatsBuckets)
+
+// ErrInvalidBucket is returned for a bucket size other than hour, day or week.
+var ErrInvalidBucket = errors.New("bucket must be one of hour, day, week")
+
+// StatsQuery describes a stats request. A nil Filter.Since/Until defaults to the
+// last 7 days, an empty Bucket is chosen from the range length, and a nil Location means UTC.
+type StatsQuery struct {
+	Filter   model.EventFilter
+	Bucket   string
+	Location *time.Location
+	Limit    int
+}
+
+type statsCacheEntry struct {
+	stats   *model.StatsResponse
+	expires time.Time
+}
+
+// StatsService computes aggregated event statistics and caches them briefly.
+type StatsService struct {
+	repo  *repository.StatsRepository
+	mu    sync.Mutex
+	cache map[string]statsCacheEntry
+	// generation is incremented by Invalidate; statistics computed during an earlier
+	// generation are not cached.
+	generation uint64
+}
+
+// NewStatsService creates a new StatsService.
+func NewStatsService(repo *repository.StatsRepository) *StatsService {
+	return &StatsService{repo: repo, cache: make(map[string]statsCacheEntry)}
+}
+
+// GetStats returns grouped counts and a time-bucketed series for the query.
+// Identical queries within statsCacheTTL are served from memory unless Invalidate is
+// called in between. Every call returns its own copy, which the caller may modify.
+func (s *StatsService) GetStats(q StatsQuery) (*model.StatsResponse, error) {
+	if q.Location == nil {
+		q.Location = time.UTC
+	}
+	applyDefaultRange(&q.Filter, defaultStatsRange)
+	span := q.Filter.Until.Sub(*q.Filter.Since)
+	if q.Bucket == "" {
+		q.Bucket = defaultBucket(span)
+	}
+	step, ok := map[string]time.Duration{BucketHour: time.Hour, BucketDay: 24 * time.Hour, BucketWeek: 7 * 24 * time.Hour}[q.Bucket]
+	if !ok {
+		return nil, ErrInvalidBucket
+	}
+	if span/step > maxStatsBuckets {
+		return nil, ErrTooManyBuckets
+	}
+	key := statsCacheKey(q)
+	cached, generation := s.getCached(key)
+	if cached != nil {
+		return cached, nil
+	}
+	stats, err := s.computeStats(q)
+	if err != nil {
+		return nil, err
+	}
+	s.putCached(key, generation, copyStats(stats))
+	return stats, nil
+}
+
+// Invalidate drops the cached statistics, including those being computed, so that the
+// next GetStats reflects the events stored so far. It is called when events are created
+// or deleted.
+func (s *StatsService) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.generation++
+	s.cache = make(map[string]statsCacheEntry)
+}
+
+func (s *StatsService) computeStats(q StatsQuery) (*model.StatsResponse, error) {
+	stats := &model.StatsResponse{
+		Since:    q.Filter.Since.In(q.Location),
+		Until:    q.Filter.Until.In(q.Location),
+		Timezone: q.Location.String(),
+		Bucket:   q.Bucket,
+	}
+	groups := []struct {
+		group repository.StatsGroup
+		dest  *[]model.StatCount
+	}{
+		{repository.GroupByRepo, &stats.ByRepo},
+		{repository.GroupByEventType, &stats.ByEventType},
+		{repository.GroupByAction, &stats.ByAction},
+		{repository.GroupBySender, &stats.BySender},
+	}
+	for _, g := range groups {
+		counts, err := s.repo.CountByGroup(q.Filter, g.group, q.Limit)
+		if err != nil {
+			return nil, err
+		}
+		*g.dest = counts
+	}
+	origin := bucketStart(q.Filter.Since.In(q.Location), q.Bucket)
+	slots, err := s.repo.CountByInterval(q.Filter, origin.UTC(), statsSlot)
+	if err != nil {
+		return nil, err
+	}
+	stats.Series, stats.Total = foldSlots(slots, origin, *q.Filter.Until, q.Bucket)
+	return stats, nil
+}
+
+// foldSlots adds up the statsSlot counts measured from origin into the buckets starting
+// at origin (in its location) and ending before until. It returns every bucket, empty ones
+// included, and the total count.
+func foldSlots(slots []repository.SlotCount, origin time.Time, until time.Time, bucket string) ([]model.SeriesPoint, int) {
+	counts := make(map[int64]int)
+	total := 0
+	for _, slot := range slots {
+		t := origin.Add(time.Duration(slot.Slot) * statsSlot)
+		counts[bucketStart(t, bucket).Unix()] += slot.Count
+		total += slot.Count
+	}
+	series := []model.SeriesPoint{}
+	for t := origin; t.Before(until); t = nextBucket(t, bucket) {
+		series = append(series, model.SeriesPoint{Start: t, Count: counts[t.Unix()]})
+	}
+	return series, total
+}
+
+// applyDefaultRange fills in a missing Until with the current time and a missing Since
+// with span before Until. Until is rounded up to the next minute so repeated requests
+// produce identical filters (and cache keys).
+func applyDefaultRange(filter *model.EventFilter, span time.Duration) {
+	if filter.Until == nil {
+		until := time.Now().UTC().Truncate(time.Minute).Add(time.Minute)
+		filter.Until = &until
+	}
+	if filter.Since == nil {
+		since := filter.Until.Add(-span)
+		filter.Since = &since
+	}
+}
+
+func defaultBucket(span time.Duration) string {
+	switch {
+	case span <= 48*time.Hour:
+		return BucketHour
+	case span <= 90*24*time.Hour:
+		return BucketDay
+	default:
+		return BucketWeek
+	}
+}
+
+// bucketStart returns the start of the bucket containing t, in t's location.
+// Weeks start on Monday.
+func bucketStart(t time.Time, bucket string) time.Time {
+	switch bucket {
+	case BucketHour:
+		// Subtract within-hour offset instead of using time.Date so the repeated
+		// hour at a DST fall-back transition yields two distinct buckets.
+		return t.Add(-time.Duration(t.Minute())*time.Minute - time.Duration(t.Second())*time.Second - time.Duration(t.Nanosecond()))
+	case BucketWeek:
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	default:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	}
+}
+
+func nextBucket(t time.Time, bucket string) time.Time {
+	switch bucket {
+	case BucketHour:
+		return t.Add(time.Hour)
+	case BucketWeek:
+		return bucketStart(t.AddDate(0, 0, 7), bucket)
+	default:
+		return bucketStart(t.AddDate(0, 0, 1), bucket)
+	}
+}
+
+func statsCacheKey(q StatsQuery) string {
+	data, _ := json.Marshal(struct {
+		Filter   model.EventFilter
+		Bucket   string
+		Location string
+		Limit    int
+	}{q.Filter, q.Bucket, q.Location.String(), q.Limit})
+	return string(data)
+}
+
+// getCached returns a copy of the cached statistics for key, or nil, and the current
+// cache generation.
+func (s *StatsService) getCached(key string) (*model.StatsResponse, uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.cache[key]
+	if !ok || time.Now().After(entry.expires) {
+		return nil, s.generation
+	}
+	return copyStats(entry.stats), s.generation
+}
+
+// copyStats returns a copy of stats that shares no slices with it.
+func copyStats(stats *model.StatsResponse) *model.StatsResponse {
+	c := *stats
+	c.ByRepo = slices.Clone(stats.ByRepo)
+	c.ByEventType = slices.Clone(stats.ByEventType)
+	c.ByAction = slices.Clone(stats.ByAction)
+	c.BySender = slices.Clone(stats.BySender)
+	c.Series = slices.Clone(stats.Series)
+	return &c
+}
+
+// putCached caches stats for key unless the cache was invalidated since generation.
+func (s *StatsService) putCached(key string, generation uint64, stats *model.StatsResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if generation != s.generation {
+		return
+	}
+	now := time.Now()
+	if len(s.cache) >= statsCacheMaxEntries {
+		for k, entry := range s.cache {
+			if now.After(entry.expires) {
+				delete(s.cache, k)
+			}
+		}
+		if len(s.cache) >= statsCacheMaxEntries {
+			s.cache = make(map[string]statsCacheEntry)
+		}
+	}
+	s.cache[key] = statsCacheEntry{stats: stats, expires: now.Add(statsCacheTTL)}
+}
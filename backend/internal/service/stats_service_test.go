@@ -0,0 +1,150 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/repository"
+)
+
+func TestFoldSlotsAcrossTimeZones(t *testing.T) {
+	load := func(name string) *time.Location {
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			t.Fatalf("failed to load %s: %v", name, err)
+		}
+		return loc
+	}
+	newYork := load("America/New_York")
+	kolkata := load("Asia/Kolkata")
+	tokyo := load("Asia/Tokyo")
+
+	tests := []struct {
+		name   string
+		origin time.Time
+		until  time.Time
+		bucket string
+		slots  []repository.SlotCount
+		// expectedStarts are the starts of the buckets, in UTC.
+		expectedStarts []string
+		expectedCounts []int
+	}{
+		{
+			name:   "Fall-back repeats 01:00 as two hourly buckets",
+			origin: time.Date(2026, 11, 1, 0, 0, 0, 0, newYork),
+			until:  time.Date(2026, 11, 1, 4, 0, 0, 0, newYork),
+			bucket: BucketHour,
+			// 01:30 EDT and 01:30 EST.
+			slots:          []repository.SlotCount{{Slot: 6, Count: 1}, {Slot: 10, Count: 2}},
+			expectedStarts: []string{"2026-11-01T04:00:00Z", "2026-11-01T05:00:00Z", "2026-11-01T06:00:00Z", "2026-11-01T07:00:00Z", "2026-11-01T08:00:00Z"},
+			expectedCounts: []int{0, 1, 2, 0, 0},
+		},
+		{
+			name:   "Spring-forward day is 23 hours long",
+			origin: time.Date(2026, 3, 8, 0, 0, 0, 0, newYork),
+			until:  time.Date(2026, 3, 10, 0, 0, 0, 0, newYork),
+			bucket: BucketDay,
+			// 23:45 EDT on March 8 and 00:15 EDT on March 9.
+			slots:          []repository.SlotCount{{Slot: 91, Count: 1}, {Slot: 93, Count: 1}},
+			expectedStarts: []string{"2026-03-08T05:00:00Z", "2026-03-09T04:00:00Z"},
+			expectedCounts: []int{1, 1},
+		},
+		{
+			name:   "Days start at local midnight with a half-hour offset",
+			origin: time.Date(2026, 3, 1, 0, 0, 0, 0, kolkata),
+			until:  time.Date(2026, 3, 3, 0, 0, 0, 0, kolkata),
+			bucket: BucketDay,
+			// 23:45 and 00:00 IST.
+			slots:          []repository.SlotCount{{Slot: 95, Count: 3}, {Slot: 96, Count: 4}},
+			expectedStarts: []string{"2026-02-28T18:30:00Z", "2026-03-01T18:30:00Z"},
+			expectedCounts: []int{3, 4},
+		},
+		{
+			name:   "Weeks start on Monday in the local time zone",
+			origin: time.Date(2026, 3, 2, 0, 0, 0, 0, tokyo),
+			until:  time.Date(2026, 3, 16, 0, 0, 0, 0, tokyo),
+			bucket: BucketWeek,
+			// Sunday 23:45 and Monday 00:00 JST.
+			slots:          []repository.SlotCount{{Slot: 671, Count: 5}, {Slot: 672, Count: 6}},
+			expectedStarts: []string{"2026-03-01T15:00:00Z", "2026-03-08T15:00:00Z"},
+			expectedCounts: []int{5, 6},
+		},
+		{
+			name:           "Fall-back week keeps its Monday start",
+			origin:         time.Date(2026, 10, 26, 0, 0, 0, 0, newYork),
+			until:          time.Date(2026, 11, 9, 0, 0, 0, 0, newYork),
+			bucket:         BucketWeek,
+			expectedStarts: []string{"2026-10-26T04:00:00Z", "2026-11-02T05:00:00Z"},
+			expectedCounts: []int{0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			series, total := foldSlots(tt.slots, tt.origin, tt.until, tt.bucket)
+			if len(series) != len(tt.expectedStarts) {
+				t.Fatalf("expected %d buckets, got %d: %+v", len(tt.expectedStarts), len(series), series)
+			}
+			expectedTotal := 0
+			for i, point := range series {
+				if got := point.Start.UTC().Format(time.RFC3339); got != tt.expectedStarts[i] {
+					t.Errorf("expected bucket %d to start at %s, got %s", i, tt.expectedStarts[i], got)
+				}
+				if point.Start.Location() != tt.origin.Location() {
+					t.Errorf("expected bucket %d in %s, got %s", i, tt.origin.Location(), point.Start.Location())
+				}
+				if point.Count != tt.expectedCounts[i] {
+					t.Errorf("expected bucket %d to count %d, got %d", i, tt.expectedCounts[i], point.Count)
+				}
+				expectedTotal += tt.expectedCounts[i]
+			}
+			if total != expectedTotal {
+				t.Errorf("expected total %d, got %d", expectedTotal, total)
+			}
+		})
+	}
+}
+
+func TestStatsCacheReturnsCopies(t *testing.T) {
+	s := NewStatsService(nil)
+	s.putCached("key", 0, copyStats(&model.StatsResponse{
+		Total:  3,
+		ByRepo: []model.StatCount{{Key: "acme/api", Count: 3}},
+		Series: []model.SeriesPoint{{Count: 3}},
+	}))
+
+	first, _ := s.getCached("key")
+	first.Total = 0
+	first.ByRepo[0].Count = 0
+	first.Series[0].Count = 0
+	first.ByRepo = append(first.ByRepo, model.StatCount{Key: "acme/web"})
+
+	second, _ := s.getCached("key")
+	if second == first {
+		t.Fatal("expected a new response for every call, got the same pointer")
+	}
+	if second.Total != 3 || len(second.ByRepo) != 1 || second.ByRepo[0].Count != 3 || second.Series[0].Count != 3 {
+		t.Errorf("expected the cached response to be unchanged, got %+v", second)
+	}
+}
+
+func TestStatsCacheInvalidate(t *testing.T) {
+	s := NewStatsService(nil)
+	s.putCached("key", 0, &model.StatsResponse{Total: 3})
+	s.Invalidate()
+	cached, generation := s.getCached("key")
+	if cached != nil {
+		t.Fatalf("expected no cached response after Invalidate, got %+v", cached)
+	}
+
+	// Statistics computed before Invalidate may miss the latest changes.
+	s.putCached("key", 0, &model.StatsResponse{Total: 3})
+	if cached, _ := s.getCached("key"); cached != nil {
+		t.Errorf("expected a response computed before Invalidate not to be cached, got %+v", cached)
+	}
+	s.putCached("key", generation, &model.StatsResponse{Total: 4})
+	if cached, _ := s.getCached("key"); cached == nil || cached.Total != 4 {
+		t.Errorf("expected the response computed after Invalidate to be cached, got %+v", cached)
+	}
+}
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+)
+
+// secretTokenBytes is the number of random bytes in feed and API tokens.
+const secretTokenBytes = 32
+
+// generateSecretToken returns a random hex-encoded token and the hash to store for it.
+func generateSecretToken() (string, string, error) {
+	b := make([]byte, secretTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", errors.New("failed to generate token")
+	}
+	token := hex.EncodeToString(b)
+	return token, hashSecretToken(token), nil
+}
+
+// hashSecretToken returns the hex-encoded SHA-256 hash under which a token is stored.
+func hashSecretToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,176 @@
+// Package sla provides business-hours calculations for response-time SLAs.
+package sla
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// maxCalendarDays bounds the day-by-day walks so malformed ranges cannot loop for long.
+const maxCalendarDays = 3660
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// Calendar describes the working hours an SLA clock runs during: the same daily window
+// (e.g. 09:00-18:00) on a fixed set of weekdays, in a single time zone.
+type Calendar struct {
+	loc         *time.Location
+	startHour   int
+	startMinute int
+	endHour     int
+	endMinute   int
+	days        [7]bool
+	spec        string
+}
+
+// NewCalendar creates a Calendar from a daily window ("09:00-18:00"), a list of weekdays
+// ("Mon-Fri" or "Mon,Tue,Thu") and an IANA time zone name.
+func NewCalendar(hours string, days string, tz string) (*Calendar, error) {
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time zone %q: %w", tz, err)
+	}
+	c := &Calendar{loc: loc, spec: strings.TrimSpace(hours) + " " + strings.TrimSpace(days)}
+	from, to, ok := strings.Cut(hours, "-")
+	if !ok {
+		return nil, fmt.Errorf("invalid business hours %q: expected HH:MM-HH:MM", hours)
+	}
+	if c.startHour, c.startMinute, err = parseClock(from); err != nil {
+		return nil, err
+	}
+	if c.endHour, c.endMinute, err = parseClock(to); err != nil {
+		return nil, err
+	}
+	if c.endHour*60+c.endMinute <= c.startHour*60+c.startMinute {
+		return nil, fmt.Errorf("invalid business hours %q: end must be after start", hours)
+	}
+	if err := c.parseDays(days); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// DayLength returns the length of the daily business window.
+func (c *Calendar) DayLength() time.Duration {
+	return time.Duration((c.endHour*60+c.endMinute)-(c.startHour*60+c.startMinute)) * time.Minute
+}
+
+// String returns the calendar's hours and days as configured, e.g. "09:00-18:00 Mon-Fri".
+func (c *Calendar) String() string {
+	return c.spec
+}
+
+// Location returns the calendar's time zone.
+func (c *Calendar) Location() *time.Location {
+	return c.loc
+}
+
+// BusinessDuration returns how much business time elapses between from and to.
+// It returns 0 if to is not after from.
+func (c *Calendar) BusinessDuration(from time.Time, to time.Time) time.Duration {
+	if !to.After(from) {
+		return 0
+	}
+	var total time.Duration
+	day := from.In(c.loc)
+	for i := 0; i < maxCalendarDays; i++ {
+		start, end, ok := c.window(day)
+		if ok {
+			lo, hi := maxTime(start, from), minTime(end, to)
+			if hi.After(lo) {
+				total += hi.Sub(lo)
+			}
+		}
+		if !end.Before(to) {
+			break
+		}
+		day = time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, c.loc)
+	}
+	return total
+}
+
+// Add returns the instant at which d of business time has elapsed after from.
+func (c *Calendar) Add(from time.Time, d time.Duration) time.Time {
+	remaining := d
+	day := from.In(c.loc)
+	for i := 0; i < maxCalendarDays; i++ {
+		start, end, ok := c.window(day)
+		if ok && end.After(from) {
+			lo := maxTime(start, from)
+			if available := end.Sub(lo); available >= remaining {
+				return lo.Add(remaining)
+			} else {
+				remaining -= available
+			}
+		}
+		day = time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, c.loc)
+	}
+	return from.Add(d)
+}
+
+// window returns the business window on the calendar day containing t.
+// ok is false when that day is not a business day (start and end are still set).
+func (c *Calendar) window(t time.Time) (time.Time, time.Time, bool) {
+	t = t.In(c.loc)
+	start := time.Date(t.Year(), t.Month(), t.Day(), c.startHour, c.startMinute, 0, 0, c.loc)
+	end := time.Date(t.Year(), t.Month(), t.Day(), c.endHour, c.endMinute, 0, 0, c.loc)
+	return start, end, c.days[t.Weekday()]
+}
+
+func (c *Calendar) parseDays(days string) error {
+	for _, part := range strings.Split(days, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if part == "" {
+			continue
+		}
+		from, to, isRange := strings.Cut(part, "-")
+		first, ok := weekdayNames[from]
+		if !ok {
+			return fmt.Errorf("invalid business day %q", from)
+		}
+		last := first
+		if isRange {
+			if last, ok = weekdayNames[to]; !ok {
+				return fmt.Errorf("invalid business day %q", to)
+			}
+		}
+		for d := first; ; d = (d + 1) % 7 {
+			c.days[d] = true
+			if d == last {
+				break
+			}
+		}
+	}
+	for _, on := range c.days {
+		if on {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid business days %q: at least one day is required", days)
+}
+
+func parseClock(s string) (int, int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid time %q: expected HH:MM", s)
+	}
+	return t.Hour(), t.Minute(), nil
+}
+
+func maxTime(a time.Time, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func minTime(a time.Time, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
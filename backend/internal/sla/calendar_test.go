@@ -0,0 +1,100 @@
+package sla
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewCalendar(t *testing.T) {
+	tests := []struct {
+		name          string
+		hours         string
+		days          string
+		tz            string
+		expectedError bool
+	}{
+		{name: "Weekdays", hours: "09:00-18:00", days: "Mon-Fri", tz: "UTC"},
+		{name: "Day list", hours: "10:00-16:30", days: "mon, wed, fri", tz: "Asia/Tokyo"},
+		{name: "Wrapping range", hours: "08:00-12:00", days: "Sun-Thu", tz: "UTC"},
+		{name: "Missing dash", hours: "09:00", days: "Mon-Fri", tz: "UTC", expectedError: true},
+		{name: "End before start", hours: "18:00-09:00", days: "Mon-Fri", tz: "UTC", expectedError: true},
+		{name: "Bad clock", hours: "9am-5pm", days: "Mon-Fri", tz: "UTC", expectedError: true},
+		{name: "Bad day", hours: "09:00-18:00", days: "Mon-Fry", tz: "UTC", expectedError: true},
+		{name: "No days", hours: "09:00-18:00", days: " , ", tz: "UTC", expectedError: true},
+		{name: "Bad time zone", hours: "09:00-18:00", days: "Mon-Fri", tz: "Mars/Olympus", expectedError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewCalendar(tt.hours, tt.days, tt.tz)
+			if (err != nil) != tt.expectedError {
+				t.Errorf("expected error %v, got %v", tt.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestCalendarBusinessDuration(t *testing.T) {
+	cal, err := NewCalendar("09:00-18:00", "Mon-Fri", "UTC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 2024-01-05 is a Friday.
+	at := func(day int, hour int, minute int) time.Time {
+		return time.Date(2024, time.January, day, hour, minute, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name     string
+		from     time.Time
+		to       time.Time
+		expected time.Duration
+	}{
+		{name: "Within one day", from: at(5, 10, 0), to: at(5, 12, 30), expected: 150 * time.Minute},
+		{name: "Before opening", from: at(5, 7, 0), to: at(5, 10, 0), expected: time.Hour},
+		{name: "After closing", from: at(4, 17, 0), to: at(4, 22, 0), expected: time.Hour},
+		{name: "Over the weekend", from: at(5, 17, 0), to: at(8, 10, 0), expected: 2 * time.Hour},
+		{name: "Weekend only", from: at(6, 9, 0), to: at(7, 18, 0), expected: 0},
+		{name: "Full week", from: at(8, 0, 0), to: at(15, 0, 0), expected: 45 * time.Hour},
+		{name: "Reversed", from: at(5, 12, 0), to: at(5, 10, 0), expected: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cal.BusinessDuration(tt.from, tt.to); got != tt.expected {
+				t.Errorf("expected business duration %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestCalendarAdd(t *testing.T) {
+	cal, err := NewCalendar("09:00-18:00", "Mon-Fri", "America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	loc := cal.Location()
+	at := func(month time.Month, day int, hour int, minute int) time.Time {
+		return time.Date(2024, month, day, hour, minute, 0, 0, loc)
+	}
+	tests := []struct {
+		name     string
+		from     time.Time
+		d        time.Duration
+		expected time.Time
+	}{
+		{name: "Same day", from: at(time.January, 5, 10, 0), d: 2 * time.Hour, expected: at(time.January, 5, 12, 0)},
+		{name: "One business day from friday", from: at(time.January, 5, 15, 0), d: 9 * time.Hour, expected: at(time.January, 8, 15, 0)},
+		{name: "Opened on saturday", from: at(time.January, 6, 11, 0), d: time.Hour, expected: at(time.January, 8, 10, 0)},
+		{name: "Opened before hours", from: at(time.January, 8, 6, 0), d: 9 * time.Hour, expected: at(time.January, 8, 18, 0)},
+		{name: "Across DST change", from: at(time.March, 8, 17, 0), d: 2 * time.Hour, expected: at(time.March, 11, 10, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := cal.Add(tt.from, tt.d)
+			if !got.Equal(tt.expected) {
+				t.Errorf("expected due time %v, got %v", tt.expected, got)
+			}
+			if back := cal.BusinessDuration(tt.from, got); back != tt.d {
+				t.Errorf("expected business duration back to %v, got %v", tt.d, back)
+			}
+		})
+	}
+}
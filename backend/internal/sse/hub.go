@@ -1,39 +1,178 @@
 package sse
 
 import (
-	"encoding/json"
+	"cmp"
+	"slices"
 	"sync"
+	"sync/atomic"
 
 	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/middleware"
 	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
 )
 
-// Hub manages SSE client connections and broadcasts events to all connected clients.
+const (
+	// defaultQueueSize is the number of events waiting for the hub loop when
+	// HubOptions.QueueSize is not set.
+	defaultQueueSize = 256
+	// defaultClientBuffer is the buffer of client channels when HubOptions.ClientBuffer is
+	// not set.
+	defaultClientBuffer = 64
+	// statsLaggingClients is the number of clients listed in HubStats.Lagging.
+	statsLaggingClients = 10
+)
+
+// OverflowPolicy decides what Broadcast does when the hub's queue is full.
+type OverflowPolicy string
+
+const (
+	// DropOldest discards the oldest queued event to make room for the new one.
+	DropOldest OverflowPolicy = "drop_oldest"
+	// DropNewest discards the new event.
+	DropNewest OverflowPolicy = "drop_newest"
+	// Coalesce replaces a queued message of the same type about the same event (or about
+	// no event, such as stats_updated) by the new one, and otherwise discards the oldest
+	// queued message.
+	Coalesce OverflowPolicy = "coalesce"
+)
+
+// HubOptions configures a Hub. The zero value uses the defaults.
+type HubOptions struct {
+	// QueueSize is the number of messages that can wait for the hub loop; 0 means 256.
+	QueueSize int
+	// Overflow is applied when the queue is full; "" means DropOldest.
+	Overflow OverflowPolicy
+	// ClientBuffer is the number of messages buffered per client by NewClient; 0 means 64.
+	// A client whose buffer fills is disconnected.
+	ClientBuffer int
+}
+
+// HubStats is a snapshot of the hub's state and counters.
+type HubStats struct {
+	Clients int `json:"clients"`
+	Queued  int `json:"queued"`
+	// Dropped counts the messages discarded because the queue was full.
+	Dropped uint64 `json:"dropped"`
+	// Coalesced counts the queued messages replaced by a newer one.
+	Coalesced uint64 `json:"coalesced"`
+	// SlowDisconnects counts the clients disconnected because their buffer was full.
+	SlowDisconnects uint64 `json:"slow_disconnects"`
+	// ResyncDisconnects counts the clients disconnected because a new_event they would
+	// have received was discarded.
+	ResyncDisconnects uint64 `json:"resync_disconnects"`
+	// Lagging lists the clients with the most buffered messages, most lagging first.
+	Lagging []ClientLag `json:"lagging"`
+}
+
+// ClientLag describes how far a client is behind the hub.
+type ClientLag struct {
+	// Buffered is the number of messages sent to the client but not yet read, out of
+	// Capacity; the client is disconnected when they are equal.
+	Buffered int `json:"buffered"`
+	Capacity int `json:"capacity"`
+	// Delivered is the number of messages sent to the client, and LastEventID the ID of
+	// the latest new_event among them.
+	Delivered   uint64 `json:"delivered"`
+	LastEventID int64  `json:"last_event_id"`
+}
+
+// Hub manages SSE client connections and routes each message to the clients it concerns:
+// event messages to the clients whose filter matches the event, others to every client.
 type Hub struct {
-	clients    map[chan []byte]bool
-	register   chan chan []byte
-	unregister chan chan []byte
-	broadcast  chan model.Event
-	mu         sync.RWMutex
+	clients      map[chan Envelope]*hubClient
+	register     chan subscription
+	unregister   chan chan Envelope
+	mu           sync.RWMutex
+	clientBuffer int
+	slow         atomic.Uint64
+	resyncs      atomic.Uint64
+
+	// queue holds the published messages not yet dispatched; pending wakes the hub loop.
+	// lost holds the events of the new_event messages discarded since the queue was last
+	// taken.
+	queueMu   sync.Mutex
+	queue     []Message
+	lost      []*model.Event
+	queueSize int
+	overflow  OverflowPolicy
+	pending   chan struct{}
+	dropped   atomic.Uint64
+	coalesced atomic.Uint64
+
+	// presence counts the open connections of each signed-in user; presenceChanged is
+	// signalled when a user comes online or goes offline.
+	presenceMu      sync.Mutex
+	presence        map[int64]int
+	presenceChanged chan struct{}
+}
+
+// subscription is a client registration with an optional filter.
+type subscription struct {
+	client chan Envelope
+	filter *model.EventFilter
+	routes routing
+}
+
+// routing selects the kinds of messages a client receives.
+type routing int
+
+const (
+	// routeAll sends the client the event messages matching its filter and every other
+	// message.
+	routeAll routing = iota
+	// routeEvents sends the client only the event messages matching its filter.
+	routeEvents
+	// routeGeneral sends the client only the messages about no event.
+	routeGeneral
+)
+
+// hubClient is the state of a registered client. The counters are updated by the hub
+// loop and read by Stats.
+type hubClient struct {
+	// filter is nil for clients that receive every event.
+	filter      *model.EventFilter
+	routes      routing
+	delivered   atomic.Uint64
+	lastEventID atomic.Int64
 }
 
 // NewHub creates a new SSE Hub.
-func NewHub() *Hub {
+func NewHub(opts HubOptions) *Hub {
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = defaultQueueSize
+	}
+	if opts.Overflow == "" {
+		opts.Overflow = DropOldest
+	}
+	if opts.ClientBuffer <= 0 {
+		opts.ClientBuffer = defaultClientBuffer
+	}
 	return &Hub{
-		clients:    make(map[chan []byte]bool),
-		register:   make(chan chan []byte),
-		unregister: make(chan chan []byte),
-		broadcast:  make(chan model.Event, 256),
+		clients:      make(map[chan Envelope]*hubClient),
+		register:     make(chan subscription),
+		unregister:   make(chan chan Envelope),
+		clientBuffer: opts.ClientBuffer,
+		queueSize:    opts.QueueSize,
+		overflow:     opts.Overflow,
+		pending:      make(chan struct{}, 1),
+
+		presence:        make(map[int64]int),
+		presenceChanged: make(chan struct{}, 1),
 	}
 }
 
+// NewClient returns a client channel with the hub's per-client buffer, to be passed to
+// Register or Subscribe.
+func (h *Hub) NewClient() chan Envelope {
+	return make(chan Envelope, h.clientBuffer)
+}
+
 // Run starts the hub event loop. Should be called in a goroutine.
 func (h *Hub) Run() {
 	for {
 		select {
-		case client := <-h.register:
+		case sub := <-h.register:
 			h.mu.Lock()
-			h.clients[client] = true
+			h.clients[sub.client] = &hubClient{filter: sub.filter, routes: sub.routes}
 			h.mu.Unlock()
 			middleware.LogEvent("info", "SSE client connected", map[string]interface{}{
 				"total_clients": h.ClientCount(),
@@ -48,42 +187,278 @@ func (h *Hub) Run() {
 			middleware.LogEvent("info", "SSE client disconnected", map[string]interface{}{
 				"total_clients": h.ClientCount(),
 			})
-		case event := <-h.broadcast:
-			data, err := json.Marshal(event)
-			if err != nil {
-				middleware.LogEvent("error", "failed to marshal SSE event", map[string]interface{}{
-					"error": err.Error(),
-				})
-				continue
+		case <-h.pending:
+			h.queueMu.Lock()
+			messages, lost := h.queue, h.lost
+			h.queue, h.lost = nil, nil
+			h.queueMu.Unlock()
+			if len(lost) > 0 {
+				h.resync(lost)
 			}
-			h.mu.RLock()
-			for client := range h.clients {
-				select {
-				case client <- data:
-				default:
-					go func(c chan []byte) {
-						h.unregister <- c
-					}(client)
-				}
+			for i := range messages {
+				h.dispatch(&messages[i])
 			}
-			h.mu.RUnlock()
 		}
 	}
 }
 
-// Register adds a new client channel to the hub.
-func (h *Hub) Register(client chan []byte) {
-	h.register <- client
+// dispatch sends a message to the clients it concerns. A client whose buffer is full is
+// disconnected right away, so that it never receives a later message after missing this
+// one: it reads the messages still buffered, then finds its channel closed.
+func (h *Hub) dispatch(msg *Message) {
+	env, err := msg.envelope()
+	if err != nil {
+		middleware.LogEvent("error", "failed to encode SSE message", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return
+	}
+	var slow []chan Envelope
+	h.mu.RLock()
+	for client, c := range h.clients {
+		if !c.receives(msg) {
+			continue
+		}
+		select {
+		case client <- env:
+			c.delivered.Add(1)
+			if msg.Type == MessageNewEvent {
+				c.lastEventID.Store(env.EventID)
+			}
+		default:
+			slow = append(slow, client)
+		}
+	}
+	h.mu.RUnlock()
+	if len(slow) == 0 {
+		return
+	}
+	h.disconnect(slow)
+	h.slow.Add(uint64(len(slow)))
+	middleware.LogEvent("warn", "SSE clients disconnected for falling behind", map[string]interface{}{
+		"clients":       len(slow),
+		"message_type":  msg.Type,
+		"event_id":      env.EventID,
+		"total_clients": h.ClientCount(),
+	})
+}
+
+// resync disconnects the clients that would have received one of the lost events, before
+// any later message reaches them. Like a slow client, each reads what it has buffered and
+// then finds its channel closed, so it resumes from the last event it received and the
+// lost events are replayed.
+func (h *Hub) resync(lost []*model.Event) {
+	var missed []chan Envelope
+	h.mu.RLock()
+	for client, c := range h.clients {
+		if c.routes != routeGeneral && (c.filter == nil || slices.ContainsFunc(lost, c.filter.Matches)) {
+			missed = append(missed, client)
+		}
+	}
+	h.mu.RUnlock()
+	if len(missed) == 0 {
+		return
+	}
+	h.disconnect(missed)
+	h.resyncs.Add(uint64(len(missed)))
+	middleware.LogEvent("warn", "SSE clients disconnected to resync after the queue overflowed", map[string]interface{}{
+		"clients":       len(missed),
+		"lost_events":   len(lost),
+		"total_clients": h.ClientCount(),
+	})
+}
+
+// disconnect removes clients from the hub and closes their channels.
+func (h *Hub) disconnect(clients []chan Envelope) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, client := range clients {
+		delete(h.clients, client)
+		close(client)
+	}
+}
+
+// receives reports whether msg concerns the client.
+func (c *hubClient) receives(msg *Message) bool {
+	if msg.Event == nil {
+		return c.routes != routeEvents
+	}
+	return c.routes != routeGeneral && (c.filter == nil || c.filter.Matches(msg.Event))
+}
+
+// Register adds a new client channel to the hub that receives every event.
+func (h *Hub) Register(client chan Envelope) {
+	h.register <- subscription{client: client}
+}
+
+// Subscribe adds a new client channel to the hub that receives only the event messages
+// whose event matches filter, and every other message.
+func (h *Hub) Subscribe(client chan Envelope, filter model.EventFilter) {
+	h.register <- subscription{client: client, filter: &filter}
+}
+
+// SubscribeEvents adds a new client channel to the hub that receives only the event
+// messages whose event matches filter. A connection carrying several of them receives the
+// other messages once through RegisterGeneral.
+func (h *Hub) SubscribeEvents(client chan Envelope, filter model.EventFilter) {
+	h.register <- subscription{client: client, filter: &filter, routes: routeEvents}
+}
+
+// RegisterGeneral adds a new client channel to the hub that receives only the messages
+// about no event, such as stats_updated, presence and server_shutdown.
+func (h *Hub) RegisterGeneral(client chan Envelope) {
+	h.register <- subscription{client: client, routes: routeGeneral}
+}
+
+// SetFilter replaces the filter of a registered client; later messages are routed with
+// the new filter. It does nothing if the client is not registered.
+func (h *Hub) SetFilter(client chan Envelope, filter model.EventFilter) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if c, ok := h.clients[client]; ok {
+		c.filter = &filter
+	}
 }
 
 // Unregister removes a client channel from the hub.
-func (h *Hub) Unregister(client chan []byte) {
+func (h *Hub) Unregister(client chan Envelope) {
 	h.unregister <- client
 }
 
-// Broadcast sends an event to all connected clients.
+// Broadcast publishes a new_event message for event.
 func (h *Hub) Broadcast(event model.Event) {
-	h.broadcast <- event
+	h.Publish(Message{Type: MessageNewEvent, Event: &event})
+}
+
+// changeMessages maps each type of change to the stored events to its message type.
+var changeMessages = map[model.EventChangeType]MessageType{
+	model.EventCreated: MessageNewEvent,
+	model.EventUpdated: MessageEventUpdated,
+	model.EventDeleted: MessageEventDeleted,
+}
+
+// PublishChange publishes the message for a change to the stored events. Creations and
+// deletions change the statistics, so they are followed by a stats_updated message.
+func (h *Hub) PublishChange(change model.EventChange) {
+	msgType, ok := changeMessages[change.Type]
+	if !ok {
+		return
+	}
+	h.Publish(Message{Type: msgType, Event: &change.Event})
+	if change.Type != model.EventUpdated {
+		h.Publish(Message{Type: MessageStatsUpdated})
+	}
+}
+
+// Publish queues a message for the connected clients. It never blocks: when the queue is
+// full, the hub's overflow policy decides which message is discarded.
+func (h *Hub) Publish(msg Message) {
+	h.queueMu.Lock()
+	h.enqueue(msg)
+	h.queueMu.Unlock()
+	select {
+	case h.pending <- struct{}{}:
+	default:
+	}
+}
+
+// enqueue adds msg to the queue, applying the overflow policy. h.queueMu must be held.
+// The events of discarded new_event messages are recorded in h.lost so that the clients
+// that miss them are made to resync.
+func (h *Hub) enqueue(msg Message) {
+	// A queued stats_updated has not reached anyone yet, so a new one replaces it and is
+	// sent after the changes published in between.
+	if msg.Type == MessageStatsUpdated {
+		if i := slices.IndexFunc(h.queue, func(m Message) bool { return m.Type == MessageStatsUpdated }); i >= 0 {
+			h.queue = slices.Delete(h.queue, i, i+1)
+			h.coalesced.Add(1)
+		}
+	}
+	if len(h.queue) < h.queueSize {
+		h.queue = append(h.queue, msg)
+		return
+	}
+	switch h.overflow {
+	case DropNewest:
+		h.drop(msg)
+		return
+	case Coalesce:
+		for i := range h.queue {
+			if msg.supersedes(&h.queue[i]) {
+				h.queue[i] = msg
+				h.coalesced.Add(1)
+				return
+			}
+		}
+	}
+	h.drop(h.queue[0])
+	h.queue = append(h.queue[1:], msg)
+}
+
+// drop counts a discarded message. h.queueMu must be held.
+func (h *Hub) drop(msg Message) {
+	h.dropped.Add(1)
+	if msg.Type == MessageNewEvent {
+		h.lost = append(h.lost, msg.Event)
+	}
+}
+
+// Join records an open connection of a signed-in user. It does nothing for userID 0
+// (anonymous). Each Join must be paired with a Leave when the connection closes.
+func (h *Hub) Join(userID int64) {
+	if userID == 0 {
+		return
+	}
+	h.presenceMu.Lock()
+	h.presence[userID]++
+	online := h.presence[userID] == 1
+	h.presenceMu.Unlock()
+	if online {
+		h.notifyPresence()
+	}
+}
+
+// Leave records that a connection recorded by Join has closed.
+func (h *Hub) Leave(userID int64) {
+	if userID == 0 {
+		return
+	}
+	h.presenceMu.Lock()
+	n, ok := h.presence[userID]
+	offline := ok && n <= 1
+	if offline {
+		delete(h.presence, userID)
+	} else if ok {
+		h.presence[userID] = n - 1
+	}
+	h.presenceMu.Unlock()
+	if offline {
+		h.notifyPresence()
+	}
+}
+
+// Online returns the number of open connections of each signed-in user.
+func (h *Hub) Online() map[int64]int {
+	h.presenceMu.Lock()
+	defer h.presenceMu.Unlock()
+	online := make(map[int64]int, len(h.presence))
+	for id, n := range h.presence {
+		online[id] = n
+	}
+	return online
+}
+
+// PresenceChanged returns a channel that receives a value after a user comes online or
+// goes offline. Changes that happen before the value is received are merged into it.
+func (h *Hub) PresenceChanged() <-chan struct{} {
+	return h.presenceChanged
+}
+
+func (h *Hub) notifyPresence() {
+	select {
+	case h.presenceChanged <- struct{}{}:
+	default:
+	}
 }
 
 // ClientCount returns the number of connected clients.
@@ -92,3 +467,31 @@ func (h *Hub) ClientCount() int {
 	defer h.mu.RUnlock()
 	return len(h.clients)
 }
+
+// Stats returns a snapshot of the hub's state and counters.
+func (h *Hub) Stats() HubStats {
+	h.queueMu.Lock()
+	queued := len(h.queue)
+	h.queueMu.Unlock()
+	h.mu.RLock()
+	lagging := make([]ClientLag, 0, len(h.clients))
+	for client, c := range h.clients {
+		lagging = append(lagging, ClientLag{
+			Buffered:    len(client),
+			Capacity:    cap(client),
+			Delivered:   c.delivered.Load(),
+			LastEventID: c.lastEventID.Load(),
+		})
+	}
+	h.mu.RUnlock()
+	slices.SortFunc(lagging, func(a, b ClientLag) int { return cmp.Compare(b.Buffered, a.Buffered) })
+	return HubStats{
+		Clients:           len(lagging),
+		Queued:            queued,
+		Dropped:           h.dropped.Load(),
+		Coalesced:         h.coalesced.Load(),
+		SlowDisconnects:   h.slow.Load(),
+		ResyncDisconnects: h.resyncs.Load(),
+		Lagging:           lagging[:min(len(lagging), statsLaggingClients)],
+	}
+}
@@ -0,0 +1,339 @@
+package sse
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"testing"
+	"time"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+)
+
+// drain returns the ids of the events buffered in client within a short wait.
+func drain(t *testing.T, client chan Envelope) []int64 {
+	t.Helper()
+	var ids []int64
+	for {
+		select {
+		case env := <-client:
+			var e model.Event
+			if err := json.Unmarshal(env.Data, &e); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			if e.ID != env.EventID {
+				t.Fatalf("envelope for event %d has EventID %d", e.ID, env.EventID)
+			}
+			ids = append(ids, e.ID)
+		case <-time.After(50 * time.Millisecond):
+			return ids
+		}
+	}
+}
+
+func TestHubRoutesEventsByFilter(t *testing.T) {
+	hub := NewHub(HubOptions{})
+	go hub.Run()
+
+	all := make(chan Envelope, 16)
+	api := make(chan Envelope, 16)
+	pushes := make(chan Envelope, 16)
+	hub.Register(all)
+	hub.Subscribe(api, model.EventFilter{Repos: []string{"acme/api"}})
+	hub.Subscribe(pushes, model.EventFilter{EventTypes: []string{"push"}, ExcludeBots: true})
+
+	events := []model.Event{
+		{ID: 1, RepoName: "acme/api", EventType: "issues", SenderLogin: "alice"},
+		{ID: 2, RepoName: "acme/web", EventType: "push", SenderLogin: "bob"},
+		{ID: 3, RepoName: "ACME/API", EventType: "push", SenderLogin: "dependabot[bot]"},
+	}
+	for _, e := range events {
+		hub.Broadcast(e)
+	}
+	tests := []struct {
+		name     string
+		client   chan Envelope
+		expected string
+	}{
+		{name: "unfiltered", client: all, expected: "[1 2 3]"},
+		{name: "repo", client: api, expected: "[1 3]"},
+		{name: "type without bots", client: pushes, expected: "[2]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fmt.Sprint(drain(t, tt.client)); got != tt.expected {
+				t.Errorf("expected to receive %s, got %s", tt.expected, got)
+			}
+		})
+	}
+
+	hub.SetFilter(api, model.EventFilter{Repos: []string{"acme/web"}})
+	hub.Broadcast(model.Event{ID: 4, RepoName: "acme/api"})
+	hub.Broadcast(model.Event{ID: 5, RepoName: "acme/web"})
+	if got := fmt.Sprint(drain(t, api)); got != "[5]" {
+		t.Errorf("expected to receive [5] after SetFilter, got %s", got)
+	}
+
+	// SetFilter on an unregistered client must not register it.
+	hub.SetFilter(make(chan Envelope), model.EventFilter{})
+	if got := hub.ClientCount(); got != 3 {
+		t.Errorf("expected ClientCount() to be 3, got %d", got)
+	}
+}
+
+func TestHubBroadcastOverflow(t *testing.T) {
+	tests := []struct {
+		name              string
+		policy            OverflowPolicy
+		events            []model.Event
+		expectedQueued    string
+		expectedDropped   uint64
+		expectedCoalesced uint64
+	}{
+		{
+			name:            "drop oldest",
+			events:          []model.Event{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}},
+			expectedQueued:  "[2 3 4]",
+			expectedDropped: 1,
+		},
+		{
+			name:            "drop newest",
+			policy:          DropNewest,
+			events:          []model.Event{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}},
+			expectedQueued:  "[1 2 3]",
+			expectedDropped: 1,
+		},
+		{
+			name:              "coalesce",
+			policy:            Coalesce,
+			events:            []model.Event{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 2, Action: "edited"}, {ID: 4}},
+			expectedQueued:    "[2 3 4]",
+			expectedDropped:   1,
+			expectedCoalesced: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The hub loop is not running, so nothing leaves the queue and Broadcast must
+			// still return.
+			hub := NewHub(HubOptions{QueueSize: 3, Overflow: tt.policy})
+			for _, e := range tt.events {
+				hub.Broadcast(e)
+			}
+			var ids []int64
+			for _, msg := range hub.queue {
+				ids = append(ids, msg.Event.ID)
+			}
+			if got := fmt.Sprint(ids); got != tt.expectedQueued {
+				t.Errorf("expected queued %s, got %s", tt.expectedQueued, got)
+			}
+			stats := hub.Stats()
+			if stats.Queued != 3 || stats.Dropped != tt.expectedDropped || stats.Coalesced != tt.expectedCoalesced {
+				t.Errorf("expected Stats() with 3 queued, %d dropped, %d coalesced, got %+v", tt.expectedDropped, tt.expectedCoalesced, stats)
+			}
+			if tt.policy == Coalesce && hub.queue[0].Event.Action != "edited" {
+				t.Errorf("expected coalesced event to be the newer one, got %+v", hub.queue[0])
+			}
+		})
+	}
+}
+
+func TestHubDisconnectsSlowClients(t *testing.T) {
+	hub := NewHub(HubOptions{ClientBuffer: 2})
+	go hub.Run()
+	slow := hub.NewClient()
+	fast := make(chan Envelope, 16)
+	hub.Register(slow)
+	hub.Register(fast)
+	for id := int64(1); id <= 4; id++ {
+		hub.Broadcast(model.Event{ID: id})
+	}
+	deadline := time.Now().Add(3 * time.Second)
+	for hub.Stats().SlowDisconnects != 1 || hub.Stats().Lagging[0].Delivered != 4 {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected Stats() to count the slow client, got %+v", hub.Stats())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// The slow client keeps the events buffered before it was dropped, then sees its
+	// channel closed.
+	var ids []int64
+	for env := range slow {
+		ids = append(ids, env.EventID)
+	}
+	if got := fmt.Sprint(ids); got != "[1 2]" {
+		t.Errorf("expected slow client to receive [1 2], got %s", got)
+	}
+	stats := hub.Stats()
+	expected := ClientLag{Buffered: 4, Capacity: 16, Delivered: 4, LastEventID: 4}
+	if stats.Clients != 1 || len(stats.Lagging) != 1 || stats.Lagging[0] != expected {
+		t.Errorf("expected Stats() with one client lagging by %+v, got %+v", expected, stats)
+	}
+}
+
+func TestHubResyncsClientsMissingDroppedEvents(t *testing.T) {
+	hub := NewHub(HubOptions{QueueSize: 2})
+	clients := map[string]chan Envelope{"all": hub.NewClient(), "api": hub.NewClient(), "web": hub.NewClient()}
+	// The hub loop is not running yet, so the clients are added directly and the queue
+	// overflows before anything is dispatched.
+	hub.clients[clients["all"]] = &hubClient{}
+	hub.clients[clients["api"]] = &hubClient{filter: &model.EventFilter{Repos: []string{"acme/api"}}}
+	hub.clients[clients["web"]] = &hubClient{filter: &model.EventFilter{Repos: []string{"acme/web"}}}
+	hub.Broadcast(model.Event{ID: 1, RepoName: "acme/api"})
+	hub.Broadcast(model.Event{ID: 2, RepoName: "acme/web"})
+	hub.Broadcast(model.Event{ID: 3, RepoName: "acme/web"})
+	go hub.Run()
+
+	deadline := time.Now().Add(3 * time.Second)
+	for hub.Stats().ResyncDisconnects != 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected Stats() to count 2 resync disconnects, got %+v", hub.Stats())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	// The clients that would have received event 1 are closed before events 2 and 3 are
+	// dispatched, so that they resume from before event 1.
+	for _, name := range []string{"all", "api"} {
+		var ids []int64
+		for env := range clients[name] {
+			ids = append(ids, env.EventID)
+		}
+		if len(ids) != 0 {
+			t.Errorf("expected %s client to be closed without events, got %v", name, ids)
+		}
+	}
+	if got := fmt.Sprint(drain(t, clients["web"])); got != "[2 3]" {
+		t.Errorf("expected web client to receive [2 3], got %s", got)
+	}
+	if stats := hub.Stats(); stats.Clients != 1 || stats.Dropped != 1 {
+		t.Errorf("expected Stats() with 1 client and 1 dropped, got %+v", stats)
+	}
+}
+
+func TestHubRoutesTypedMessages(t *testing.T) {
+	hub := NewHub(HubOptions{})
+	go hub.Run()
+	api := make(chan Envelope, 16)
+	hub.Subscribe(api, model.EventFilter{Repos: []string{"acme/api"}})
+
+	hub.Publish(Message{Type: MessageEventUpdated, Event: &model.Event{ID: 1, RepoName: "acme/api"}})
+	hub.Publish(Message{Type: MessageEventDeleted, Event: &model.Event{ID: 2, RepoName: "acme/web"}})
+	hub.Publish(Message{Type: MessageStatsUpdated})
+	hub.Publish(Message{Type: MessagePresence, Data: []string{"alice"}})
+	var got []string
+	for len(got) < 3 {
+		select {
+		case env := <-api:
+			data := string(env.Data)
+			if env.EventID != 0 {
+				var e model.Event
+				if err := json.Unmarshal(env.Data, &e); err != nil {
+					t.Fatal(err)
+				}
+				data = e.RepoName
+			}
+			got = append(got, fmt.Sprintf("%s %d %s", env.Type, env.EventID, data))
+		case <-time.After(3 * time.Second):
+			t.Fatalf("expected 3 messages, got %q", got)
+		}
+	}
+	// Event messages are filtered; the others reach every client.
+	expected := []string{
+		`event_updated 1 acme/api`,
+		`stats_updated 0 {}`,
+		`presence 0 ["alice"]`,
+	}
+	if fmt.Sprint(got) != fmt.Sprint(expected) {
+		t.Errorf("expected to receive %q, got %q", expected, got)
+	}
+}
+
+func TestHubPublishChange(t *testing.T) {
+	// The hub loop is not running, so the queue shows what was published.
+	hub := NewHub(HubOptions{})
+	hub.PublishChange(model.EventChange{Type: model.EventCreated, Event: model.Event{ID: 1}})
+	hub.PublishChange(model.EventChange{Type: model.EventUpdated, Event: model.Event{ID: 1}})
+	hub.PublishChange(model.EventChange{Type: model.EventCreated, Event: model.Event{ID: 2}})
+	hub.PublishChange(model.EventChange{Type: model.EventDeleted, Event: model.Event{ID: 1}})
+
+	var got []string
+	for _, msg := range hub.queue {
+		if msg.Event != nil {
+			got = append(got, fmt.Sprintf("%s %d", msg.Type, msg.Event.ID))
+		} else {
+			got = append(got, string(msg.Type))
+		}
+	}
+	// Updates leave the statistics alone, and one stats_updated follows the last change.
+	expected := []string{"new_event 1", "event_updated 1", "new_event 2", "event_deleted 1", "stats_updated"}
+	if fmt.Sprint(got) != fmt.Sprint(expected) {
+		t.Errorf("expected queued %q, got %q", expected, got)
+	}
+	if stats := hub.Stats(); stats.Coalesced != 2 || stats.Dropped != 0 {
+		t.Errorf("expected Stats() with 2 coalesced and none dropped, got %+v", stats)
+	}
+}
+
+func TestHubPresence(t *testing.T) {
+	hub := NewHub(HubOptions{})
+	changed := func() bool {
+		select {
+		case <-hub.PresenceChanged():
+			return true
+		default:
+			return false
+		}
+	}
+	tests := []struct {
+		name            string
+		join            bool
+		userID          int64
+		expectedOnline  map[int64]int
+		expectedChanged bool
+	}{
+		{name: "anonymous", join: true, userID: 0, expectedOnline: map[int64]int{}},
+		{name: "first connection", join: true, userID: 1, expectedOnline: map[int64]int{1: 1}, expectedChanged: true},
+		{name: "second connection", join: true, userID: 1, expectedOnline: map[int64]int{1: 2}},
+		{name: "other user", join: true, userID: 2, expectedOnline: map[int64]int{1: 2, 2: 1}, expectedChanged: true},
+		{name: "one of two closes", userID: 1, expectedOnline: map[int64]int{1: 1, 2: 1}},
+		{name: "last connection closes", userID: 1, expectedOnline: map[int64]int{2: 1}, expectedChanged: true},
+		{name: "unknown user leaves", userID: 3, expectedOnline: map[int64]int{2: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.join {
+				hub.Join(tt.userID)
+			} else {
+				hub.Leave(tt.userID)
+			}
+			if got := hub.Online(); !maps.Equal(got, tt.expectedOnline) {
+				t.Errorf("expected Online() to be %v, got %v", tt.expectedOnline, got)
+			}
+			if got := changed(); got != tt.expectedChanged {
+				t.Errorf("expected PresenceChanged() signalled %v, got %v", tt.expectedChanged, got)
+			}
+		})
+	}
+}
+
+func TestMessageSupersedes(t *testing.T) {
+	tests := []struct {
+		name     string
+		msg, old Message
+		expected bool
+	}{
+		{name: "same event", msg: Message{Type: MessageEventUpdated, Event: &model.Event{ID: 1}}, old: Message{Type: MessageEventUpdated, Event: &model.Event{ID: 1}}, expected: true},
+		{name: "other event", msg: Message{Type: MessageEventUpdated, Event: &model.Event{ID: 1}}, old: Message{Type: MessageEventUpdated, Event: &model.Event{ID: 2}}, expected: false},
+		{name: "other type", msg: Message{Type: MessageEventDeleted, Event: &model.Event{ID: 1}}, old: Message{Type: MessageEventUpdated, Event: &model.Event{ID: 1}}, expected: false},
+		{name: "stats", msg: Message{Type: MessageStatsUpdated}, old: Message{Type: MessageStatsUpdated}, expected: true},
+		{name: "stats and event", msg: Message{Type: MessageStatsUpdated}, old: Message{Type: MessageNewEvent, Event: &model.Event{ID: 1}}, expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.msg.supersedes(&tt.old); got != tt.expected {
+				t.Errorf("expected supersedes() to be %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package sse
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+)
+
+// MessageType is the type of a hub message. Streams use it as the SSE event name.
+type MessageType string
+
+const (
+	// MessageNewEvent announces a newly received event.
+	MessageNewEvent MessageType = "new_event"
+	// MessageEventUpdated carries the new version of a stored event.
+	MessageEventUpdated MessageType = "event_updated"
+	// MessageEventDeleted carries an event that was removed.
+	MessageEventDeleted MessageType = "event_deleted"
+	// MessageStatsUpdated tells clients that the dashboard statistics changed.
+	MessageStatsUpdated MessageType = "stats_updated"
+	// MessagePresence carries the users currently watching the dashboard.
+	MessagePresence MessageType = "presence"
+	// MessageServerShutdown tells clients that the server is going away, so they should
+	// reconnect (to another replica) after their usual delay.
+	MessageServerShutdown MessageType = "server_shutdown"
+)
+
+// Message is a typed update published to the hub.
+type Message struct {
+	Type MessageType
+	// Event is the event concerned by new_event, event_updated and event_deleted
+	// messages, which reach only the clients whose filter matches it.
+	Event *model.Event
+	// Data is the payload of the other types, which reach every client. It is encoded as
+	// JSON; nil is sent as {}.
+	Data interface{}
+}
+
+// Envelope is a message as delivered to a client, with its payload encoded once for all
+// clients.
+type Envelope struct {
+	Type MessageType
+	// EventID is the ID of the event concerned, or 0 for messages without one.
+	EventID int64
+	// Data is the JSON payload: the event for event messages, Message.Data otherwise.
+	Data []byte
+}
+
+// envelope encodes the message for delivery.
+func (m *Message) envelope() (Envelope, error) {
+	env := Envelope{Type: m.Type}
+	payload := m.Data
+	if m.Event != nil {
+		env.EventID = m.Event.ID
+		payload = m.Event
+	}
+	if payload == nil {
+		env.Data = []byte("{}")
+		return env, nil
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return Envelope{}, fmt.Errorf("failed to marshal %s message: %w", m.Type, err)
+	}
+	env.Data = data
+	return env, nil
+}
+
+// supersedes reports whether m makes the queued message old redundant: both have the same
+// type and concern the same event, or no event.
+func (m *Message) supersedes(old *Message) bool {
+	if m.Type != old.Type || (m.Event == nil) != (old.Event == nil) {
+		return false
+	}
+	return m.Event == nil || m.Event.ID == old.Event.ID
+}
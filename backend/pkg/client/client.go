@@ -0,0 +1,166 @@
+// Package client is a Go client for the GitHub Events Dashboard HTTP API.
+//
+//	c, err := client.New("https://dashboard.example.com", client.WithToken(os.Getenv("DASHBOARD_TOKEN")))
+//	for event, err := range c.AllEvents(ctx, client.ListOptions{Repos: []string{"acme/api"}}) {
+//		...
+//	}
+//
+// Requests are authenticated with a personal API token (see POST /api/tokens).
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+)
+
+const (
+	defaultMinReconnectDelay = time.Second
+	defaultMaxReconnectDelay = 30 * time.Second
+	// maxErrorBodyBytes limits how much of an error response is read.
+	maxErrorBodyBytes = 64 << 10
+)
+
+// Event is a GitHub webhook event as returned by the API.
+type Event = model.Event
+
+// EventList is a page of events.
+type EventList = model.EventListResponse
+
+// Pagination holds the cursors and counts of an EventList.
+type Pagination = model.Pagination
+
+// Client calls the dashboard API. It is safe for concurrent use.
+type Client struct {
+	baseURL           *url.URL
+	token             string
+	httpClient        *http.Client
+	minReconnectDelay time.Duration
+	maxReconnectDelay time.Duration
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithToken authenticates requests with a personal API token.
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithHTTPClient sets the HTTP client used for requests. It should not have a Timeout,
+// which would also cut off event streams; use request contexts instead.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithReconnectDelay sets the delay before the first reconnection attempt of an event
+// stream and the maximum it backs off to (defaults: 1s and 30s). A retry interval sent
+// by the server replaces min.
+func WithReconnectDelay(min, max time.Duration) Option {
+	return func(c *Client) {
+		c.minReconnectDelay = min
+		c.maxReconnectDelay = max
+	}
+}
+
+// New creates a Client for the API served at baseURL (e.g. "http://localhost:8080").
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid base URL %q: must be an absolute http(s) URL", baseURL)
+	}
+	c := &Client{
+		baseURL:           u,
+		httpClient:        &http.Client{},
+		minReconnectDelay: defaultMinReconnectDelay,
+		maxReconnectDelay: defaultMaxReconnectDelay,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.minReconnectDelay <= 0 || c.maxReconnectDelay < c.minReconnectDelay {
+		return nil, errors.New("invalid reconnect delay: min must be positive and not above max")
+	}
+	return c, nil
+}
+
+// APIError is returned when the API responds with a non-2xx status.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("dashboard API error: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// IsNotFound reports whether err is an APIError with status 404.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+func (c *Client) newRequest(ctx context.Context, method string, path string, query url.Values) (*http.Request, error) {
+	u := c.baseURL.JoinPath(path)
+	u.RawQuery = query.Encode()
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	return req, nil
+}
+
+// getJSON performs a GET request and decodes the JSON response into v.
+func (c *Client) getJSON(ctx context.Context, path string, query url.Values, v interface{}) error {
+	req, err := c.newRequest(ctx, http.MethodGet, path, query)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp); err != nil {
+		return err
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// checkResponse returns an APIError for non-2xx responses, using the message of the
+// API's {"error": "..."} body when there is one.
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+	var payload struct {
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(body, &payload) == nil && payload.Error != "" {
+		apiErr.Message = payload.Error
+	} else {
+		apiErr.Message = strings.TrimSpace(string(body))
+	}
+	return apiErr
+}
@@ -0,0 +1,431 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/sessions"
+
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/handler"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/middleware"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/model"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/repository"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/service"
+	"github.com/hirokazuyamada/github-events-dashboard-poc/backend/internal/sse"
+)
+
+const testToken = "secret"
+
+var eventColumns = []string{
+	"id", "delivery_id", "event_type", "action", "repo_name", "sender_login", "sender_avatar_url",
+	"title", "body", "html_url", "event_data", "occurred_at", "received_at", "created_at",
+}
+
+type staticTokens map[string]int64
+
+func (s staticTokens) Authenticate(token string) (int64, error) {
+	return s[token], nil
+}
+
+// testServer serves the real event handlers behind the real auth middleware, backed by a
+// mocked database and a running SSE hub.
+type testServer struct {
+	*httptest.Server
+	mock        sqlmock.Sqlmock
+	hub         *sse.Hub
+	connections atomic.Int32
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	hub := sse.NewHub(sse.HubOptions{})
+	go hub.Run()
+
+	ts := &testServer{mock: mock, hub: hub}
+	eventService := service.NewEventService(repository.NewEventRepository(db))
+	eventsHandler := handler.NewEventsHandler(eventService)
+	sseHandler := handler.NewSSEHandler(hub, eventService, handler.SSEOptions{})
+	r := chi.NewRouter()
+	r.Use(middleware.Auth(sessions.NewCookieStore([]byte("0123456789abcdef0123456789abcdef")), staticTokens{testToken: 1}))
+	r.Get("/api/events", eventsHandler.List)
+	r.Get("/api/events/{id}", eventsHandler.GetByID)
+	r.Get("/api/events/stream", func(w http.ResponseWriter, r *http.Request) {
+		ts.connections.Add(1)
+		sseHandler.ServeHTTP(w, r)
+	})
+	r.Put("/api/events/stream/subscriptions/{id}", sseHandler.UpdateSubscription)
+	ts.Server = httptest.NewServer(r)
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func (ts *testServer) client(t *testing.T, opts ...Option) *Client {
+	t.Helper()
+	opts = append([]Option{WithToken(testToken), WithReconnectDelay(10*time.Millisecond, 50*time.Millisecond)}, opts...)
+	c, err := New(ts.URL, opts...)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return c
+}
+
+func eventRows(ids ...int64) *sqlmock.Rows {
+	rows := sqlmock.NewRows(eventColumns)
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	for _, id := range ids {
+		at := base.Add(time.Duration(id) * time.Minute)
+		rows.AddRow(id, fmt.Sprintf("delivery-%d", id), "issues", "opened", "acme/api", "alice", nil,
+			fmt.Sprintf("Issue %d", id), nil, "https://github.com/acme/api/issues/1", nil, at, at, at)
+	}
+	return rows
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name        string
+		baseURL     string
+		opts        []Option
+		expectedErr bool
+	}{
+		{name: "valid", baseURL: "http://localhost:8080"},
+		{name: "trailing slash", baseURL: "https://dashboard.example.com/"},
+		{name: "relative", baseURL: "/api", expectedErr: true},
+		{name: "unsupported scheme", baseURL: "ftp://example.com", expectedErr: true},
+		{name: "invalid reconnect delay", baseURL: "http://localhost", opts: []Option{WithReconnectDelay(time.Second, time.Millisecond)}, expectedErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.baseURL, tt.opts...)
+			if (err != nil) != tt.expectedErr {
+				t.Errorf("expected error %v from New(), got %v", tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestListOptionsValues(t *testing.T) {
+	opts := ListOptions{
+		Repos:        []string{"acme/api", "acme/web"},
+		Senders:      []string{"alice"},
+		Since:        time.Date(2026, 1, 2, 3, 4, 5, 0, time.FixedZone("JST", 9*60*60)),
+		Query:        "is:merged",
+		PerPage:      50,
+		Cursor:       "abc",
+		IncludeTotal: true,
+	}
+	expected := "cursor=abc&include_total=true&pagination=cursor&per_page=50&q=is%3Amerged&repo=acme%2Fapi&repo=acme%2Fweb&sender=alice&since=2026-01-01T18%3A04%3A05Z"
+	if got := opts.values().Encode(); got != expected {
+		t.Errorf("expected values() to be %s, got %s", expected, got)
+	}
+}
+
+func TestListAndGetEvents(t *testing.T) {
+	ts := newTestServer(t)
+	c := ts.client(t)
+	ctx := context.Background()
+
+	t.Run("iterates all pages", func(t *testing.T) {
+		ts.mock.ExpectQuery(`FROM events WHERE repo_name = \? ORDER BY received_at DESC, id DESC LIMIT \?`).
+			WithArgs("acme/api", 3).
+			WillReturnRows(eventRows(5, 4, 3))
+		ts.mock.ExpectQuery(`FROM events WHERE repo_name = \? AND \(received_at < \? OR \(received_at = \? AND id < \?\)\)`).
+			WithArgs("acme/api", sqlmock.AnyArg(), sqlmock.AnyArg(), 4, 3).
+			WillReturnRows(eventRows(3))
+
+		var ids []int64
+		for event, err := range c.AllEvents(ctx, ListOptions{Repos: []string{"acme/api"}, PerPage: 2}) {
+			if err != nil {
+				t.Fatalf("AllEvents() error = %v", err)
+			}
+			ids = append(ids, event.ID)
+		}
+		if fmt.Sprint(ids) != "[5 4 3]" {
+			t.Errorf("expected AllEvents() ids to be [5 4 3], got %v", ids)
+		}
+	})
+
+	t.Run("get event", func(t *testing.T) {
+		ts.mock.ExpectQuery(`FROM events WHERE id = \?`).WithArgs(7).WillReturnRows(eventRows(7))
+		event, err := c.GetEvent(ctx, 7)
+		if err != nil {
+			t.Fatalf("GetEvent() error = %v", err)
+		}
+		if event.ID != 7 || event.Title == nil || *event.Title != "Issue 7" {
+			t.Errorf("GetEvent() = %+v", event)
+		}
+	})
+
+	t.Run("missing event", func(t *testing.T) {
+		ts.mock.ExpectQuery(`FROM events WHERE id = \?`).WithArgs(8).WillReturnError(sql.ErrNoRows)
+		_, err := c.GetEvent(ctx, 8)
+		if !IsNotFound(err) {
+			t.Errorf("expected GetEvent() error to be not found, got %v", err)
+		}
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		_, err := c.ListEvents(ctx, ListOptions{Query: `"unterminated`})
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+			t.Errorf("expected ListEvents() error to be 400, got %v", err)
+		}
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		_, err := ts.client(t, WithToken("wrong")).ListEvents(ctx, ListOptions{})
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized || apiErr.Message != "invalid API token" {
+			t.Errorf("expected ListEvents() error to be 401 invalid API token, got %v", err)
+		}
+	})
+
+	if err := ts.mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStreamEvents(t *testing.T) {
+	ts := newTestServer(t)
+	c := ts.client(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := c.StreamEvents(ctx, StreamOptions{})
+	if err != nil {
+		t.Fatalf("StreamEvents() error = %v", err)
+	}
+	defer stream.Close()
+
+	waitFor(t, func() bool { return ts.hub.ClientCount() == 1 })
+	ts.hub.Broadcast(model.Event{ID: 1, RepoName: "acme/api"})
+	if event := receive(t, stream); event.ID != 1 {
+		t.Errorf("expected event 1, got %d", event.ID)
+	}
+
+	// Drop the connection; the stream reconnects from event 1, the server replays what it
+	// missed, and live delivery continues.
+	ts.mock.ExpectQuery(`FROM events WHERE id > \? ORDER BY id ASC`).
+		WithArgs(1, sqlmock.AnyArg()).
+		WillReturnRows(eventRows(2))
+	ts.CloseClientConnections()
+	if event := receive(t, stream); event.ID != 2 {
+		t.Errorf("expected replayed event 2 after reconnect, got %d", event.ID)
+	}
+	waitFor(t, func() bool { return ts.connections.Load() == 2 && ts.hub.ClientCount() == 1 })
+	ts.hub.Broadcast(model.Event{ID: 3, RepoName: "acme/api"})
+	if event := receive(t, stream); event.ID != 3 {
+		t.Errorf("expected event 3 after reconnect, got %d", event.ID)
+	}
+	if stream.LastEventID() != "3" {
+		t.Errorf("expected LastEventID() to be 3, got %q", stream.LastEventID())
+	}
+	if err := ts.mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+
+	stream.Close()
+	for range stream.Events() {
+	}
+	if err := stream.Err(); err != nil {
+		t.Errorf("expected Err() after Close to be nil, got %v", err)
+	}
+}
+
+func TestStreamEventsFilter(t *testing.T) {
+	ts := newTestServer(t)
+	c := ts.client(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := c.StreamEvents(ctx, StreamOptions{Filter: ListOptions{Repos: []string{"acme/api"}, PerPage: 5}})
+	if err != nil {
+		t.Fatalf("StreamEvents() error = %v", err)
+	}
+	defer stream.Close()
+
+	waitFor(t, func() bool { return ts.hub.ClientCount() == 1 })
+	ts.hub.Broadcast(model.Event{ID: 1, RepoName: "acme/web"})
+	ts.hub.Broadcast(model.Event{ID: 2, RepoName: "acme/api"})
+	if event := receive(t, stream); event.ID != 2 {
+		t.Errorf("expected event 2, got %d", event.ID)
+	}
+
+	if err := stream.SetFilter(ctx, ListOptions{Repos: []string{"acme/web"}}); err != nil {
+		t.Fatalf("SetFilter() error = %v", err)
+	}
+	ts.hub.Broadcast(model.Event{ID: 3, RepoName: "acme/api"})
+	ts.hub.Broadcast(model.Event{ID: 4, RepoName: "acme/web"})
+	if event := receive(t, stream); event.ID != 4 {
+		t.Errorf("expected event 4 after SetFilter, got %d", event.ID)
+	}
+	if got := ts.connections.Load(); got != 1 {
+		t.Errorf("expected connections to be 1, got %d", got)
+	}
+}
+
+func TestStreamEventsRejected(t *testing.T) {
+	ts := newTestServer(t)
+	_, err := ts.client(t, WithToken("wrong")).StreamEvents(context.Background(), StreamOptions{})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected StreamEvents() error to be 401, got %v", err)
+	}
+}
+
+func TestStreamEventsResumesWithLastEventID(t *testing.T) {
+	var lastEventIDs []string
+	var connections atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastEventIDs = append(lastEventIDs, r.Header.Get("Last-Event-ID"))
+		w.Header().Set("Content-Type", "text/event-stream")
+		switch connections.Add(1) {
+		case 1:
+			// An event, then an incomplete frame whose id must not be recorded.
+			fmt.Fprint(w, "retry: 10\n\nid: 10\nevent: new_event\ndata: {\"id\":10}\n\nid: 11\nevent: new_event\n")
+		case 2:
+			fmt.Fprint(w, ": keep-alive\n\nid: 11\nevent: new_event\ndata: {\"id\":11}\n\n")
+		case 3:
+			http.Error(w, `{"error":"invalid API token"}`, http.StatusUnauthorized)
+		}
+	}))
+	defer srv.Close()
+
+	c, err := New(srv.URL, WithReconnectDelay(10*time.Millisecond, 50*time.Millisecond))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	stream, err := c.StreamEvents(context.Background(), StreamOptions{LastEventID: "9"})
+	if err != nil {
+		t.Fatalf("StreamEvents() error = %v", err)
+	}
+	var ids []int64
+	for event := range stream.Events() {
+		ids = append(ids, event.ID)
+	}
+	if fmt.Sprint(ids) != "[10 11]" {
+		t.Errorf("expected ids [10 11], got %v", ids)
+	}
+	if fmt.Sprint(lastEventIDs) != "[9 10 11]" {
+		t.Errorf("expected Last-Event-ID headers to be [9 10 11], got %v", lastEventIDs)
+	}
+	var apiErr *APIError
+	if !errors.As(stream.Err(), &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected Err() to be 401, got %v", stream.Err())
+	}
+	if stream.LastEventID() != "11" {
+		t.Errorf("expected LastEventID() to be 11, got %q", stream.LastEventID())
+	}
+}
+
+func TestStreamEventsReconnectsImmediatelyWhenAsked(t *testing.T) {
+	var connections atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		if connections.Add(1) == 1 {
+			// The retry hint would delay an ordinary reconnection by a minute.
+			fmt.Fprint(w, "retry: 60000\n\nid: 1\nevent: new_event\ndata: {\"id\":1}\n\nevent: reconnect\ndata: {}\n\n")
+			return
+		}
+		fmt.Fprintf(w, "id: 2\nevent: new_event\ndata: {\"id\":2}\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	c, err := New(srv.URL)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	stream, err := c.StreamEvents(context.Background(), StreamOptions{})
+	if err != nil {
+		t.Fatalf("StreamEvents() error = %v", err)
+	}
+	defer stream.Close()
+	for _, expected := range []int64{1, 2} {
+		if event := receive(t, stream); event.ID != expected {
+			t.Errorf("expected event %d, got %d", expected, event.ID)
+		}
+	}
+}
+
+func TestStreamEventsBacksOffAfterReconnect(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Connection", "close")
+		fmt.Fprint(w, "id: 1\nevent: new_event\ndata: {\"id\":1}\n\nevent: reconnect\ndata: {}\n\n")
+	}))
+	defer srv.Close()
+	var dials atomic.Int32
+	dialer := &net.Dialer{}
+	transport := &http.Transport{DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+		dials.Add(1)
+		return dialer.DialContext(ctx, network, addr)
+	}}
+
+	c, err := New(srv.URL, WithHTTPClient(&http.Client{Transport: transport}), WithReconnectDelay(50*time.Millisecond, 200*time.Millisecond))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stream, err := c.StreamEvents(context.Background(), StreamOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer stream.Close()
+	receive(t, stream)
+	// From now on every connection attempt is refused.
+	srv.Listener.Close()
+	time.Sleep(500 * time.Millisecond)
+
+	// One dial for the first connection, one immediate attempt after reconnect, then
+	// attempts after 50ms, 100ms and 200ms.
+	if got := dials.Load(); got > 6 {
+		t.Errorf("expected at most 6 connection attempts, got %d", got)
+	}
+	select {
+	case _, ok := <-stream.Events():
+		if !ok {
+			t.Errorf("expected the stream to keep retrying, got %v", stream.Err())
+		}
+	default:
+	}
+}
+
+func receive(t *testing.T, stream *EventStream) Event {
+	t.Helper()
+	select {
+	case event, ok := <-stream.Events():
+		if !ok {
+			t.Fatalf("stream ended: %v", stream.Err())
+		}
+		return event
+	case <-time.After(3 * time.Second):
+		t.Fatal("timed out waiting for an event")
+	}
+	return Event{}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(3 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for condition")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
@@ -0,0 +1,131 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// ListOptions filters and paginates event lists. They map to the GET /api/events query
+// parameters; zero values mean no restriction.
+type ListOptions struct {
+	EventTypes []string
+	Repos      []string
+	// Owners are repository owners (users or organizations).
+	Owners  []string
+	Senders []string
+	Actions []string
+	// Since and Until bound received_at; Until is exclusive.
+	Since time.Time
+	Until time.Time
+	// Query is a search string, e.g. "is:merged repo:acme/api flaky".
+	Query string
+	// PerPage is the page size (server default 20, max 100).
+	PerPage int
+	// Cursor continues a list from a previous page's NextCursor or PrevCursor.
+	Cursor string
+	// IncludeTotal asks the server to count all matching events.
+	IncludeTotal bool
+}
+
+func (o ListOptions) values() url.Values {
+	v := o.filterValues()
+	v.Set("pagination", "cursor")
+	if o.PerPage > 0 {
+		v.Set("per_page", strconv.Itoa(o.PerPage))
+	}
+	if o.Cursor != "" {
+		v.Set("cursor", o.Cursor)
+	}
+	if o.IncludeTotal {
+		v.Set("include_total", "true")
+	}
+	return v
+}
+
+// filterValues returns the query parameters of the filter fields only.
+func (o ListOptions) filterValues() url.Values {
+	v := url.Values{}
+	lists := []struct {
+		key    string
+		values []string
+	}{
+		{"event_type", o.EventTypes},
+		{"repo", o.Repos},
+		{"owner", o.Owners},
+		{"sender", o.Senders},
+		{"action", o.Actions},
+	}
+	for _, l := range lists {
+		for _, value := range l.values {
+			v.Add(l.key, value)
+		}
+	}
+	if !o.Since.IsZero() {
+		v.Set("since", o.Since.UTC().Format(time.RFC3339Nano))
+	}
+	if !o.Until.IsZero() {
+		v.Set("until", o.Until.UTC().Format(time.RFC3339Nano))
+	}
+	if o.Query != "" {
+		v.Set("q", o.Query)
+	}
+	return v
+}
+
+// ListEvents returns one page of events matching opts, newest first.
+func (c *Client) ListEvents(ctx context.Context, opts ListOptions) (*EventList, error) {
+	var list EventList
+	if err := c.getJSON(ctx, "/api/events", opts.values(), &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// GetEvent returns a single event. A missing event is an APIError for which IsNotFound
+// reports true.
+func (c *Client) GetEvent(ctx context.Context, id int64) (*Event, error) {
+	var event Event
+	if err := c.getJSON(ctx, "/api/events/"+strconv.FormatInt(id, 10), nil, &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// EventPages iterates over the pages of events matching opts, starting at opts.Cursor and
+// following NextCursor until the last page. Iteration stops after the first error.
+func (c *Client) EventPages(ctx context.Context, opts ListOptions) iter.Seq2[*EventList, error] {
+	return func(yield func(*EventList, error) bool) {
+		for {
+			page, err := c.ListEvents(ctx, opts)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(page, nil) || page.Pagination.NextCursor == nil {
+				return
+			}
+			opts.Cursor = *page.Pagination.NextCursor
+		}
+	}
+}
+
+// AllEvents iterates over every event matching opts across all pages, newest first.
+// Iteration stops after the first error.
+func (c *Client) AllEvents(ctx context.Context, opts ListOptions) iter.Seq2[Event, error] {
+	return func(yield func(Event, error) bool) {
+		for page, err := range c.EventPages(ctx, opts) {
+			if err != nil {
+				yield(Event{}, err)
+				return
+			}
+			for _, event := range page.Events {
+				if !yield(event, nil) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,295 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// streamEventName is the SSE event type carrying a model.Event.
+	streamEventName = "new_event"
+	// subscribedEventName is the SSE event type opening a stream with its subscription ID.
+	subscribedEventName = "subscribed"
+	// reconnectEventName is the SSE event type the server sends before closing a stream
+	// that reached its maximum lifetime.
+	reconnectEventName = "reconnect"
+	// resyncEventName is the SSE event type the server sends before closing a stream whose
+	// client fell behind; reconnecting from the last received event catches up.
+	resyncEventName = "resync"
+	// maxStreamLineBytes limits the length of a single SSE line.
+	maxStreamLineBytes = 4 << 20
+)
+
+// StreamOptions configures an event stream.
+type StreamOptions struct {
+	// LastEventID resumes a previous stream after the event with this SSE id.
+	LastEventID string
+	// Filter limits the stream to matching events. Only the filter fields are used;
+	// PerPage, Cursor and IncludeTotal are ignored.
+	Filter ListOptions
+}
+
+// EventStream delivers events from GET /api/events/stream. When the connection drops it
+// reconnects with exponential backoff, sending the id of the last received event as
+// Last-Event-ID so the server can replay what was missed.
+type EventStream struct {
+	client *Client
+	events chan Event
+	cancel context.CancelFunc
+
+	mu             sync.Mutex
+	err            error
+	lastEventID    string
+	retry          time.Duration
+	filter         url.Values
+	subscriptionID string
+}
+
+// StreamEvents connects to the event stream. It returns an error if the first connection
+// attempt fails; later disconnections are retried until ctx is cancelled, Close is
+// called, or the server rejects the request (e.g. 401 for a revoked token).
+func (c *Client) StreamEvents(ctx context.Context, opts StreamOptions) (*EventStream, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	s := &EventStream{
+		client:      c,
+		events:      make(chan Event),
+		cancel:      cancel,
+		lastEventID: opts.LastEventID,
+		filter:      opts.Filter.filterValues(),
+	}
+	resp, err := c.connectStream(ctx, opts.LastEventID, s.filter)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	go s.run(ctx, resp)
+	return s, nil
+}
+
+// SetFilter changes the stream's filter without reconnecting; events received by the
+// server from then on are matched against the new filter. The filter is also used for
+// any later reconnection.
+func (s *EventStream) SetFilter(ctx context.Context, filter ListOptions) error {
+	values := filter.filterValues()
+	s.mu.Lock()
+	s.filter = values
+	id := s.subscriptionID
+	s.mu.Unlock()
+	if id == "" {
+		// Between connections: the next one uses the new filter.
+		return nil
+	}
+	req, err := s.client.newRequest(ctx, http.MethodPut, "/api/events/stream/subscriptions/"+id, values)
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+	err = checkResponse(resp)
+	if IsNotFound(err) {
+		// The connection just ended; the next one uses the new filter.
+		return nil
+	}
+	return err
+}
+
+// Events returns the channel of received events. It is closed when the stream ends;
+// Err then reports why.
+func (s *EventStream) Events() <-chan Event {
+	return s.events
+}
+
+// Err returns the error that ended the stream, or nil if it was closed by the caller.
+func (s *EventStream) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// LastEventID returns the id of the last received event, for resuming in a later stream.
+func (s *EventStream) LastEventID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastEventID
+}
+
+// Close stops the stream and closes the Events channel.
+func (s *EventStream) Close() {
+	s.cancel()
+}
+
+func (s *EventStream) run(ctx context.Context, resp *http.Response) {
+	defer close(s.events)
+	defer s.cancel()
+	c := s.client
+	delay := c.minReconnectDelay
+	for {
+		if resp != nil {
+			reconnect := s.read(ctx, resp.Body)
+			resp.Body.Close()
+			s.mu.Lock()
+			s.subscriptionID = ""
+			s.mu.Unlock()
+			delay = s.reconnectDelay(c.minReconnectDelay)
+			if reconnect {
+				// A planned close: resume right away.
+				delay = 0
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		s.mu.Lock()
+		lastEventID, filter := s.lastEventID, s.filter
+		s.mu.Unlock()
+		var err error
+		resp, err = c.connectStream(ctx, lastEventID, filter)
+		if isPermanent(err) {
+			s.mu.Lock()
+			s.err = err
+			s.mu.Unlock()
+			return
+		}
+		if err != nil {
+			resp = nil
+			// Back off from at least the minimum, also after an immediate reconnection.
+			delay = min(max(delay*2, c.minReconnectDelay), c.maxReconnectDelay)
+		}
+	}
+}
+
+// reconnectDelay returns the server-sent retry interval, or def if there was none.
+func (s *EventStream) reconnectDelay(def time.Duration) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.retry > 0 {
+		return s.retry
+	}
+	return def
+}
+
+// read parses SSE frames from body and delivers new_event frames until body ends,
+// recording the subscription ID of the subscribed frame. It reports whether the server
+// asked the client to reconnect.
+func (s *EventStream) read(ctx context.Context, body io.Reader) bool {
+	reconnect := false
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64<<10), maxStreamLineBytes)
+	var eventType, id string
+	var data []string
+	hasID := false
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			if hasID {
+				s.mu.Lock()
+				s.lastEventID = id
+				s.mu.Unlock()
+			}
+			if eventType == reconnectEventName || eventType == resyncEventName {
+				reconnect = true
+			}
+			if eventType == subscribedEventName && len(data) > 0 {
+				var sub struct {
+					SubscriptionID string `json:"subscription_id"`
+				}
+				if err := json.Unmarshal([]byte(strings.Join(data, "\n")), &sub); err == nil {
+					s.mu.Lock()
+					s.subscriptionID = sub.SubscriptionID
+					s.mu.Unlock()
+				}
+			}
+			if eventType == streamEventName && len(data) > 0 {
+				var event Event
+				if err := json.Unmarshal([]byte(strings.Join(data, "\n")), &event); err == nil {
+					select {
+					case s.events <- event:
+					case <-ctx.Done():
+						return false
+					}
+				}
+			}
+			eventType, data, hasID = "", nil, false
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			eventType = value
+		case "data":
+			data = append(data, value)
+		case "id":
+			if !strings.ContainsRune(value, 0) {
+				id, hasID = value, true
+			}
+		case "retry":
+			if ms, err := strconv.Atoi(value); err == nil && ms > 0 {
+				s.mu.Lock()
+				s.retry = time.Duration(ms) * time.Millisecond
+				s.mu.Unlock()
+			}
+		}
+	}
+	return reconnect
+}
+
+func (c *Client) connectStream(ctx context.Context, lastEventID string, filter url.Values) (*http.Response, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/api/events/stream", filter)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Cache-Control", "no-cache")
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to event stream: %w", err)
+	}
+	if err := checkResponse(resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType != "text/event-stream" {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected event stream content type %q", resp.Header.Get("Content-Type"))
+	}
+	return resp, nil
+}
+
+// isPermanent reports whether a connection error should end the stream instead of being
+// retried: client errors other than timeouts and rate limiting.
+func isPermanent(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.StatusCode >= 400 && apiErr.StatusCode < 500 &&
+		apiErr.StatusCode != http.StatusRequestTimeout && apiErr.StatusCode != http.StatusTooManyRequests
+}
@@ -0,0 +1,599 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: dashboard/v1/events.proto
+
+package dashboardv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Event is a GitHub webhook event.
+type Event struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	DeliveryId      string                 `protobuf:"bytes,2,opt,name=delivery_id,json=deliveryId,proto3" json:"delivery_id,omitempty"`
+	EventType       string                 `protobuf:"bytes,3,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	Action          string                 `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`
+	RepoName        string                 `protobuf:"bytes,5,opt,name=repo_name,json=repoName,proto3" json:"repo_name,omitempty"`
+	SenderLogin     string                 `protobuf:"bytes,6,opt,name=sender_login,json=senderLogin,proto3" json:"sender_login,omitempty"`
+	SenderAvatarUrl *string                `protobuf:"bytes,7,opt,name=sender_avatar_url,json=senderAvatarUrl,proto3,oneof" json:"sender_avatar_url,omitempty"`
+	Title           *string                `protobuf:"bytes,8,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	Body            *string                `protobuf:"bytes,9,opt,name=body,proto3,oneof" json:"body,omitempty"`
+	HtmlUrl         string                 `protobuf:"bytes,10,opt,name=html_url,json=htmlUrl,proto3" json:"html_url,omitempty"`
+	// Event-type specific data as a JSON document.
+	EventData     *string                `protobuf:"bytes,11,opt,name=event_data,json=eventData,proto3,oneof" json:"event_data,omitempty"`
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	ReceivedAt    *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=received_at,json=receivedAt,proto3" json:"received_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_dashboard_v1_events_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_dashboard_v1_events_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_dashboard_v1_events_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Event) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Event) GetDeliveryId() string {
+	if x != nil {
+		return x.DeliveryId
+	}
+	return ""
+}
+
+func (x *Event) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *Event) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *Event) GetRepoName() string {
+	if x != nil {
+		return x.RepoName
+	}
+	return ""
+}
+
+func (x *Event) GetSenderLogin() string {
+	if x != nil {
+		return x.SenderLogin
+	}
+	return ""
+}
+
+func (x *Event) GetSenderAvatarUrl() string {
+	if x != nil && x.SenderAvatarUrl != nil {
+		return *x.SenderAvatarUrl
+	}
+	return ""
+}
+
+func (x *Event) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
+	}
+	return ""
+}
+
+func (x *Event) GetBody() string {
+	if x != nil && x.Body != nil {
+		return *x.Body
+	}
+	return ""
+}
+
+func (x *Event) GetHtmlUrl() string {
+	if x != nil {
+		return x.HtmlUrl
+	}
+	return ""
+}
+
+func (x *Event) GetEventData() string {
+	if x != nil && x.EventData != nil {
+		return *x.EventData
+	}
+	return ""
+}
+
+func (x *Event) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *Event) GetReceivedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReceivedAt
+	}
+	return nil
+}
+
+// EventFilter narrows down events; it is equivalent to the GET /api/events query parameters.
+// Each repeated field accepts at most 20 values.
+type EventFilter struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	EventTypes []string               `protobuf:"bytes,1,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	Repos      []string               `protobuf:"bytes,2,rep,name=repos,proto3" json:"repos,omitempty"`
+	// Repository owners (users or organizations).
+	Owners  []string `protobuf:"bytes,3,rep,name=owners,proto3" json:"owners,omitempty"`
+	Senders []string `protobuf:"bytes,4,rep,name=senders,proto3" json:"senders,omitempty"`
+	Actions []string `protobuf:"bytes,5,rep,name=actions,proto3" json:"actions,omitempty"`
+	// Only events received at or after this time.
+	Since *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=since,proto3" json:"since,omitempty"`
+	// Only events received before this time.
+	Until *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=until,proto3" json:"until,omitempty"`
+	// A search string in the /api/events q syntax, e.g. "is:merged repo:acme/api flaky".
+	Q             string `protobuf:"bytes,8,opt,name=q,proto3" json:"q,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EventFilter) Reset() {
+	*x = EventFilter{}
+	mi := &file_dashboard_v1_events_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventFilter) ProtoMessage() {}
+
+func (x *EventFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_dashboard_v1_events_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventFilter.ProtoReflect.Descriptor instead.
+func (*EventFilter) Descriptor() ([]byte, []int) {
+	return file_dashboard_v1_events_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *EventFilter) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *EventFilter) GetRepos() []string {
+	if x != nil {
+		return x.Repos
+	}
+	return nil
+}
+
+func (x *EventFilter) GetOwners() []string {
+	if x != nil {
+		return x.Owners
+	}
+	return nil
+}
+
+func (x *EventFilter) GetSenders() []string {
+	if x != nil {
+		return x.Senders
+	}
+	return nil
+}
+
+func (x *EventFilter) GetActions() []string {
+	if x != nil {
+		return x.Actions
+	}
+	return nil
+}
+
+func (x *EventFilter) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *EventFilter) GetUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Until
+	}
+	return nil
+}
+
+func (x *EventFilter) GetQ() string {
+	if x != nil {
+		return x.Q
+	}
+	return ""
+}
+
+type ListEventsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Filter *EventFilter           `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	// Number of events to return: default 20, max 100.
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token from a previous response, or a cursor from GET /api/events.
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEventsRequest) Reset() {
+	*x = ListEventsRequest{}
+	mi := &file_dashboard_v1_events_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEventsRequest) ProtoMessage() {}
+
+func (x *ListEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dashboard_v1_events_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListEventsRequest) Descriptor() ([]byte, []int) {
+	return file_dashboard_v1_events_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListEventsRequest) GetFilter() *EventFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ListEventsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListEventsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListEventsResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Events []*Event               `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	// Token for the next page; empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEventsResponse) Reset() {
+	*x = ListEventsResponse{}
+	mi := &file_dashboard_v1_events_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEventsResponse) ProtoMessage() {}
+
+func (x *ListEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dashboard_v1_events_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListEventsResponse) Descriptor() ([]byte, []int) {
+	return file_dashboard_v1_events_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListEventsResponse) GetEvents() []*Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *ListEventsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type GetEventRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEventRequest) Reset() {
+	*x = GetEventRequest{}
+	mi := &file_dashboard_v1_events_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEventRequest) ProtoMessage() {}
+
+func (x *GetEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dashboard_v1_events_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEventRequest.ProtoReflect.Descriptor instead.
+func (*GetEventRequest) Descriptor() ([]byte, []int) {
+	return file_dashboard_v1_events_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetEventRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type SubscribeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filter        *EventFilter           `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeRequest) Reset() {
+	*x = SubscribeRequest{}
+	mi := &file_dashboard_v1_events_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeRequest) ProtoMessage() {}
+
+func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dashboard_v1_events_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return file_dashboard_v1_events_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SubscribeRequest) GetFilter() *EventFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+var File_dashboard_v1_events_proto protoreflect.FileDescriptor
+
+const file_dashboard_v1_events_proto_rawDesc = "" +
+	"\n" +
+	"\x19dashboard/v1/events.proto\x12\fdashboard.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x85\x04\n" +
+	"\x05Event\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1f\n" +
+	"\vdelivery_id\x18\x02 \x01(\tR\n" +
+	"deliveryId\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x03 \x01(\tR\teventType\x12\x16\n" +
+	"\x06action\x18\x04 \x01(\tR\x06action\x12\x1b\n" +
+	"\trepo_name\x18\x05 \x01(\tR\brepoName\x12!\n" +
+	"\fsender_login\x18\x06 \x01(\tR\vsenderLogin\x12/\n" +
+	"\x11sender_avatar_url\x18\a \x01(\tH\x00R\x0fsenderAvatarUrl\x88\x01\x01\x12\x19\n" +
+	"\x05title\x18\b \x01(\tH\x01R\x05title\x88\x01\x01\x12\x17\n" +
+	"\x04body\x18\t \x01(\tH\x02R\x04body\x88\x01\x01\x12\x19\n" +
+	"\bhtml_url\x18\n" +
+	" \x01(\tR\ahtmlUrl\x12\"\n" +
+	"\n" +
+	"event_data\x18\v \x01(\tH\x03R\teventData\x88\x01\x01\x12;\n" +
+	"\voccurred_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12;\n" +
+	"\vreceived_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"receivedAtB\x14\n" +
+	"\x12_sender_avatar_urlB\b\n" +
+	"\x06_titleB\a\n" +
+	"\x05_bodyB\r\n" +
+	"\v_event_data\"\x82\x02\n" +
+	"\vEventFilter\x12\x1f\n" +
+	"\vevent_types\x18\x01 \x03(\tR\n" +
+	"eventTypes\x12\x14\n" +
+	"\x05repos\x18\x02 \x03(\tR\x05repos\x12\x16\n" +
+	"\x06owners\x18\x03 \x03(\tR\x06owners\x12\x18\n" +
+	"\asenders\x18\x04 \x03(\tR\asenders\x12\x18\n" +
+	"\aactions\x18\x05 \x03(\tR\aactions\x120\n" +
+	"\x05since\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x120\n" +
+	"\x05until\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x05until\x12\f\n" +
+	"\x01q\x18\b \x01(\tR\x01q\"\x82\x01\n" +
+	"\x11ListEventsRequest\x121\n" +
+	"\x06filter\x18\x01 \x01(\v2\x19.dashboard.v1.EventFilterR\x06filter\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"i\n" +
+	"\x12ListEventsResponse\x12+\n" +
+	"\x06events\x18\x01 \x03(\v2\x13.dashboard.v1.EventR\x06events\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"!\n" +
+	"\x0fGetEventRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"E\n" +
+	"\x10SubscribeRequest\x121\n" +
+	"\x06filter\x18\x01 \x01(\v2\x19.dashboard.v1.EventFilterR\x06filter2\xe3\x01\n" +
+	"\fEventService\x12O\n" +
+	"\n" +
+	"ListEvents\x12\x1f.dashboard.v1.ListEventsRequest\x1a .dashboard.v1.ListEventsResponse\x12>\n" +
+	"\bGetEvent\x12\x1d.dashboard.v1.GetEventRequest\x1a\x13.dashboard.v1.Event\x12B\n" +
+	"\tSubscribe\x12\x1e.dashboard.v1.SubscribeRequest\x1a\x13.dashboard.v1.Event0\x01B^Z\\github.com/hirokazuyamada/github-events-dashboard-poc/backend/proto/dashboard/v1;dashboardv1b\x06proto3"
+
+var (
+	file_dashboard_v1_events_proto_rawDescOnce sync.Once
+	file_dashboard_v1_events_proto_rawDescData []byte
+)
+
+func file_dashboard_v1_events_proto_rawDescGZIP() []byte {
+	file_dashboard_v1_events_proto_rawDescOnce.Do(func() {
+		file_dashboard_v1_events_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_dashboard_v1_events_proto_rawDesc), len(file_dashboard_v1_events_proto_rawDesc)))
+	})
+	return file_dashboard_v1_events_proto_rawDescData
+}
+
+var file_dashboard_v1_events_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_dashboard_v1_events_proto_goTypes = []any{
+	(*Event)(nil),                 // 0: dashboard.v1.Event
+	(*EventFilter)(nil),           // 1: dashboard.v1.EventFilter
+	(*ListEventsRequest)(nil),     // 2: dashboard.v1.ListEventsRequest
+	(*ListEventsResponse)(nil),    // 3: dashboard.v1.ListEventsResponse
+	(*GetEventRequest)(nil),       // 4: dashboard.v1.GetEventRequest
+	(*SubscribeRequest)(nil),      // 5: dashboard.v1.SubscribeRequest
+	(*timestamppb.Timestamp)(nil), // 6: google.protobuf.Timestamp
+}
+var file_dashboard_v1_events_proto_depIdxs = []int32{
+	6,  // 0: dashboard.v1.Event.occurred_at:type_name -> google.protobuf.Timestamp
+	6,  // 1: dashboard.v1.Event.received_at:type_name -> google.protobuf.Timestamp
+	6,  // 2: dashboard.v1.EventFilter.since:type_name -> google.protobuf.Timestamp
+	6,  // 3: dashboard.v1.EventFilter.until:type_name -> google.protobuf.Timestamp
+	1,  // 4: dashboard.v1.ListEventsRequest.filter:type_name -> dashboard.v1.EventFilter
+	0,  // 5: dashboard.v1.ListEventsResponse.events:type_name -> dashboard.v1.Event
+	1,  // 6: dashboard.v1.SubscribeRequest.filter:type_name -> dashboard.v1.EventFilter
+	2,  // 7: dashboard.v1.EventService.ListEvents:input_type -> dashboard.v1.ListEventsRequest
+	4,  // 8: dashboard.v1.EventService.GetEvent:input_type -> dashboard.v1.GetEventRequest
+	5,  // 9: dashboard.v1.EventService.Subscribe:input_type -> dashboard.v1.SubscribeRequest
+	3,  // 10: dashboard.v1.EventService.ListEvents:output_type -> dashboard.v1.ListEventsResponse
+	0,  // 11: dashboard.v1.EventService.GetEvent:output_type -> dashboard.v1.Event
+	0,  // 12: dashboard.v1.EventService.Subscribe:output_type -> dashboard.v1.Event
+	10, // [10:13] is the sub-list for method output_type
+	7,  // [7:10] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_dashboard_v1_events_proto_init() }
+func file_dashboard_v1_events_proto_init() {
+	if File_dashboard_v1_events_proto != nil {
+		return
+	}
+	file_dashboard_v1_events_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_dashboard_v1_events_proto_rawDesc), len(file_dashboard_v1_events_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_dashboard_v1_events_proto_goTypes,
+		DependencyIndexes: file_dashboard_v1_events_proto_depIdxs,
+		MessageInfos:      file_dashboard_v1_events_proto_msgTypes,
+	}.Build()
+	File_dashboard_v1_events_proto = out.File
+	file_dashboard_v1_events_proto_goTypes = nil
+	file_dashboard_v1_events_proto_depIdxs = nil
+}
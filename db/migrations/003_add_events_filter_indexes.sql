@@ -0,0 +1,3 @@
+ALTER TABLE events
+    ADD INDEX idx_sender_login (sender_login),
+    ADD INDEX idx_repo_received_at (repo_name, received_at);
@@ -0,0 +1,2 @@
+ALTER TABLE events
+    ADD FULLTEXT INDEX ft_title_body (title, body);
@@ -0,0 +1,4 @@
+ALTER TABLE events
+    ADD COLUMN pr_number INT UNSIGNED
+        GENERATED ALWAYS AS (CAST(JSON_UNQUOTE(JSON_EXTRACT(event_data, '$.pull_request_number')) AS UNSIGNED)) VIRTUAL,
+    ADD INDEX idx_repo_pr_number (repo_name, pr_number);
@@ -0,0 +1,4 @@
+ALTER TABLE events
+    ADD COLUMN issue_number INT UNSIGNED
+        GENERATED ALWAYS AS (CAST(JSON_UNQUOTE(JSON_EXTRACT(event_data, '$.issue_number')) AS UNSIGNED)) VIRTUAL,
+    ADD INDEX idx_repo_issue_number (repo_name, issue_number);
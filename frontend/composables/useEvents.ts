@@ -13,6 +13,8 @@ interface UseEventsReturn {
   setPage: (page: number) => void
   setFilter: (eventType: string) => void
   prependEvent: (event: Event) => void
+  updateEvent: (event: Event) => void
+  removeEvent: (id: number) => void
 }
 
 /**
@@ -91,10 +93,32 @@ export const useEvents = (): UseEventsReturn => {
     if (filterType.value && event.event_type !== filterType.value) {
       return
     }
+    // Events replayed after a reconnect may already have been fetched.
+    if (events.value.some((e) => e.id === event.id)) {
+      return
+    }
     events.value = [event, ...events.value]
     pagination.value.total += 1
   }
 
+  const updateEvent = (event: Event): void => {
+    events.value = events.value.map((e) => (e.id === event.id ? event : e))
+    if (selectedEvent.value?.id === event.id) {
+      selectedEvent.value = event
+    }
+  }
+
+  const removeEvent = (id: number): void => {
+    if (!events.value.some((e) => e.id === id)) {
+      return
+    }
+    events.value = events.value.filter((e) => e.id !== id)
+    pagination.value.total -= 1
+    if (selectedEvent.value?.id === id) {
+      selectedEvent.value = null
+    }
+  }
+
   return {
     events,
     pagination,
@@ -107,5 +131,7 @@ export const useEvents = (): UseEventsReturn => {
     setPage,
     setFilter,
     prependEvent,
+    updateEvent,
+    removeEvent,
   }
 }
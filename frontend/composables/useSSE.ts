@@ -1,8 +1,15 @@
 import { ref, onUnmounted } from 'vue'
 import type { Event } from '~/types/event'
+import type { PresenceResponse } from '~/types/presence'
 
 interface UseSSEOptions {
   onNewEvent: (event: Event) => void
+  onEventUpdated?: (event: Event) => void
+  onEventDeleted?: (event: Event) => void
+  // Receives the users online on the dashboard whenever someone comes or goes.
+  onPresence?: (presence: PresenceResponse) => void
+  // Returns the stream filters (the /api/events query parameters) to apply on the server.
+  getFilter?: () => Record<string, string>
   onSessionExpired?: () => void
   onReconnect?: () => void
 }
@@ -11,6 +18,7 @@ interface UseSSEReturn {
   connected: ReturnType<typeof ref<boolean>>
   connect: () => void
   disconnect: () => void
+  updateFilter: () => Promise<void>
 }
 
 const RECONNECT_DELAY_MS = 3000
@@ -27,25 +35,94 @@ export const useSSE = (options: UseSSEOptions): UseSSEReturn => {
   let eventSource: EventSource | null = null
   let reconnectDelay = RECONNECT_DELAY_MS
   let reconnectTimer: ReturnType<typeof setTimeout> | null = null
+  // ID of the last received event; the server replays newer events on reconnect.
+  let lastEventId = ''
+  // ID of the open stream's subscription, used to change its filters in place.
+  let subscriptionId = ''
 
   const connect = (): void => {
     if (eventSource) {
       eventSource.close()
     }
-    const url = `${apiBase}/api/events/stream`
+    subscriptionId = ''
+    const params = new URLSearchParams(options.getFilter ? options.getFilter() : {})
+    if (lastEventId) {
+      params.set('last_event_id', lastEventId)
+    }
+    const query = params.toString()
+    const url = `${apiBase}/api/events/stream${query ? `?${query}` : ''}`
     eventSource = new EventSource(url, { withCredentials: true })
     eventSource.addEventListener('open', () => {
       connected.value = true
       reconnectDelay = RECONNECT_DELAY_MS
     })
+    eventSource.addEventListener('subscribed', (e: MessageEvent) => {
+      try {
+        subscriptionId = JSON.parse(e.data).subscription_id
+      } catch {
+        console.error('Failed to parse SSE subscription')
+      }
+    })
     eventSource.addEventListener('new_event', (e: MessageEvent) => {
       try {
         const event: Event = JSON.parse(e.data)
+        if (e.lastEventId) {
+          lastEventId = e.lastEventId
+        }
         options.onNewEvent(event)
       } catch {
         console.error('Failed to parse SSE event data')
       }
     })
+    eventSource.addEventListener('event_updated', (e: MessageEvent) => {
+      try {
+        options.onEventUpdated?.(JSON.parse(e.data))
+      } catch {
+        console.error('Failed to parse SSE event data')
+      }
+    })
+    eventSource.addEventListener('event_deleted', (e: MessageEvent) => {
+      try {
+        options.onEventDeleted?.(JSON.parse(e.data))
+      } catch {
+        console.error('Failed to parse SSE event data')
+      }
+    })
+    eventSource.addEventListener('presence', (e: MessageEvent) => {
+      try {
+        options.onPresence?.(JSON.parse(e.data))
+      } catch {
+        console.error('Failed to parse SSE presence data')
+      }
+    })
+    // Sent before a server that is shutting down closes the stream; reconnecting after the
+    // usual delay reaches another replica and replays what was missed.
+    eventSource.addEventListener('server_shutdown', () => {
+      connected.value = false
+      if (eventSource) {
+        eventSource.close()
+        eventSource = null
+      }
+      scheduleReconnect()
+    })
+    // Sent before the server closes a stream that reached its maximum lifetime; events
+    // received meanwhile are replayed from lastEventId, so no refetch is needed.
+    eventSource.addEventListener('reconnect', () => {
+      connect()
+    })
+    // Sent before the server drops a stream that fell behind; reconnecting replays the
+    // events after the last one delivered.
+    eventSource.addEventListener('resync', (e: MessageEvent) => {
+      try {
+        const { last_event_id: id }: { last_event_id: number } = JSON.parse(e.data)
+        if (id > 0) {
+          lastEventId = String(id)
+        }
+      } catch {
+        console.error('Failed to parse SSE resync data')
+      }
+      connect()
+    })
     eventSource.addEventListener('session_expired', () => {
       disconnect()
       if (options.onSessionExpired) {
@@ -74,6 +151,26 @@ export const useSSE = (options: UseSSEOptions): UseSSEReturn => {
     connected.value = false
   }
 
+  /**
+   * Applies the current filters to the open stream without reconnecting. Without an open
+   * stream the next connection picks them up.
+   */
+  const updateFilter = async (): Promise<void> => {
+    if (!subscriptionId) {
+      return
+    }
+    const params = new URLSearchParams(options.getFilter ? options.getFilter() : {})
+    try {
+      await $fetch(`${apiBase}/api/events/stream/subscriptions/${subscriptionId}?${params.toString()}`, {
+        method: 'PUT',
+        credentials: 'include',
+      })
+    } catch {
+      // The stream ended meanwhile; reconnect so the new connection uses the filters.
+      connect()
+    }
+  }
+
   const scheduleReconnect = (): void => {
     if (reconnectTimer) {
       return
@@ -96,5 +193,6 @@ export const useSSE = (options: UseSSEOptions): UseSSEReturn => {
     connected,
     connect,
     disconnect,
+    updateFilter,
   }
 }
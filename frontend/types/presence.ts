@@ -0,0 +1,11 @@
+export interface PresenceUser {
+  id: number
+  login: string
+  display_name: string
+  avatar_url: string | null
+  connections: number
+}
+
+export interface PresenceResponse {
+  users: PresenceUser[]
+}